@@ -0,0 +1,6 @@
+-- Remove upload status index
+DROP INDEX IF EXISTS idx_cloud_files_upload_status ON cloud_files;
+
+-- Remove upload status column from cloud_files table
+ALTER TABLE cloud_files
+DROP COLUMN IF EXISTS upload_status;
@@ -0,0 +1,7 @@
+-- Add upload status for two-phase upload commit (pending -> committed/failed)
+-- Existing rows were uploaded before verification existed, so they are treated as committed
+ALTER TABLE cloud_files
+ADD COLUMN upload_status VARCHAR(20) NOT NULL DEFAULT 'committed' COMMENT 'pending, committed, failed';
+
+-- Index for filtering visible files and sweeping stale pending uploads
+CREATE INDEX idx_cloud_files_upload_status ON cloud_files(upload_status);
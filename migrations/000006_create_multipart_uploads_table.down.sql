@@ -0,0 +1,3 @@
+-- Drop multipart upload tables (parts first due to foreign key)
+DROP TABLE IF EXISTS multipart_upload_parts;
+DROP TABLE IF EXISTS multipart_uploads;
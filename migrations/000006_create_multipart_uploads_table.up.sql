@@ -0,0 +1,41 @@
+-- Track S3 multipart uploads for large files so clients can resume and expired uploads can be aborted
+CREATE TABLE multipart_uploads (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT UNSIGNED NOT NULL,
+  file_id BIGINT UNSIGNED NOT NULL,
+  s3_upload_id VARCHAR(1024) NOT NULL,
+  s3_key VARCHAR(512) NOT NULL,
+  part_size BIGINT NOT NULL,
+  total_parts INT NOT NULL,
+  status VARCHAR(20) NOT NULL COMMENT 'in_progress, completed, aborted',
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- One multipart upload per file
+  UNIQUE KEY uniq_multipart_file (file_id),
+
+  -- Optimize ownership checks and the expiration sweep
+  INDEX idx_multipart_user (user_id),
+  INDEX idx_multipart_status_expires (status, expires_at),
+
+  CONSTRAINT fk_multipart_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Parts confirmed in S3 for each multipart upload
+CREATE TABLE multipart_upload_parts (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  multipart_upload_id BIGINT UNSIGNED NOT NULL,
+  part_number INT NOT NULL,
+  etag VARCHAR(128) NOT NULL,
+  size BIGINT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- Re-uploaded parts overwrite the previous record
+  UNIQUE KEY uniq_upload_part (multipart_upload_id, part_number),
+
+  CONSTRAINT fk_part_upload FOREIGN KEY (multipart_upload_id)
+    REFERENCES multipart_uploads(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Rollback: Drop tus uploads table
+DROP TABLE IF EXISTS tus_uploads;
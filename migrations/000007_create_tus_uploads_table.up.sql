@@ -0,0 +1,27 @@
+-- Track tus resumable uploads streamed by the server into S3 multipart uploads
+CREATE TABLE tus_uploads (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id BIGINT UNSIGNED NOT NULL,
+  file_id BIGINT UNSIGNED NOT NULL,
+  s3_upload_id VARCHAR(1024) NOT NULL,
+  s3_key VARCHAR(512) NOT NULL,
+  upload_length BIGINT NOT NULL,
+  upload_offset BIGINT NOT NULL DEFAULT 0,
+  part_count INT NOT NULL DEFAULT 0,
+  incomplete_part_size BIGINT NOT NULL DEFAULT 0 COMMENT 'bytes held in the {s3_key}.part object',
+  metadata TEXT NULL COMMENT 'raw Upload-Metadata header',
+  status VARCHAR(20) NOT NULL COMMENT 'in_progress, completed, aborted',
+  expires_at DATETIME NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- One tus upload per file
+  UNIQUE KEY uniq_tus_file (file_id),
+
+  -- Optimize ownership checks and the expiration sweep
+  INDEX idx_tus_user (user_id),
+  INDEX idx_tus_status_expires (status, expires_at),
+
+  CONSTRAINT fk_tus_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
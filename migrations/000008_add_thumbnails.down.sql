@@ -0,0 +1,8 @@
+-- Drop generated thumbnails table
+DROP TABLE IF EXISTS file_thumbnails;
+
+-- Remove thumbnail status index and column
+DROP INDEX IF EXISTS idx_cloud_files_thumbnail_status ON cloud_files;
+
+ALTER TABLE cloud_files
+DROP COLUMN IF EXISTS thumbnail_status;
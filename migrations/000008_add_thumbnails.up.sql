@@ -0,0 +1,25 @@
+-- Track server-side thumbnail generation on cloud_files
+-- Existing rows start as pending so the backfill job generates their thumbnails
+ALTER TABLE cloud_files
+ADD COLUMN thumbnail_status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT 'pending, ready, failed, unsupported';
+
+-- Index for the thumbnail backfill job
+CREATE INDEX idx_cloud_files_thumbnail_status ON cloud_files(thumbnail_status);
+
+-- Generated thumbnails, one row per file and size
+CREATE TABLE file_thumbnails (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  file_id BIGINT UNSIGNED NOT NULL,
+  size INT NOT NULL COMMENT 'bounding box in px (longest edge)',
+  s3_key VARCHAR(512) NOT NULL,
+  width INT NOT NULL,
+  height INT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- Regenerating a size replaces the previous row
+  UNIQUE KEY uniq_file_thumbnail_size (file_id, size),
+
+  CONSTRAINT fk_thumbnail_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,11 @@
+-- Remove video metadata indexes
+DROP INDEX IF EXISTS idx_cloud_files_duration ON cloud_files;
+DROP INDEX IF EXISTS idx_cloud_files_metadata_status ON cloud_files;
+
+-- Remove video metadata columns
+ALTER TABLE cloud_files
+DROP COLUMN IF EXISTS metadata_status,
+DROP COLUMN IF EXISTS rotation,
+DROP COLUMN IF EXISTS video_codec,
+DROP COLUMN IF EXISTS height,
+DROP COLUMN IF EXISTS width;
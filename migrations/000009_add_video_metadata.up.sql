@@ -0,0 +1,14 @@
+-- Server-extracted video metadata on cloud_files
+-- Existing rows start as pending so the backfill job extracts their metadata
+ALTER TABLE cloud_files
+ADD COLUMN width INT NULL COMMENT 'frame width in px, before rotation',
+ADD COLUMN height INT NULL COMMENT 'frame height in px, before rotation',
+ADD COLUMN video_codec VARCHAR(50) NULL,
+ADD COLUMN rotation INT NOT NULL DEFAULT 0 COMMENT 'clockwise display rotation in degrees',
+ADD COLUMN metadata_status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT 'pending, ready, failed, unsupported';
+
+-- Index for the processing backfill job
+CREATE INDEX idx_cloud_files_metadata_status ON cloud_files(metadata_status);
+
+-- Index for duration filtering and sorting
+CREATE INDEX idx_cloud_files_duration ON cloud_files(duration);
@@ -0,0 +1,7 @@
+-- Drop EXIF metadata table
+DROP TABLE IF EXISTS file_exif;
+
+-- Images without an extractor are unsupported again
+UPDATE cloud_files
+SET metadata_status = 'unsupported'
+WHERE file_type = 'image';
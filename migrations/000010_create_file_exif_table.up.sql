@@ -0,0 +1,32 @@
+-- EXIF metadata extracted from images, one row per file
+CREATE TABLE file_exif (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  file_id BIGINT UNSIGNED NOT NULL,
+  taken_at DATETIME NULL COMMENT 'capture time in UTC; camera wall clock when no offset was recorded',
+  camera_make VARCHAR(100) NULL,
+  camera_model VARCHAR(100) NULL,
+  lens_model VARCHAR(150) NULL,
+  exposure_time DOUBLE NULL COMMENT 'seconds',
+  f_number DECIMAL(5,2) NULL,
+  iso INT NULL,
+  focal_length DECIMAL(7,2) NULL COMMENT 'millimeters',
+  orientation INT NOT NULL DEFAULT 0 COMMENT 'EXIF orientation 1-8, 0 if absent',
+  latitude DOUBLE NULL,
+  longitude DOUBLE NULL,
+  altitude DOUBLE NULL COMMENT 'meters above sea level',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY idx_file_exif_file_id (file_id),
+
+  -- Index for the capture date timeline (sort=taken, taken date range)
+  INDEX idx_file_exif_taken_at (taken_at),
+
+  CONSTRAINT fk_exif_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Images were marked unsupported before EXIF extraction existed; queue them for the backfill job
+UPDATE cloud_files
+SET metadata_status = 'pending'
+WHERE file_type = 'image' AND metadata_status = 'unsupported' AND content_type IN ('image/jpeg', 'image/png', 'image/webp');
@@ -0,0 +1,5 @@
+-- Remove content hash index and column
+DROP INDEX IF EXISTS idx_cloud_files_user_sha256 ON cloud_files;
+
+ALTER TABLE cloud_files
+DROP COLUMN IF EXISTS sha256;
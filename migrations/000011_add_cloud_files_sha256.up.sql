@@ -0,0 +1,6 @@
+-- Content hash for upload deduplication
+ALTER TABLE cloud_files
+ADD COLUMN sha256 CHAR(64) NULL COMMENT 'hex SHA-256 of the content, enforced by S3 on single uploads';
+
+-- Index for duplicate lookups per user
+CREATE INDEX idx_cloud_files_user_sha256 ON cloud_files(user_id, sha256);
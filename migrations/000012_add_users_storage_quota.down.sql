@@ -0,0 +1,4 @@
+-- Remove storage quota columns
+ALTER TABLE users
+DROP COLUMN IF EXISTS storage_limit,
+DROP COLUMN IF EXISTS storage_used;
@@ -0,0 +1,14 @@
+-- Storage quota, maintained incrementally by uploads and deletes
+ALTER TABLE users
+ADD COLUMN storage_used BIGINT NOT NULL DEFAULT 0 COMMENT 'bytes reserved by pending uploads and held by committed files',
+ADD COLUMN storage_limit BIGINT NOT NULL DEFAULT 16106127360 COMMENT 'quota in bytes (15GB)';
+
+-- Backfill from files that are live or still uploading
+UPDATE users
+JOIN (
+    SELECT user_id, SUM(file_size) AS total
+    FROM cloud_files
+    WHERE deleted_at IS NULL AND upload_status IN ('pending', 'committed')
+    GROUP BY user_id
+) usage_totals ON usage_totals.user_id = users.id
+SET users.storage_used = usage_totals.total;
@@ -0,0 +1,3 @@
+-- Remove trash usage column
+ALTER TABLE users
+DROP COLUMN IF EXISTS trash_used;
@@ -0,0 +1,9 @@
+-- Trash: deleted files keep their objects until purged and are tracked separately
+ALTER TABLE users
+ADD COLUMN trash_used BIGINT NOT NULL DEFAULT 0 COMMENT 'bytes held by files in the trash, included in storage_used';
+
+-- Files deleted before the trash existed already lost their objects and released their storage,
+-- so mark them failed to keep them out of the trash listing and the storage accounting
+UPDATE cloud_files
+SET upload_status = 'failed'
+WHERE deleted_at IS NOT NULL;
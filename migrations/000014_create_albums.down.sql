@@ -0,0 +1,3 @@
+-- Drop album tables
+DROP TABLE IF EXISTS album_files;
+DROP TABLE IF EXISTS albums;
@@ -0,0 +1,37 @@
+-- Albums for organizing files; albums can be nested through parent_id
+CREATE TABLE albums (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  parent_id BIGINT UNSIGNED NULL COMMENT 'NULL for top-level albums',
+  name VARCHAR(255) NOT NULL,
+  cover_file_id BIGINT UNSIGNED NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  -- Optimize listing one level of a user's albums
+  INDEX idx_albums_user_parent (user_id, parent_id),
+
+  CONSTRAINT fk_album_user FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_album_parent FOREIGN KEY (parent_id)
+    REFERENCES albums(id) ON DELETE CASCADE,
+  -- Purging the cover file leaves the album without a cover
+  CONSTRAINT fk_album_cover_file FOREIGN KEY (cover_file_id)
+    REFERENCES cloud_files(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Files in albums, in manual order
+CREATE TABLE album_files (
+  album_id BIGINT UNSIGNED NOT NULL,
+  file_id BIGINT UNSIGNED NOT NULL,
+  position INT NOT NULL COMMENT 'manual order within the album, ascending',
+  added_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  PRIMARY KEY (album_id, file_id),
+  INDEX idx_album_files_file_id (file_id),
+
+  CONSTRAINT fk_album_files_album FOREIGN KEY (album_id)
+    REFERENCES albums(id) ON DELETE CASCADE,
+  CONSTRAINT fk_album_files_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
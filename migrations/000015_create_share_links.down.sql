@@ -0,0 +1,4 @@
+-- Drop share link tables
+DROP TABLE IF EXISTS share_link_accesses;
+DROP TABLE IF EXISTS share_link_files;
+DROP TABLE IF EXISTS share_links;
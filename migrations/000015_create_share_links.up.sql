@@ -0,0 +1,47 @@
+-- Public share links for one or more files
+CREATE TABLE share_links (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  token_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hex of the token; the token itself is not stored',
+  password_hash VARCHAR(255) NULL COMMENT 'bcrypt, empty when the link has no password',
+  expires_at DATETIME NULL,
+  max_downloads INT NULL,
+  download_count INT NOT NULL DEFAULT 0,
+  revoked_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uniq_share_links_token_hash (token_hash),
+  INDEX idx_share_links_user_id (user_id),
+
+  CONSTRAINT fk_share_link_user FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Files shared by a link
+CREATE TABLE share_link_files (
+  share_link_id BIGINT UNSIGNED NOT NULL,
+  file_id BIGINT UNSIGNED NOT NULL,
+
+  PRIMARY KEY (share_link_id, file_id),
+  INDEX idx_share_link_files_file_id (file_id),
+
+  CONSTRAINT fk_share_link_files_link FOREIGN KEY (share_link_id)
+    REFERENCES share_links(id) ON DELETE CASCADE,
+  CONSTRAINT fk_share_link_files_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Every attempt to open an existing share link
+CREATE TABLE share_link_accesses (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  share_link_id BIGINT UNSIGNED NOT NULL,
+  outcome VARCHAR(20) NOT NULL COMMENT 'granted, revoked, expired, limit_reached, password_required, wrong_password, no_files',
+  ip_address VARCHAR(45) NULL,
+  user_agent VARCHAR(255) NULL,
+  accessed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  INDEX idx_share_link_accessed_at (share_link_id, accessed_at),
+
+  CONSTRAINT fk_share_link_accesses_link FOREIGN KEY (share_link_id)
+    REFERENCES share_links(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
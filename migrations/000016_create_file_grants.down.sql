@@ -0,0 +1,2 @@
+-- Drop file grants table
+DROP TABLE IF EXISTS file_grants;
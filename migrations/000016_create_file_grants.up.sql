@@ -0,0 +1,21 @@
+-- Access to a file granted by its owner to another user
+CREATE TABLE file_grants (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  file_id BIGINT UNSIGNED NOT NULL,
+  owner_id INT NOT NULL,
+  grantee_id INT NOT NULL,
+  permission VARCHAR(20) NOT NULL COMMENT 'view, download or edit',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uniq_file_grantee (file_id, grantee_id),
+  INDEX idx_file_grants_owner_id (owner_id),
+  INDEX idx_file_grants_grantee_created_at (grantee_id, created_at),
+
+  CONSTRAINT fk_file_grants_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE,
+  CONSTRAINT fk_file_grants_owner FOREIGN KEY (owner_id)
+    REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT fk_file_grants_grantee FOREIGN KEY (grantee_id)
+    REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
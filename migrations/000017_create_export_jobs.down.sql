@@ -0,0 +1,2 @@
+-- Drop export jobs table
+DROP TABLE IF EXISTS export_jobs;
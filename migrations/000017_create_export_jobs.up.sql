@@ -0,0 +1,23 @@
+-- Archive exports of many files, built in the background and kept in S3 until expires_at
+CREATE TABLE export_jobs (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT NOT NULL,
+  status VARCHAR(20) NOT NULL COMMENT 'pending, running, completed or failed',
+  file_ids MEDIUMTEXT NOT NULL COMMENT 'JSON array of the selected file IDs',
+  file_count INT NOT NULL,
+  total_size BIGINT NOT NULL,
+  s3_key VARCHAR(512) NULL,
+  archive_size BIGINT NOT NULL DEFAULT 0,
+  error VARCHAR(500) NULL,
+  started_at DATETIME NULL,
+  completed_at DATETIME NULL,
+  expires_at DATETIME NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+  INDEX idx_export_jobs_user_id (user_id),
+  INDEX idx_export_jobs_status (status),
+  INDEX idx_export_jobs_expires_at (expires_at),
+
+  CONSTRAINT fk_export_jobs_user FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
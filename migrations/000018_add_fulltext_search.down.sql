@@ -0,0 +1,6 @@
+-- Remove FULLTEXT indexes and the description column
+DROP INDEX ftx_tags_name ON tags;
+DROP INDEX ftx_cloud_files_search ON cloud_files;
+
+ALTER TABLE cloud_files
+DROP COLUMN description;
@@ -0,0 +1,9 @@
+-- Free-text caption of a file, searched together with its name
+ALTER TABLE cloud_files
+ADD COLUMN description VARCHAR(2000) NOT NULL DEFAULT '' COMMENT 'caption set by the user' AFTER file_name;
+
+-- FULLTEXT indexes for keyword search. The ngram parser indexes every run of ngram_token_size (default 2) characters,
+-- so Korean text without spaces between words can be searched. Disable innodb_ft_enable_stopword on the server:
+-- the ngram parser drops every token that contains a stopword such as "a".
+CREATE FULLTEXT INDEX ftx_cloud_files_search ON cloud_files(file_name, description) WITH PARSER ngram;
+CREATE FULLTEXT INDEX ftx_tags_name ON tags(name) WITH PARSER ngram;
@@ -0,0 +1,4 @@
+-- Remove file listing indexes
+DROP INDEX idx_cloud_files_user_file_size ON cloud_files;
+DROP INDEX idx_cloud_files_user_file_name ON cloud_files;
+DROP INDEX idx_cloud_files_user_created_at ON cloud_files;
@@ -0,0 +1,5 @@
+-- Indexes for keyset (cursor) pagination of file listings. InnoDB appends the primary key to secondary indexes,
+-- so each one also covers the id tie-breaker.
+CREATE INDEX idx_cloud_files_user_created_at ON cloud_files(user_id, created_at);
+CREATE INDEX idx_cloud_files_user_file_name ON cloud_files(user_id, file_name);
+CREATE INDEX idx_cloud_files_user_file_size ON cloud_files(user_id, file_size);
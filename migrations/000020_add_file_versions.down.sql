@@ -0,0 +1,6 @@
+-- Drop file versions and the version columns of cloud_files
+DROP TABLE IF EXISTS file_versions;
+
+ALTER TABLE cloud_files
+DROP COLUMN version_created_at,
+DROP COLUMN version;
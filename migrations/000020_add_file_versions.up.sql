@@ -0,0 +1,30 @@
+-- Version number of the current content of a file and when that content was uploaded
+ALTER TABLE cloud_files
+ADD COLUMN version INT NOT NULL DEFAULT 1 AFTER sha256,
+ADD COLUMN version_created_at DATETIME NULL COMMENT 'upload time of the current version; NULL means created_at' AFTER version;
+
+-- Content of a file other than its current one. Making a version current swaps its content with the file's,
+-- so each number stays with the same content. Pending and archived versions count against the owner's storage.
+CREATE TABLE file_versions (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  file_id BIGINT UNSIGNED NOT NULL,
+  user_id INT NOT NULL COMMENT 'owner of the file',
+  version INT NOT NULL DEFAULT 0 COMMENT '0 while pending',
+  s3_key VARCHAR(512) NOT NULL,
+  file_size BIGINT NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  sha256 CHAR(64) NULL,
+  status VARCHAR(20) NOT NULL COMMENT 'pending, archived or failed',
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'when this content was uploaded',
+  archived_at DATETIME NULL COMMENT 'when it stopped being the current content',
+
+  UNIQUE KEY uniq_file_versions_s3_key (s3_key),
+  INDEX idx_file_versions_file_status (file_id, status),
+  INDEX idx_file_versions_user_id (user_id),
+  INDEX idx_file_versions_archived_at (archived_at),
+
+  CONSTRAINT fk_file_versions_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE,
+  CONSTRAINT fk_file_versions_user FOREIGN KEY (user_id)
+    REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
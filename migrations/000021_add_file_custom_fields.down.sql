@@ -0,0 +1,3 @@
+-- Remove custom fields of files
+ALTER TABLE cloud_files
+DROP COLUMN custom_fields;
@@ -0,0 +1,3 @@
+-- User-defined key/value pairs of a file, edited with PATCH /api/v1/files/:id
+ALTER TABLE cloud_files
+ADD COLUMN custom_fields JSON NULL COMMENT 'object of string values set by the user' AFTER description;
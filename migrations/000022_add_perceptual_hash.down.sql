@@ -0,0 +1,6 @@
+-- Remove image analysis from cloud_files
+DROP INDEX idx_cloud_files_analysis_status ON cloud_files;
+
+ALTER TABLE cloud_files
+DROP COLUMN perceptual_hash,
+DROP COLUMN analysis_status;
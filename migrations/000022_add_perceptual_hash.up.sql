@@ -0,0 +1,8 @@
+-- Server-computed image analysis on cloud_files, used to find similar images
+-- Existing rows start as pending so the backfill job analyzes them
+ALTER TABLE cloud_files
+ADD COLUMN analysis_status VARCHAR(20) NOT NULL DEFAULT 'pending' COMMENT 'pending, ready, failed, unsupported' AFTER metadata_status,
+ADD COLUMN perceptual_hash BIGINT UNSIGNED NULL COMMENT '64-bit difference hash, compared by Hamming distance' AFTER analysis_status;
+
+-- Index for the processing backfill job
+CREATE INDEX idx_cloud_files_analysis_status ON cloud_files(analysis_status);
@@ -0,0 +1,4 @@
+-- Remove image quality scores from cloud_files
+ALTER TABLE cloud_files
+DROP COLUMN exposure_score,
+DROP COLUMN sharpness_score;
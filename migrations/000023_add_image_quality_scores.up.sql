@@ -0,0 +1,7 @@
+-- Server-measured image quality on cloud_files, used by the quality=low filter
+ALTER TABLE cloud_files
+ADD COLUMN sharpness_score DOUBLE NULL COMMENT 'variance of the Laplacian at 1024px, below 100 is blurry' AFTER perceptual_hash,
+ADD COLUMN exposure_score DOUBLE NULL COMMENT '0-1 from the brightness histogram, below 0.3 is badly exposed' AFTER sharpness_score;
+
+-- Analyze already hashed images again so the backfill job measures their quality
+UPDATE cloud_files SET analysis_status = 'pending' WHERE analysis_status = 'ready';
@@ -0,0 +1,2 @@
+-- Drop image color palettes
+DROP TABLE IF EXISTS file_colors;
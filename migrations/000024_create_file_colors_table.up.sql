@@ -0,0 +1,18 @@
+-- Dominant colors of images found by k-means, up to five rows per file
+CREATE TABLE file_colors (
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  file_id BIGINT UNSIGNED NOT NULL,
+  hex VARCHAR(7) NOT NULL COMMENT 'sRGB, e.g. #3a7bd5',
+  lab_l DOUBLE NOT NULL COMMENT 'CIELAB lightness',
+  lab_a DOUBLE NOT NULL COMMENT 'CIELAB green-red axis',
+  lab_b DOUBLE NOT NULL COMMENT 'CIELAB blue-yellow axis',
+  weight DOUBLE NOT NULL COMMENT 'share of the image pixels, 0-1',
+
+  INDEX idx_file_colors_file_id (file_id),
+
+  CONSTRAINT fk_color_file FOREIGN KEY (file_id)
+    REFERENCES cloud_files(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Analyze already analyzed images again so the backfill job finds their palette
+UPDATE cloud_files SET analysis_status = 'pending' WHERE analysis_status = 'ready';
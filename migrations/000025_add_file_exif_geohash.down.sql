@@ -0,0 +1,5 @@
+-- Remove the geohash of EXIF locations
+DROP INDEX idx_file_exif_geohash ON file_exif;
+
+ALTER TABLE file_exif
+DROP COLUMN geohash;
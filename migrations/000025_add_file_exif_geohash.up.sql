@@ -0,0 +1,11 @@
+-- Geohash of the EXIF GPS coordinates, aggregated by prefix for the file map
+ALTER TABLE file_exif
+ADD COLUMN geohash VARCHAR(12) NOT NULL DEFAULT '' COMMENT 'empty without GPS coordinates' AFTER altitude;
+
+-- Index for map clusters (prefix match on a cluster's geohash)
+CREATE INDEX idx_file_exif_geohash ON file_exif(geohash);
+
+-- Existing geotagged images; new ones get their geohash when their EXIF is extracted
+UPDATE file_exif
+SET geohash = ST_GeoHash(longitude, latitude, 12)
+WHERE latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180;
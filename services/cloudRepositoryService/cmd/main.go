@@ -12,11 +12,13 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	_ "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/docs"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/handler"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/job"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	"github.com/JokerTrickster/joker_backend/shared"
 	"github.com/JokerTrickster/joker_backend/shared/db/mysql"
@@ -33,6 +35,15 @@ func main() {
 	e, err := shared.Init(&shared.InitConfig{
 		LogLevel:    os.Getenv("LOG_LEVEL"),
 		Environment: os.Getenv("ENV"),
+		// tus PATCH requests stream file chunks and archive downloads stream a ZIP;
+		// both can outlive the default request timeout, which also buffers the whole response
+		TimeoutSkipper: func(c echo.Context) bool {
+			req := c.Request()
+			if req.Method == http.MethodPatch && strings.HasPrefix(req.URL.Path, "/api/v1/files/tus/") {
+				return true
+			}
+			return req.Method == http.MethodPost && req.URL.Path == "/api/v1/files/archive"
+		},
 	})
 	if err != nil {
 		panic("Failed to initialize: " + err.Error())
@@ -58,7 +69,7 @@ func main() {
 
 	// Auto-migrate database
 	logger.Info("Starting database migration...")
-	if err := database.AutoMigrate(&entity.CloudFile{}, &entity.Tag{}, &entity.ActivityLog{}); err != nil {
+	if err := database.AutoMigrate(&entity.CloudFile{}, &entity.Tag{}, &entity.ActivityLog{}, &entity.MultipartUpload{}, &entity.MultipartUploadPart{}, &entity.TusUpload{}, &entity.FileThumbnail{}, &entity.FileExif{}, &entity.Album{}, &entity.AlbumFile{}, &entity.ShareLink{}, &entity.ShareLinkFile{}, &entity.ShareLinkAccess{}, &entity.FileGrant{}, &entity.ExportJob{}, &entity.FileVersion{}, &entity.FileColor{}); err != nil {
 		logger.Fatal("Failed to migrate database", zap.Error(err))
 	}
 	logger.Info("Database migration completed successfully")
@@ -86,6 +97,13 @@ func main() {
 	}
 
 	handler.RegisterRoutes(api, database, bucket)
+	// Share links are opened by people without an account
+	handler.RegisterPublicRoutes(e, database, bucket)
+
+	// Start background jobs (pending upload sweeper, etc.)
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	job.Start(jobCtx, database, bucket)
 
 	// Swagger
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -115,6 +133,9 @@ func main() {
 
 	logger.Info("Shutting down server...")
 
+	// Stop background jobs
+	stopJobs()
+
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -0,0 +1,68 @@
+// Command reconcile compares cloud_files with the objects in the S3 bucket and prints a JSON report.
+// It runs in dry-run mode unless -dry-run=false is given.
+//
+//	go run ./cmd/reconcile                  # report every user
+//	go run ./cmd/reconcile -user 42         # report a single user
+//	go run ./cmd/reconcile -dry-run=false   # delete orphans, fail missing/corrupt files, regenerate thumbnails
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/repository"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/usecase"
+	"github.com/JokerTrickster/joker_backend/shared"
+	"github.com/JokerTrickster/joker_backend/shared/db/mysql"
+	"github.com/JokerTrickster/joker_backend/shared/logger"
+	"go.uber.org/zap"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", true, "only report issues, do not repair them")
+	userID := flag.Uint("user", 0, "reconcile a single user (0 = all users)")
+	flag.Parse()
+
+	if _, err := shared.Init(&shared.InitConfig{
+		LogLevel:    os.Getenv("LOG_LEVEL"),
+		Environment: os.Getenv("ENV"),
+	}); err != nil {
+		panic("Failed to initialize: " + err.Error())
+	}
+	defer shared.Cleanup()
+
+	bucket := os.Getenv("CLOUD_REPOSITORY_BUCKET")
+	if bucket == "" {
+		bucket = "joker-cloud-repository-dev"
+	}
+	if mysql.GormMysqlDB == nil {
+		logger.Fatal("Database connection is nil - check DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME environment variables")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	reconcileRepo := repository.NewReconcileCloudRepositoryRepository(mysql.GormMysqlDB, bucket)
+	reconcileUC := usecase.NewReconcileCloudRepositoryUseCase(reconcileRepo, usecase.ReconcileUserTimeout)
+
+	var report *response.ReconcileReportDTO
+	var err error
+	if *userID != 0 {
+		report, err = reconcileUC.ReconcileUser(ctx, *userID, *dryRun)
+	} else {
+		report, err = reconcileUC.Reconcile(ctx, *dryRun)
+	}
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(report)
+	}
+	if err != nil {
+		logger.Fatal("Storage reconciliation failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,330 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type AlbumHandler struct {
+	UseCase _interface.IAlbumUseCase
+}
+
+func NewAlbumHandler(c *echo.Group, useCase _interface.IAlbumUseCase) *AlbumHandler {
+	handler := &AlbumHandler{
+		UseCase: useCase,
+	}
+	c.POST("/albums", handler.CreateAlbum)
+	c.GET("/albums", handler.ListAlbums)
+	c.GET("/albums/:id", handler.GetAlbum)
+	c.PATCH("/albums/:id", handler.UpdateAlbum)
+	c.DELETE("/albums/:id", handler.DeleteAlbum)
+	c.POST("/albums/:id/files", handler.AddFiles)
+	c.PUT("/albums/:id/files/order", handler.ReorderFiles)
+	c.DELETE("/albums/:id/files/:fileId", handler.RemoveFile)
+	return handler
+}
+
+// CreateAlbum handles creating an album
+// @Summary Create album
+// @Description Create an album at the top level or inside another album
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param body body request.CreateAlbumRequestDTO true "Create album request"
+// @Success 201 {object} response.AlbumDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums [post]
+func (h *AlbumHandler) CreateAlbum(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.CreateAlbumRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.CreateAlbum(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListAlbums handles listing the albums at one level
+// @Summary List albums
+// @Description List the top-level albums, or the albums directly inside parent_id
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param parent_id query int false "Parent album ID (default: top level)"
+// @Success 200 {object} response.ListAlbumsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums [get]
+func (h *AlbumHandler) ListAlbums(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.ListAlbumsRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	resp, err := h.UseCase.ListAlbums(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetAlbum handles getting a single album
+// @Summary Get album
+// @Description Get an album with its path from the top level and its sub-albums. List its files with GET /api/v1/files?album_id={id}
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 200 {object} response.AlbumDetailResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id} [get]
+func (h *AlbumHandler) GetAlbum(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	resp, err := h.UseCase.GetAlbum(ctx, userID, uint(albumID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateAlbum handles renaming, moving or changing the cover of an album
+// @Summary Update album
+// @Description Rename an album, move it to another parent (0 = top level) or set its cover (0 = none). Omitted fields are unchanged
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param body body request.UpdateAlbumRequestDTO true "Update album request"
+// @Success 200 {object} response.AlbumDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id} [patch]
+func (h *AlbumHandler) UpdateAlbum(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	var req request.UpdateAlbumRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.UpdateAlbum(ctx, userID, uint(albumID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteAlbum handles deleting an album
+// @Summary Delete album
+// @Description Delete an album and all of its sub-albums. The files in them are not deleted
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id} [delete]
+func (h *AlbumHandler) DeleteAlbum(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	if err := h.UseCase.DeleteAlbum(ctx, userID, uint(albumID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AddFiles handles adding files to an album
+// @Summary Add files to album
+// @Description Append files to the end of an album. Files already in the album are skipped
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param body body request.AddAlbumFilesRequestDTO true "Add files request"
+// @Success 200 {object} response.AddAlbumFilesResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id}/files [post]
+func (h *AlbumHandler) AddFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	var req request.AddAlbumFilesRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.AddFiles(ctx, userID, uint(albumID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ReorderFiles handles setting the manual order of an album
+// @Summary Reorder album files
+// @Description Move the listed files to the front of the album in the given order. Other files keep their relative order after them
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param body body request.ReorderAlbumFilesRequestDTO true "Reorder request"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id}/files/order [put]
+func (h *AlbumHandler) ReorderFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	var req request.ReorderAlbumFilesRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if err := h.UseCase.ReorderFiles(ctx, userID, uint(albumID), req); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveFile handles removing a file from an album
+// @Summary Remove file from album
+// @Description Remove a file from an album. The file itself is not deleted
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Album ID"
+// @Param fileId path int true "File ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/albums/{id}/files/{fileId} [delete]
+func (h *AlbumHandler) RemoveFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	albumID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid album ID"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("fileId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	if err := h.UseCase.RemoveFile(ctx, userID, uint(albumID), uint(fileID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
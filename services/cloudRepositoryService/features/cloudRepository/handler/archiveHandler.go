@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type ArchiveHandler struct {
+	UseCase _interface.IArchiveUseCase
+}
+
+func NewArchiveHandler(c *echo.Group, useCase _interface.IArchiveUseCase) *ArchiveHandler {
+	handler := &ArchiveHandler{
+		UseCase: useCase,
+	}
+	c.POST("/files/archive", handler.CreateArchive)
+	c.GET("/exports", handler.ListExports)
+	c.GET("/exports/:id", handler.GetExport)
+	return handler
+}
+
+// CreateArchive handles downloading many files as one ZIP archive
+// @Summary Download files as ZIP
+// @Description Stream a ZIP archive of the selected files with a manifest.json describing them. Files are selected by file_ids or by tag. Files the user cannot download are skipped and listed in the manifest. Selections over 500 files or 2 GiB, or requests with async=true, create an export job instead (202) whose archive is downloaded from GET /api/v1/exports/{id}.
+// @Tags Archive
+// @Accept json
+// @Produce application/zip
+// @Param body body request.CreateArchiveRequestDTO true "Archive request"
+// @Success 200 {file} file "ZIP archive"
+// @Success 202 {object} response.ExportJobDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/archive [post]
+func (h *ArchiveHandler) CreateArchive(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.CreateArchiveRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	plan, export, err := h.UseCase.PrepareArchive(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+	if export != nil {
+		return c.JSON(http.StatusAccepted, export)
+	}
+
+	filename := fmt.Sprintf("files-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	res.Header().Set("Cache-Control", "no-store")
+	res.WriteHeader(http.StatusOK)
+
+	// The status is already sent, so a failure can only cut the archive short; the client sees a truncated ZIP
+	return h.UseCase.WriteArchive(ctx, plan, res)
+}
+
+// ListExports handles listing archive exports
+// @Summary List exports
+// @Description List archive exports of the authenticated user, newest first. Completed exports include a download URL until they expire.
+// @Tags Archive
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.ListExportJobsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/exports [get]
+func (h *ArchiveHandler) ListExports(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.ListExportsRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	resp, err := h.UseCase.ListExports(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetExport handles getting an archive export
+// @Summary Get export
+// @Description Get the status of an archive export, with a download URL valid for 1 hour once it is completed
+// @Tags Archive
+// @Accept json
+// @Produce json
+// @Param id path int true "Export ID"
+// @Success 200 {object} response.ExportJobDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/exports/{id} [get]
+func (h *ArchiveHandler) GetExport(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	exportID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid export ID"})
+	}
+
+	resp, err := h.UseCase.GetExport(ctx, userID, uint(exportID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -29,6 +29,7 @@ func NewBatchUploadCloudRepositoryHandler(c *echo.Group, useCase _interface.IBat
 // @Param body body request.BatchUploadRequestDTO true "Batch upload request"
 // @Success 200 {object} response.BatchUploadResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string "Storage quota exceeded"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/files/upload/batch [post]
 func (h *BatchUploadCloudRepositoryHandler) RequestBatchUploadURL(c echo.Context) error {
@@ -50,7 +51,7 @@ func (h *BatchUploadCloudRepositoryHandler) RequestBatchUploadURL(c echo.Context
 
 	resp, err := h.UseCase.RequestBatchUploadURL(ctx, userID, &req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type BulkFileHandler struct {
+	UseCase _interface.IBulkFileUseCase
+}
+
+func NewBulkFileHandler(c *echo.Group, useCase _interface.IBulkFileUseCase) *BulkFileHandler {
+	handler := &BulkFileHandler{
+		UseCase: useCase,
+	}
+	c.POST("/files/bulk", handler.Execute)
+	return handler
+}
+
+// Execute handles applying one operation to many files
+// @Summary Bulk file operation
+// @Description Delete, tag, untag, favorite or unfavorite up to 1000 files selected by file_ids or by a file listing filter. Files are changed in transactions of 100; the result of every file is reported.
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param body body request.BulkFileOperationRequestDTO true "Bulk operation request"
+// @Success 200 {object} response.BulkFileOperationResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/bulk [post]
+func (h *BulkFileHandler) Execute(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.BulkFileOperationRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.Execute(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -22,13 +22,14 @@ func NewDeleteCloudRepositoryHandler(c *echo.Group, useCase _interface.IDeleteCl
 
 // DeleteFile handles file deletion
 // @Summary Delete file
-// @Description Soft delete a file and remove it from S3
+// @Description Move a file to the trash. It can be restored until it is purged after the retention period
 // @Tags CloudRepository
 // @Accept json
 // @Produce json
 // @Param id path int true "File ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/files/{id} [delete]
@@ -46,8 +47,8 @@ func (h *DeleteCloudRepositoryHandler) DeleteFile(c echo.Context) error {
 	}
 
 	if err := h.UseCase.DeleteFile(ctx, userID, uint(fileID)); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{"message": "file deleted successfully"})
+	return c.JSON(http.StatusOK, map[string]string{"message": "file moved to trash"})
 }
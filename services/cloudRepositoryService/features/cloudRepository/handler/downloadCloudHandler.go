@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/labstack/echo/v4"
 )
 
@@ -17,6 +18,7 @@ func NewDownloadCloudRepositoryHandler(c *echo.Group, useCase _interface.IDownlo
 		UseCase: useCase,
 	}
 	c.GET("/files/:id/download", handler.RequestDownloadURL)
+	c.GET("/files/:id/thumbnail", handler.RequestThumbnailURL)
 	return handler
 }
 
@@ -29,6 +31,7 @@ func NewDownloadCloudRepositoryHandler(c *echo.Group, useCase _interface.IDownlo
 // @Param id path int true "File ID"
 // @Success 200 {object} response.DownloadResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/files/{id}/download [get]
@@ -47,7 +50,51 @@ func (h *DownloadCloudRepositoryHandler) RequestDownloadURL(c echo.Context) erro
 
 	resp, err := h.UseCase.RequestDownloadURL(ctx, userID, uint(fileID))
 	if err != nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RequestThumbnailURL handles the request for a server-generated thumbnail
+// @Summary Request thumbnail URL
+// @Description Get a presigned URL for a thumbnail generated by the server (256 or 1024 px)
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param size query int false "Thumbnail size (256 or 1024, default 256)"
+// @Success 200 {object} response.ThumbnailResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/thumbnail [get]
+func (h *DownloadCloudRepositoryHandler) RequestThumbnailURL(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	var req request.ThumbnailRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.RequestThumbnailURL(ctx, userID, uint(fileID), &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -3,7 +3,6 @@ package handler
 import (
 	"net/http"
 	"strconv"
-	"strings"
 
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
@@ -56,14 +55,7 @@ func (h *FavoriteHandler) AddFavorite(c echo.Context) error {
 
 	resp, err := h.UseCase.AddFavorite(ctx, userID, req.FileID)
 	if err != nil {
-		// Determine appropriate status code based on error message
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "not found") {
-			statusCode = http.StatusNotFound
-		} else if strings.Contains(err.Error(), "access denied") || strings.Contains(err.Error(), "do not own") {
-			statusCode = http.StatusForbidden
-		}
-		return c.JSON(statusCode, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -108,11 +100,13 @@ func (h *FavoriteHandler) RemoveFavorite(c echo.Context) error {
 // @Produce json
 // @Param page query int false "Page number (default: 1)"
 // @Param size query int false "Page size (default: 20, max: 100)"
-// @Param sort query string false "Sort field (uploadDate, fileName)"
+// @Param sort query string false "Sort field (uploadDate, fileName, fileSize; default: when favorited)"
 // @Param order query string false "Sort order (asc, desc)"
 // @Param q query string false "Filename search"
 // @Param ext query string false "File extension filter"
 // @Param tag query string false "Tag filter"
+// @Param cursor query string false "next_cursor of the previous page; replaces page"
+// @Param skip_total query bool false "Leave out total and total_pages"
 // @Success 200 {object} response.ListFavoritesResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
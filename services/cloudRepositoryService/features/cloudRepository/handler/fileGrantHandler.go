@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type FileGrantHandler struct {
+	UseCase _interface.IFileGrantUseCase
+}
+
+func NewFileGrantHandler(c *echo.Group, useCase _interface.IFileGrantUseCase) *FileGrantHandler {
+	handler := &FileGrantHandler{
+		UseCase: useCase,
+	}
+	c.GET("/files/shared-with-me", handler.ListSharedWithMe)
+	c.GET("/files/:id/grants", handler.ListGrants)
+	c.PUT("/files/:id/grants/:userId", handler.GrantAccess)
+	c.DELETE("/files/:id/grants/:userId", handler.RevokeAccess)
+	return handler
+}
+
+// ListGrants handles listing the users a file is shared with
+// @Summary List file grants
+// @Description List the users the owner granted access to a file
+// @Tags Grants
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Success 200 {object} response.ListFileGrantsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/grants [get]
+func (h *FileGrantHandler) ListGrants(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	resp, err := h.UseCase.ListGrants(ctx, userID, uint(fileID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GrantAccess handles granting another user access to a file
+// @Summary Grant file access
+// @Description Give another registered user view, download or edit permission on a file. Granting again changes the permission.
+// @Tags Grants
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param userId path int true "Grantee user ID"
+// @Param body body request.GrantFileRequestDTO true "Grant request"
+// @Success 200 {object} response.FileGrantDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/grants/{userId} [put]
+func (h *FileGrantHandler) GrantAccess(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	granteeID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	var req request.GrantFileRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.GrantAccess(ctx, userID, uint(fileID), uint(granteeID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAccess handles revoking a user's access to a file
+// @Summary Revoke file access
+// @Description Remove the access the owner granted a user on a file
+// @Tags Grants
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param userId path int true "Grantee user ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/grants/{userId} [delete]
+func (h *FileGrantHandler) RevokeAccess(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	granteeID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	if err := h.UseCase.RevokeAccess(ctx, userID, uint(fileID), uint(granteeID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListSharedWithMe handles listing the files other users shared with the authenticated user
+// @Summary List files shared with me
+// @Description List files other users granted the authenticated user access to, most recently shared first. Download URLs are only included with download permission or higher.
+// @Tags Grants
+// @Accept json
+// @Produce json
+// @Param permission query string false "Minimum permission (view, download, edit)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.ListSharedWithMeResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/shared-with-me [get]
+func (h *FileGrantHandler) ListSharedWithMe(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.ListSharedWithMeRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.ListSharedWithMe(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type FileMetadataHandler struct {
+	UseCase _interface.IFileMetadataUseCase
+}
+
+func NewFileMetadataHandler(c *echo.Group, useCase _interface.IFileMetadataUseCase) *FileMetadataHandler {
+	handler := &FileMetadataHandler{
+		UseCase: useCase,
+	}
+	c.GET("/files/:id", handler.GetFile)
+	c.PATCH("/files/:id", handler.UpdateFile)
+	return handler
+}
+
+// GetFile handles getting a single file
+// @Summary Get file
+// @Description Get a file with its custom fields, the caller's permission and its ETag. The ETag is also returned in the ETag header; a matching If-None-Match returns 304.
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {object} response.FileDetailDTO
+// @Success 304 "Not Modified"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id} [get]
+func (h *FileMetadataHandler) GetFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	resp, err := h.UseCase.GetFile(ctx, userID, uint(fileID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set("ETag", resp.ETag)
+	if c.Request().Header.Get("If-None-Match") == resp.ETag {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateFile handles renaming a file and editing its description and custom fields
+// @Summary Update file
+// @Description Rename a file and set its description and custom fields. Omitted fields are unchanged; custom_fields is merged and a null value removes a field. The S3 object is not moved; downloads use the new name. Send the ETag from GET /api/v1/files/{id} as If-Match to get 412 instead of overwriting a concurrent change. Requires edit permission.
+// @Tags Files
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param If-Match header string false "ETag the update is based on"
+// @Param body body request.UpdateFileRequestDTO true "Update request"
+// @Success 200 {object} response.FileDetailDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 412 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id} [patch]
+func (h *FileMetadataHandler) UpdateFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	var req request.UpdateFileRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.UpdateFile(ctx, userID, uint(fileID), c.Request().Header.Get("If-Match"), &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set("ETag", resp.ETag)
+	return c.JSON(http.StatusOK, resp)
+}
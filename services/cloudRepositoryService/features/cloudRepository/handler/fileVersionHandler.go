@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type FileVersionHandler struct {
+	UseCase _interface.IFileVersionUseCase
+}
+
+func NewFileVersionHandler(c *echo.Group, useCase _interface.IFileVersionUseCase) *FileVersionHandler {
+	handler := &FileVersionHandler{
+		UseCase: useCase,
+	}
+	c.GET("/files/:id/versions", handler.ListVersions)
+	c.POST("/files/:id/versions", handler.RequestVersionUpload)
+	c.POST("/files/:id/versions/uploads/:uploadId/complete", handler.CompleteVersionUpload)
+	c.GET("/files/:id/versions/:version/download", handler.DownloadVersion)
+	c.POST("/files/:id/versions/:version/restore", handler.RestoreVersion)
+	c.DELETE("/files/:id/versions/:version", handler.DeleteVersion)
+	return handler
+}
+
+// ListVersions handles listing the versions of a file
+// @Summary List file versions
+// @Description List the current content of a file followed by its previous versions, newest first, with the storage they retain
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Success 200 {object} response.ListFileVersionsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions [get]
+func (h *FileVersionHandler) ListVersions(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	resp, err := h.UseCase.ListVersions(ctx, userID, uint(fileID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RequestVersionUpload handles requesting an upload URL for a new version of a file
+// @Summary Upload new version
+// @Description Get a presigned URL to upload new content for an existing file. The content must be of the same file type and counts against the owner's quota. It becomes the current version after POST /api/v1/files/{id}/versions/uploads/{uploadId}/complete; the replaced content is kept as a previous version.
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param body body request.UploadVersionRequestDTO true "Version upload request"
+// @Success 200 {object} response.UploadVersionResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions [post]
+func (h *FileVersionHandler) RequestVersionUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	var req request.UploadVersionRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.RequestVersionUpload(ctx, userID, uint(fileID), &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CompleteVersionUpload handles completing the upload of a new version
+// @Summary Complete version upload
+// @Description Verify the uploaded content of a pending version and make it the current version of the file. Thumbnails and metadata are regenerated in the background.
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param uploadId path int true "Upload ID returned when the upload was requested"
+// @Success 200 {object} response.FileVersionDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions/uploads/{uploadId}/complete [post]
+func (h *FileVersionHandler) CompleteVersionUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("uploadId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid upload ID"})
+	}
+
+	resp, err := h.UseCase.CompleteVersionUpload(ctx, userID, uint(fileID), uint(uploadID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DownloadVersion handles generating a download URL for a version of a file
+// @Summary Download file version
+// @Description Get a presigned download URL for the current or a previous version of a file
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} response.DownloadResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions/{version}/download [get]
+func (h *FileVersionHandler) DownloadVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	number, err := parseVersionNumber(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid version number"})
+	}
+
+	resp, err := h.UseCase.DownloadVersion(ctx, userID, uint(fileID), number)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RestoreVersion handles making a previous version current again
+// @Summary Restore file version
+// @Description Make a previous version the current content of a file. The replaced content is kept as a previous version.
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} response.FileVersionDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions/{version}/restore [post]
+func (h *FileVersionHandler) RestoreVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	number, err := parseVersionNumber(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid version number"})
+	}
+
+	resp, err := h.UseCase.RestoreVersion(ctx, userID, uint(fileID), number)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteVersion handles permanently deleting a previous version
+// @Summary Delete file version
+// @Description Permanently delete a previous version of a file and release its storage. Only the owner can delete versions; the current version cannot be deleted.
+// @Tags Versions
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param version path int true "Version number"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/versions/{version} [delete]
+func (h *FileVersionHandler) DeleteVersion(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	number, err := parseVersionNumber(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid version number"})
+	}
+
+	if err := h.UseCase.DeleteVersion(ctx, userID, uint(fileID), number); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseVersionNumber reads the positive version number from the path
+func parseVersionNumber(c echo.Context) (int, error) {
+	number, err := strconv.ParseUint(c.Param("version"), 10, 31)
+	if err != nil || number == 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return int(number), nil
+}
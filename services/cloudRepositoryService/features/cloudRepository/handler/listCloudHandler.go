@@ -27,12 +27,23 @@ func NewListCloudRepositoryHandler(c *echo.Group, useCase _interface.IListCloudR
 // @Accept json
 // @Produce json
 // @Param file_type query string false "File type filter (image or video)"
-// @Param keyword query string false "Search keyword for filename"
-// @Param sort query string false "Sort order (latest, oldest, name, size)"
+// @Param keyword query string false "Full-text search in file name, description and tags; results include highlights"
+// @Param sort query string false "Sort order (latest, oldest, name, size, duration, resolution, taken, relevance); relevance is the default with a keyword"
 // @Param start_date query string false "Start date (YYYY-MM-DD)"
 // @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param taken_start_date query string false "Capture start date from EXIF (YYYY-MM-DD)"
+// @Param taken_end_date query string false "Capture end date from EXIF (YYYY-MM-DD)"
+// @Param min_duration query number false "Minimum video duration in seconds"
+// @Param max_duration query number false "Maximum video duration in seconds"
+// @Param min_resolution query string false "Minimum resolution (480p, 720p, 1080p, 1440p, 2160p)"
+// @Param quality query string false "Image quality filter (low: blurry or badly exposed)"
+// @Param color query string false "Images with a dominant color close to this hex color (#RRGGBB)"
+// @Param color_distance query number false "Color filter tolerance as Lab ΔE, 1-100 (default: 20)"
+// @Param album_id query int false "Album filter (default order: the album's manual order)"
 // @Param page query int false "Page number (default: 1)"
 // @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Param cursor query string false "next_cursor of the previous page (latest, oldest, name and size orders); replaces page"
+// @Param skip_total query bool false "Leave out total_count"
 // @Success 200 {object} response.ListFilesResponseDTO
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -56,7 +67,7 @@ func (h *ListCloudRepositoryHandler) ListFiles(c echo.Context) error {
 
 	resp, err := h.UseCase.ListFiles(ctx, userID, req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type MapHandler struct {
+	UseCase _interface.IMapUseCase
+}
+
+func NewMapHandler(c *echo.Group, useCase _interface.IMapUseCase) *MapHandler {
+	handler := &MapHandler{
+		UseCase: useCase,
+	}
+	c.GET("/files/map", handler.GetMap)
+	return handler
+}
+
+// GetMap handles placing geotagged files on a map
+// @Summary File map
+// @Description Place the caller's photos with EXIF GPS coordinates on a map. Below zoom 18 files in the bounding box are aggregated into geohash grid clusters sized for the zoom, each with a count, centroid, bounds and a representative thumbnail. From zoom 18, or with cluster set to a cluster's geohash, the files themselves are returned.
+// @Tags Map
+// @Accept json
+// @Produce json
+// @Param bbox query string false "Bounding box min_lng,min_lat,max_lng,max_lat (required without cluster)"
+// @Param zoom query int false "Map zoom level 0-22 (default: 0)"
+// @Param cluster query string false "Geohash of a cluster whose files are listed"
+// @Param limit query int false "Maximum number of files, 1-500 (default: 200)"
+// @Success 200 {object} response.MapResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/map [get]
+func (h *MapHandler) GetMap(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.MapRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.GetMap(ctx, userID, &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type MultipartUploadCloudRepositoryHandler struct {
+	UseCase _interface.IMultipartUploadCloudRepositoryUseCase
+}
+
+func NewMultipartUploadCloudRepositoryHandler(c *echo.Group, useCase _interface.IMultipartUploadCloudRepositoryUseCase) _interface.IMultipartUploadCloudRepositoryHandler {
+	handler := &MultipartUploadCloudRepositoryHandler{
+		UseCase: useCase,
+	}
+	c.POST("/files/multipart", handler.InitiateMultipartUpload)
+	c.POST("/files/multipart/:id/part-urls", handler.GetPartUploadURLs)
+	c.GET("/files/multipart/:id", handler.GetUploadStatus)
+	c.POST("/files/multipart/:id/complete", handler.CompleteMultipartUpload)
+	c.DELETE("/files/multipart/:id", handler.AbortMultipartUpload)
+	return handler
+}
+
+// InitiateMultipartUpload handles starting a multipart upload
+// @Summary Initiate multipart upload
+// @Description Start an S3 multipart upload for a large file (over 5GB or unreliable networks). sha256 is not supported and is rejected
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param body body request.InitiateMultipartUploadRequestDTO true "Multipart upload request"
+// @Success 200 {object} response.InitiateMultipartUploadResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string "Storage quota exceeded"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/multipart [post]
+func (h *MultipartUploadCloudRepositoryHandler) InitiateMultipartUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.InitiateMultipartUploadRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.InitiateMultipartUpload(ctx, userID, &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetPartUploadURLs handles the request for presigned part upload URLs
+// @Summary Request part upload URLs
+// @Description Get presigned URLs for uploading specific parts of a multipart upload (max 100 per request)
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "Multipart upload ID"
+// @Param body body request.PartUploadURLsRequestDTO true "Part numbers"
+// @Success 200 {object} response.PartUploadURLsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/multipart/{id}/part-urls [post]
+func (h *MultipartUploadCloudRepositoryHandler) GetPartUploadURLs(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid upload ID"})
+	}
+
+	var req request.PartUploadURLsRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.GetPartUploadURLs(ctx, userID, uint(uploadID), &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetUploadStatus handles listing the progress of a multipart upload
+// @Summary Get multipart upload status
+// @Description List uploaded and missing parts of a multipart upload so the client can resume
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "Multipart upload ID"
+// @Success 200 {object} response.MultipartUploadStatusResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/multipart/{id} [get]
+func (h *MultipartUploadCloudRepositoryHandler) GetUploadStatus(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid upload ID"})
+	}
+
+	resp, err := h.UseCase.GetUploadStatus(ctx, userID, uint(uploadID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CompleteMultipartUpload handles completing a multipart upload
+// @Summary Complete multipart upload
+// @Description Assemble all uploaded parts, verify the object and commit the file
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "Multipart upload ID"
+// @Success 200 {object} response.CompleteUploadResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/multipart/{id}/complete [post]
+func (h *MultipartUploadCloudRepositoryHandler) CompleteMultipartUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid upload ID"})
+	}
+
+	resp, err := h.UseCase.CompleteMultipartUpload(ctx, userID, uint(uploadID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// AbortMultipartUpload handles aborting a multipart upload
+// @Summary Abort multipart upload
+// @Description Abort a multipart upload and discard its uploaded parts
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "Multipart upload ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/multipart/{id} [delete]
+func (h *MultipartUploadCloudRepositoryHandler) AbortMultipartUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid upload ID"})
+	}
+
+	if err := h.UseCase.AbortMultipartUpload(ctx, userID, uint(uploadID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -13,32 +13,81 @@ import (
 func RegisterRoutes(e *echo.Group, db *gorm.DB, bucket string) {
 	// Repositories
 	uploadRepo := repository.NewUploadCloudRepositoryRepository(db, bucket)
+	multipartUploadRepo := repository.NewMultipartUploadCloudRepositoryRepository(db, bucket)
+	tusUploadRepo := repository.NewTusUploadCloudRepositoryRepository(db, bucket)
 	// batchUploadRepo := repository.NewBatchUploadCloudRepositoryRepository(db, bucket) // Unused as usecase reuses uploadUC
 	downloadRepo := repository.NewDownloadCloudRepositoryRepository(db, bucket)
 	listRepo := repository.NewListCloudRepositoryRepository(db, bucket)
 	deleteRepo := repository.NewDeleteCloudRepositoryRepository(db, bucket)
+	trashRepo := repository.NewTrashCloudRepositoryRepository(db, bucket)
 	userStatsRepo := repository.NewUserStatsCloudRepositoryRepository(db)
+	fileProcessingRepo := repository.NewFileProcessingCloudRepositoryRepository(db, bucket)
 	activityHistoryRepo := repository.NewActivityHistoryCloudRepositoryRepository(db)
 	favoriteRepo := repository.NewFavoriteRepository(db)
+	albumRepo := repository.NewAlbumRepository(db)
+	shareLinkRepo := repository.NewShareLinkRepository(db, bucket)
+	fileGrantRepo := repository.NewFileGrantRepository(db, bucket)
+	tagRepo := repository.NewTagRepository(db)
+	bulkFileRepo := repository.NewBulkFileRepository(db)
+	archiveRepo := repository.NewArchiveRepository(db, bucket)
+	fileVersionRepo := repository.NewFileVersionRepository(db, bucket)
+	fileMetadataRepo := repository.NewFileMetadataRepository(db, bucket)
+	similarImageRepo := repository.NewSimilarImageRepository(db, bucket)
+	mapRepo := repository.NewMapRepository(db, bucket)
 
 	// UseCases - using 30s timeout to match Echo server timeout and provide buffer for DB operations
-	uploadUC := usecase.NewUploadCloudRepositoryUseCase(uploadRepo, userStatsRepo, db, 30*time.Second)
+	fileAuthorizer := usecase.NewFileAuthorizer(fileGrantRepo)
+	fileProcessingUC := usecase.NewFileProcessingCloudRepositoryUseCase(fileProcessingRepo, usecase.ProcessingTimeout)
+	uploadUC := usecase.NewUploadCloudRepositoryUseCase(uploadRepo, userStatsRepo, fileProcessingUC, fileAuthorizer, 30*time.Second)
 	batchUploadUC := usecase.NewBatchUploadCloudRepositoryUseCase(uploadUC, 30*time.Second) // Reuses uploadUC logic
-	downloadUC := usecase.NewDownloadCloudRepositoryUseCase(downloadRepo, userStatsRepo, 30*time.Second)
+	multipartUploadUC := usecase.NewMultipartUploadCloudRepositoryUseCase(multipartUploadRepo, uploadRepo, uploadUC, userStatsRepo, 30*time.Second)
+	tusUploadUC := usecase.NewTusUploadCloudRepositoryUseCase(tusUploadRepo, uploadRepo, uploadUC, userStatsRepo, 30*time.Second)
+	downloadUC := usecase.NewDownloadCloudRepositoryUseCase(downloadRepo, userStatsRepo, fileAuthorizer, 30*time.Second)
 	listUC := usecase.NewListCloudRepositoryUseCase(listRepo, 30*time.Second)
-	deleteUC := usecase.NewDeleteCloudRepositoryUseCase(deleteRepo, 30*time.Second)
+	deleteUC := usecase.NewDeleteCloudRepositoryUseCase(deleteRepo, fileAuthorizer, 30*time.Second)
+	trashUC := usecase.NewTrashCloudRepositoryUseCase(trashRepo, usecase.TrashRetention(), 30*time.Second)
 	userStatsUC := usecase.NewUserStatsCloudRepositoryUseCase(userStatsRepo, 30*time.Second)
 	activityHistoryUC := usecase.NewActivityHistoryCloudRepositoryUseCase(activityHistoryRepo, 30*time.Second)
-	favoriteUC := usecase.NewFavoriteUseCase(favoriteRepo, downloadRepo, listRepo, 30*time.Second)
+	favoriteUC := usecase.NewFavoriteUseCase(favoriteRepo, downloadRepo, listRepo, fileAuthorizer, 30*time.Second)
+	albumUC := usecase.NewAlbumUseCase(albumRepo, listRepo, 30*time.Second)
+	shareLinkUC := usecase.NewShareLinkUseCase(shareLinkRepo, 30*time.Second)
+	fileGrantUC := usecase.NewFileGrantUseCase(fileGrantRepo, downloadRepo, fileAuthorizer, 30*time.Second)
+	tagUC := usecase.NewTagUseCase(tagRepo, downloadRepo, userStatsRepo, fileAuthorizer, 30*time.Second)
+	bulkFileUC := usecase.NewBulkFileUseCase(bulkFileRepo, userStatsRepo, fileAuthorizer, 30*time.Second)
+	archiveUC := usecase.NewArchiveUseCase(archiveRepo, fileAuthorizer, 30*time.Second)
+	fileVersionUC := usecase.NewFileVersionUseCase(fileVersionRepo, userStatsRepo, fileProcessingUC, fileAuthorizer, usecase.MaxFileVersions(), usecase.FileVersionRetention(), 30*time.Second)
+	fileMetadataUC := usecase.NewFileMetadataUseCase(fileMetadataRepo, fileAuthorizer, 30*time.Second)
+	similarImageUC := usecase.NewSimilarImageUseCase(similarImageRepo, fileAuthorizer, 30*time.Second)
+	mapUC := usecase.NewMapUseCase(mapRepo, 30*time.Second)
 
 	// Handlers
 	NewUploadCloudRepositoryHandler(e, uploadUC)
 	NewBatchUploadCloudRepositoryHandler(e, batchUploadUC)
+	NewMultipartUploadCloudRepositoryHandler(e, multipartUploadUC)
+	NewTusUploadCloudRepositoryHandler(e, tusUploadUC)
 	NewDownloadCloudRepositoryHandler(e, downloadUC)
 	NewListCloudRepositoryHandler(e, listUC)
 	NewDeleteCloudRepositoryHandler(e, deleteUC)
+	NewTrashCloudRepositoryHandler(e, trashUC)
 	NewUserStatsCloudRepositoryHandler(e, userStatsUC)
 	NewActivityHistoryCloudRepositoryHandler(e, activityHistoryUC)
 	NewFavoriteHandler(e, favoriteUC)
+	NewAlbumHandler(e, albumUC)
+	NewShareLinkHandler(e, shareLinkUC)
+	NewFileGrantHandler(e, fileGrantUC)
+	NewTagHandler(e, tagUC)
+	NewBulkFileHandler(e, bulkFileUC)
+	NewArchiveHandler(e, archiveUC)
+	NewFileVersionHandler(e, fileVersionUC)
+	NewFileMetadataHandler(e, fileMetadataUC)
+	NewSimilarImageHandler(e, similarImageUC)
+	NewMapHandler(e, mapUC)
 
 }
+
+// RegisterPublicRoutes registers the cloud repository routes that do not require authentication
+func RegisterPublicRoutes(e *echo.Echo, db *gorm.DB, bucket string) {
+	shareLinkRepo := repository.NewShareLinkRepository(db, bucket)
+	shareLinkUC := usecase.NewShareLinkUseCase(shareLinkRepo, 30*time.Second)
+	NewPublicShareLinkHandler(e, shareLinkUC)
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+// ShareLinkPasswordHeader carries the password of a password-protected share link
+const ShareLinkPasswordHeader = "X-Share-Password"
+
+type ShareLinkHandler struct {
+	UseCase _interface.IShareLinkUseCase
+}
+
+func NewShareLinkHandler(c *echo.Group, useCase _interface.IShareLinkUseCase) *ShareLinkHandler {
+	handler := &ShareLinkHandler{
+		UseCase: useCase,
+	}
+	c.POST("/shares", handler.CreateShareLink)
+	c.GET("/shares", handler.ListShareLinks)
+	c.DELETE("/shares/:id", handler.RevokeShareLink)
+	c.GET("/shares/:id/accesses", handler.ListAccesses)
+	return handler
+}
+
+// NewPublicShareLinkHandler registers the unauthenticated share link endpoint
+func NewPublicShareLinkHandler(e *echo.Echo, useCase _interface.IShareLinkUseCase) *ShareLinkHandler {
+	handler := &ShareLinkHandler{
+		UseCase: useCase,
+	}
+	e.GET("/s/:token", handler.ResolveShareLink)
+	return handler
+}
+
+// CreateShareLink handles creating a share link
+// @Summary Create share link
+// @Description Share files through a link with an optional password, expiry time and download limit. The token is only returned once
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param body body request.CreateShareLinkRequestDTO true "Create share link request"
+// @Success 201 {object} response.CreateShareLinkResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/shares [post]
+func (h *ShareLinkHandler) CreateShareLink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.CreateShareLinkRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.CreateShareLink(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ListShareLinks handles listing share links
+// @Summary List share links
+// @Description List share links of the authenticated user, newest first
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.ListShareLinksResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/shares [get]
+func (h *ShareLinkHandler) ListShareLinks(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.ListShareLinksRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	resp, err := h.UseCase.ListShareLinks(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RevokeShareLink handles revoking a share link
+// @Summary Revoke share link
+// @Description Revoke a share link; it stops working immediately
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param id path int true "Share link ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/shares/{id} [delete]
+func (h *ShareLinkHandler) RevokeShareLink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid share link ID"})
+	}
+
+	if err := h.UseCase.RevokeShareLink(ctx, userID, uint(linkID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListAccesses handles listing the access log of a share link
+// @Summary List share link accesses
+// @Description List every attempt to open a share link, most recent first
+// @Tags Shares
+// @Accept json
+// @Produce json
+// @Param id path int true "Share link ID"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.ListShareAccessesResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/shares/{id}/accesses [get]
+func (h *ShareLinkHandler) ListAccesses(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	linkID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid share link ID"})
+	}
+
+	var req request.ListShareAccessesRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	resp, err := h.UseCase.ListAccesses(ctx, userID, uint(linkID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ResolveShareLink handles opening a share link without authentication
+// @Summary Open share link
+// @Description Resolve a share link and get short-lived download URLs for its files. Each call counts as one download
+// @Tags Shares
+// @Produce json
+// @Param token path string true "Share link token"
+// @Param X-Share-Password header string false "Password of a password-protected link"
+// @Success 200 {object} response.ResolveShareLinkResponseDTO
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 410 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /s/{token} [get]
+func (h *ShareLinkHandler) ResolveShareLink(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	// Presigned URLs in the response must not be cached by intermediaries
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	resp, err := h.UseCase.ResolveShareLink(ctx, c.Param("token"), c.Request().Header.Get(ShareLinkPasswordHeader), c.RealIP(), c.Request().UserAgent())
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
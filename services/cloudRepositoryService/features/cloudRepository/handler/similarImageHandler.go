@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type SimilarImageHandler struct {
+	UseCase _interface.ISimilarImageUseCase
+}
+
+func NewSimilarImageHandler(c *echo.Group, useCase _interface.ISimilarImageUseCase) *SimilarImageHandler {
+	handler := &SimilarImageHandler{
+		UseCase: useCase,
+	}
+	c.GET("/files/:id/similar", handler.ListSimilarFiles)
+	c.GET("/files/similar-groups", handler.ListSimilarGroups)
+	return handler
+}
+
+// ListSimilarFiles handles finding images that look like a file
+// @Summary List similar images
+// @Description List the caller's images whose perceptual hash is within max_distance bits of the given image, closest first. Finds resized, recompressed and lightly edited copies as well as burst shots.
+// @Tags Similar Images
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param max_distance query int false "Differing hash bits out of 64, 1-16 (default: 6)"
+// @Param limit query int false "Maximum number of files, 1-100 (default: 20)"
+// @Success 200 {object} response.ListSimilarFilesResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/similar [get]
+func (h *SimilarImageHandler) ListSimilarFiles(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	var req request.SimilarFilesRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.ListSimilarFiles(ctx, userID, uint(fileID), &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListSimilarGroups handles the similar images cleanup report
+// @Summary Similar image groups
+// @Description Cluster the caller's images into groups of similar shots by the Hamming distance of their perceptual hashes. Each group suggests the file to keep (the largest, then the oldest); the others can be deleted with POST /api/v1/files/bulk. Groups are ordered by the space that would be freed.
+// @Tags Similar Images
+// @Accept json
+// @Produce json
+// @Param max_distance query int false "Differing hash bits out of 64, 1-16 (default: 6)"
+// @Param limit query int false "Maximum number of groups, 1-200 (default: 50)"
+// @Success 200 {object} response.SimilarGroupsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/similar-groups [get]
+func (h *SimilarImageHandler) ListSimilarGroups(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.SimilarGroupsRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.ListSimilarGroups(ctx, userID, &req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
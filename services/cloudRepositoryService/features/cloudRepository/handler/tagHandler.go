@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/labstack/echo/v4"
+)
+
+type TagHandler struct {
+	UseCase _interface.ITagUseCase
+}
+
+func NewTagHandler(c *echo.Group, useCase _interface.ITagUseCase) *TagHandler {
+	handler := &TagHandler{
+		UseCase: useCase,
+	}
+	c.GET("/tags", handler.ListTags)
+	c.PATCH("/tags/:id", handler.RenameTag)
+	c.POST("/tags/:id/merge", handler.MergeTag)
+	c.DELETE("/tags/:id", handler.DeleteTag)
+	c.POST("/files/:id/tags", handler.AddFileTags)
+	c.DELETE("/files/:id/tags/:tagId", handler.RemoveFileTag)
+	return handler
+}
+
+// ListTags handles listing the user's tags
+// @Summary List tags
+// @Description List the tags of the authenticated user by name with the number of files each one is attached to
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.ListTagsResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tags [get]
+func (h *TagHandler) ListTags(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	resp, err := h.UseCase.ListTags(ctx, userID)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RenameTag handles renaming a tag
+// @Summary Rename tag
+// @Description Rename a tag. Renaming to the name of another tag fails with 409; merge the tags instead.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param body body request.RenameTagRequestDTO true "Rename tag request"
+// @Success 200 {object} response.TagSummaryDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tags/{id} [patch]
+func (h *TagHandler) RenameTag(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
+	}
+
+	var req request.RenameTagRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.RenameTag(ctx, userID, uint(tagID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// MergeTag handles merging a tag into another one
+// @Summary Merge tags
+// @Description Move every file of a tag to the target tag and delete the merged tag
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID to merge away"
+// @Param body body request.MergeTagRequestDTO true "Merge tag request"
+// @Success 200 {object} response.TagSummaryDTO
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tags/{id}/merge [post]
+func (h *TagHandler) MergeTag(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
+	}
+
+	var req request.MergeTagRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.MergeTag(ctx, userID, uint(tagID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteTag handles deleting a tag
+// @Summary Delete tag
+// @Description Remove a tag from every file and delete it. The files are kept.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) DeleteTag(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	tagID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
+	}
+
+	if err := h.UseCase.DeleteTag(ctx, userID, uint(tagID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// AddFileTags handles adding tags to an existing file
+// @Summary Add tags to file
+// @Description Add tags to a file, creating the missing ones. Requires edit permission on shared files.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param body body request.AddFileTagsRequestDTO true "Add tags request"
+// @Success 200 {object} response.FileTagsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/tags [post]
+func (h *TagHandler) AddFileTags(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	var req request.AddFileTagsRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.AddFileTags(ctx, userID, uint(fileID), req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RemoveFileTag handles removing a tag from a file
+// @Summary Remove tag from file
+// @Description Remove a tag from a file. The tag itself is kept. Requires edit permission on shared files.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Param tagId path int true "Tag ID"
+// @Success 200 {object} response.FileTagsResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/tags/{tagId} [delete]
+func (h *TagHandler) RemoveFileTag(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	tagID, err := strconv.ParseUint(c.Param("tagId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid tag ID"})
+	}
+
+	resp, err := h.UseCase.RemoveFileTag(ctx, userID, uint(fileID), uint(tagID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	"github.com/labstack/echo/v4"
+)
+
+type TrashCloudRepositoryHandler struct {
+	UseCase _interface.ITrashCloudRepositoryUseCase
+}
+
+func NewTrashCloudRepositoryHandler(c *echo.Group, useCase _interface.ITrashCloudRepositoryUseCase) _interface.ITrashCloudRepositoryHandler {
+	handler := &TrashCloudRepositoryHandler{
+		UseCase: useCase,
+	}
+	c.GET("/trash", handler.ListTrash)
+	c.POST("/trash/:id/restore", handler.RestoreFile)
+	c.DELETE("/trash", handler.EmptyTrash)
+	return handler
+}
+
+// ListTrash handles listing the files in the trash
+// @Summary List trash
+// @Description List deleted files of the authenticated user, most recently deleted first, with the time each one is purged
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Page size (default: 20, max: 100)"
+// @Success 200 {object} response.ListTrashResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trash [get]
+func (h *TrashCloudRepositoryHandler) ListTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	var req request.ListTrashRequestDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid query parameters"})
+	}
+
+	resp, err := h.UseCase.ListTrash(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RestoreFile handles restoring a file from the trash
+// @Summary Restore file
+// @Description Move a file out of the trash
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trash/{id}/restore [post]
+func (h *TrashCloudRepositoryHandler) RestoreFile(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	if err := h.UseCase.RestoreFile(ctx, userID, uint(fileID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "file restored successfully"})
+}
+
+// EmptyTrash handles permanently removing every file in the trash
+// @Summary Empty trash
+// @Description Permanently delete every file in the trash of the authenticated user
+// @Tags Trash
+// @Accept json
+// @Produce json
+// @Success 200 {object} response.EmptyTrashResponseDTO
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/trash [delete]
+func (h *TrashCloudRepositoryHandler) EmptyTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	purged, err := h.UseCase.EmptyTrash(ctx, userID)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, response.EmptyTrashResponseDTO{PurgedCount: purged})
+}
@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	tusExtensions        = "creation,termination"
+	tusOffsetContentType = "application/offset+octet-stream"
+)
+
+type TusUploadCloudRepositoryHandler struct {
+	UseCase _interface.ITusUploadCloudRepositoryUseCase
+}
+
+func NewTusUploadCloudRepositoryHandler(c *echo.Group, useCase _interface.ITusUploadCloudRepositoryUseCase) _interface.ITusUploadCloudRepositoryHandler {
+	handler := &TusUploadCloudRepositoryHandler{
+		UseCase: useCase,
+	}
+	tus := c.Group("/files/tus", tusResumable)
+	tus.OPTIONS("", handler.Options)
+	tus.POST("", handler.CreateUpload)
+	tus.HEAD("/:id", handler.GetUploadOffset)
+	tus.PATCH("/:id", handler.WriteChunk)
+	tus.DELETE("/:id", handler.TerminateUpload)
+	return handler
+}
+
+// tusResumable sets the Tus-Resumable header and rejects requests for other protocol versions
+func tusResumable(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set("Tus-Resumable", usecase.TusVersion)
+		if c.Request().Method != http.MethodOptions && c.Request().Header.Get("Tus-Resumable") != usecase.TusVersion {
+			c.Response().Header().Set("Tus-Version", usecase.TusVersion)
+			return c.NoContent(http.StatusPreconditionFailed)
+		}
+		return next(c)
+	}
+}
+
+// Options handles tus server discovery
+// @Summary tus server capabilities
+// @Description Report the supported tus version, extensions and maximum upload size
+// @Tags CloudRepository
+// @Success 204 "No Content"
+// @Router /api/v1/files/tus [options]
+func (h *TusUploadCloudRepositoryHandler) Options(c echo.Context) error {
+	header := c.Response().Header()
+	header.Set("Tus-Version", usecase.TusVersion)
+	header.Set("Tus-Extension", tusExtensions)
+	header.Set("Tus-Max-Size", strconv.FormatInt(usecase.TusMaxSize, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CreateUpload handles the tus creation extension
+// @Summary Create tus upload
+// @Description Create a resumable upload. Upload-Metadata must contain filename and filetype; file_type, tags (comma separated) and duration are optional; sha256 is not supported and is rejected
+// @Tags CloudRepository
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Param Upload-Length header int true "Total upload size in bytes"
+// @Param Upload-Metadata header string true "tus metadata (key base64value pairs)"
+// @Success 201 "Created, Location header points to the upload"
+// @Failure 400 {object} map[string]string
+// @Failure 412 "Unsupported tus version"
+// @Failure 413 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/tus [post]
+func (h *TusUploadCloudRepositoryHandler) CreateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	if c.Request().Header.Get("Upload-Defer-Length") != "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "deferred upload length is not supported"})
+	}
+
+	uploadLength, err := strconv.ParseInt(c.Request().Header.Get("Upload-Length"), 10, 64)
+	if err != nil || uploadLength < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid Upload-Length header"})
+	}
+	if uploadLength > usecase.TusMaxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "upload exceeds Tus-Max-Size"})
+	}
+
+	rawMetadata := c.Request().Header.Get("Upload-Metadata")
+	metadata, err := parseTusMetadata(rawMetadata)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	req, err := newTusCreateUploadRequest(uploadLength, rawMetadata, metadata)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	if err := c.Validate(req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	resp, err := h.UseCase.CreateUpload(ctx, userID, req)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	location := fmt.Sprintf("%s/%d", strings.TrimSuffix(c.Request().URL.Path, "/"), resp.UploadID)
+	c.Response().Header().Set(echo.HeaderLocation, location)
+	return c.NoContent(http.StatusCreated)
+}
+
+// GetUploadOffset handles tus offset retrieval
+// @Summary Get tus upload offset
+// @Description Return the number of bytes received so far in the Upload-Offset header
+// @Tags CloudRepository
+// @Param id path int true "tus upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Success 200 "Upload-Offset and Upload-Length headers"
+// @Failure 403 "Access denied"
+// @Failure 404 "Upload not found or terminated"
+// @Router /api/v1/files/tus/{id} [head]
+func (h *TusUploadCloudRepositoryHandler) GetUploadOffset(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.NoContent(http.StatusUnauthorized)
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	resp, err := h.UseCase.GetUpload(ctx, userID, uint(uploadID))
+	if err != nil {
+		return c.NoContent(errorStatusCode(err))
+	}
+
+	header := c.Response().Header()
+	header.Set("Cache-Control", "no-store")
+	header.Set("Upload-Offset", strconv.FormatInt(resp.UploadOffset, 10))
+	header.Set("Upload-Length", strconv.FormatInt(resp.UploadLength, 10))
+	if resp.Metadata != "" {
+		header.Set("Upload-Metadata", resp.Metadata)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// WriteChunk handles a tus PATCH request
+// @Summary Upload tus chunk
+// @Description Append bytes at Upload-Offset. The file is committed when the last byte is received
+// @Tags CloudRepository
+// @Accept application/offset+octet-stream
+// @Param id path int true "tus upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Param Upload-Offset header int true "Offset the chunk starts at"
+// @Success 204 "New Upload-Offset header"
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 415 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/tus/{id} [patch]
+func (h *TusUploadCloudRepositoryHandler) WriteChunk(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "invalid upload ID"})
+	}
+
+	if c.Request().Header.Get(echo.HeaderContentType) != tusOffsetContentType {
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": "content type must be " + tusOffsetContentType})
+	}
+
+	offset, err := strconv.ParseInt(c.Request().Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid Upload-Offset header"})
+	}
+
+	resp, err := h.UseCase.WriteChunk(ctx, userID, uint(uploadID), offset, c.Request().Body)
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	c.Response().Header().Set("Upload-Offset", strconv.FormatInt(resp.UploadOffset, 10))
+	return c.NoContent(http.StatusNoContent)
+}
+
+// TerminateUpload handles the tus termination extension
+// @Summary Terminate tus upload
+// @Description Abort an unfinished upload and discard the received bytes
+// @Tags CloudRepository
+// @Param id path int true "tus upload ID"
+// @Param Tus-Resumable header string true "tus protocol version (1.0.0)"
+// @Success 204 "No Content"
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/tus/{id} [delete]
+func (h *TusUploadCloudRepositoryHandler) TerminateUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	uploadID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "invalid upload ID"})
+	}
+
+	if err := h.UseCase.TerminateUpload(ctx, userID, uint(uploadID)); err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// parseTusMetadata decodes an Upload-Metadata header ("key base64value,key2 base64value2")
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid Upload-Metadata header")
+		}
+
+		value := ""
+		if len(fields) == 2 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid Upload-Metadata value for %s", fields[0])
+			}
+			value = string(decoded)
+		}
+		metadata[fields[0]] = value
+	}
+
+	return metadata, nil
+}
+
+// newTusCreateUploadRequest maps tus metadata to an upload request.
+// Key names follow the ones sent by common tus clients (Uppy sends filename/filetype and name/type).
+func newTusCreateUploadRequest(uploadLength int64, rawMetadata string, metadata map[string]string) (*request.TusCreateUploadRequestDTO, error) {
+	req := &request.TusCreateUploadRequestDTO{
+		UploadRequestDTO: request.UploadRequestDTO{
+			FileName:    firstNonEmpty(metadata["filename"], metadata["name"]),
+			Description: metadata["description"],
+			ContentType: firstNonEmpty(metadata["filetype"], metadata["type"], metadata["content_type"]),
+			FileType:    metadata["file_type"],
+			FileSize:    uploadLength,
+			SHA256:      metadata["sha256"],
+		},
+		Metadata: rawMetadata,
+	}
+
+	// Derive image/video from the content type when the client does not send file_type
+	if req.FileType == "" {
+		req.FileType, _, _ = strings.Cut(req.ContentType, "/")
+	}
+
+	if tags := metadata["tags"]; tags != "" {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+	}
+
+	if duration := metadata["duration"]; duration != "" {
+		value, err := strconv.ParseFloat(duration, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration metadata: %s", duration)
+		}
+		req.Duration = &value
+	}
+
+	return req, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
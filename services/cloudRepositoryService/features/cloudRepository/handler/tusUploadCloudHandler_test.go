@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTusMetadata(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "empty header",
+			header: "",
+			want:   map[string]string{},
+		},
+		{
+			name:   "uppy metadata",
+			header: "filename cGhvdG8uanBn,filetype aW1hZ2UvanBlZw==",
+			want:   map[string]string{"filename": "photo.jpg", "filetype": "image/jpeg"},
+		},
+		{
+			name:   "key without value",
+			header: "is_confidential, tags dHJhdmVsLDIwMjM=",
+			want:   map[string]string{"is_confidential": "", "tags": "travel,2023"},
+		},
+		{
+			name:    "invalid base64",
+			header:  "filename not-base64!",
+			wantErr: true,
+		},
+		{
+			name:    "too many fields",
+			header:  "filename cGhvdG8= extra",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTusMetadata(tt.header)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTusMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTusMetadata() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewTusCreateUploadRequest(t *testing.T) {
+	metadata := map[string]string{
+		"name":     "clip.mp4",
+		"type":     "video/mp4",
+		"tags":     "travel, 2023,",
+		"duration": "12.5",
+		"sha256":   "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}
+
+	req, err := newTusCreateUploadRequest(1024, "raw", metadata)
+	if err != nil {
+		t.Fatalf("newTusCreateUploadRequest() error = %v", err)
+	}
+	if req.FileName != "clip.mp4" || req.ContentType != "video/mp4" || req.FileType != "video" || req.FileSize != 1024 ||
+		req.SHA256 != metadata["sha256"] {
+		t.Errorf("unexpected request: %+v", req.UploadRequestDTO)
+	}
+	if !reflect.DeepEqual(req.Tags, []string{"travel", "2023"}) {
+		t.Errorf("Tags = %v", req.Tags)
+	}
+	if req.Duration == nil || *req.Duration != 12.5 {
+		t.Errorf("Duration = %v", req.Duration)
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
@@ -17,18 +18,20 @@ func NewUploadCloudRepositoryHandler(c *echo.Group, useCase _interface.IUploadCl
 		UseCase: useCase,
 	}
 	c.POST("/files/upload", handler.RequestUploadURL)
+	c.POST("/files/:id/complete", handler.CompleteUpload)
 	return handler
 }
 
 // RequestUploadURL handles the request for a presigned upload URL
 // @Summary Request presigned upload URL
-// @Description Get a presigned URL for uploading a file to S3
+// @Description Get a presigned URL for uploading a file to S3. With sha256 set, an existing file with the same content is returned with duplicate=true
 // @Tags CloudRepository
 // @Accept json
 // @Produce json
 // @Param body body request.UploadRequestDTO true "Upload request"
 // @Success 200 {object} response.UploadResponseDTO
 // @Failure 400 {object} map[string]string
+// @Failure 413 {object} map[string]string "Storage quota exceeded"
 // @Failure 500 {object} map[string]string
 // @Router /api/v1/files/upload [post]
 func (h *UploadCloudRepositoryHandler) RequestUploadURL(c echo.Context) error {
@@ -51,7 +54,43 @@ func (h *UploadCloudRepositoryHandler) RequestUploadURL(c echo.Context) error {
 
 	resp, err := h.UseCase.RequestUploadURL(ctx, userID, &req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CompleteUpload handles upload completion after the client finished the S3 upload
+// @Summary Complete upload
+// @Description Verify the uploaded object (size and content type) and commit the file
+// @Tags CloudRepository
+// @Accept json
+// @Produce json
+// @Param id path int true "File ID"
+// @Success 200 {object} response.CompleteUploadResponseDTO
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 422 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/files/{id}/complete [post]
+func (h *UploadCloudRepositoryHandler) CompleteUpload(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+	}
+
+	fileID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid file ID"})
+	}
+
+	resp, err := h.UseCase.CompleteUpload(ctx, userID, uint(fileID))
+	if err != nil {
+		return c.JSON(errorStatusCode(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, resp)
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 // getUserIDFromContext extracts user ID from context (set by JWT middleware)
@@ -32,3 +35,17 @@ func getUserIDFromContext(c echo.Context) (uint, error) {
 
 	return userID, nil
 }
+
+// errorStatusCode maps usecase errors to HTTP status codes.
+// Client errors carry their status as an AppError; anything else is an internal error.
+func errorStatusCode(err error) int {
+	var appErr *sharedErrors.AppError
+	switch {
+	case errors.As(err, &appErr):
+		return appErr.HTTPStatus
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
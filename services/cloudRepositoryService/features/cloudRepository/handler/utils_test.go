@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+func TestErrorStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "app error",
+			err:  sharedErrors.Forbidden("access denied: you do not own this file"),
+			want: http.StatusForbidden,
+		},
+		{
+			name: "wrapped app error",
+			err:  fmt.Errorf("failed to update upload status: %w", sharedErrors.Conflict("upload conflict: file is not pending")),
+			want: http.StatusConflict,
+		},
+		{
+			name: "missing record",
+			err:  fmt.Errorf("failed to get file: %w", gorm.ErrRecordNotFound),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "database failure mentioning not found",
+			err:  fmt.Errorf("file not found: %w", errors.New("i/o timeout")),
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "driver message mentioning invalid",
+			err:  errors.New("invalid connection"),
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorStatusCode(tt.err); got != tt.want {
+				t.Errorf("errorStatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package job
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/repository"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/usecase"
+	"github.com/JokerTrickster/joker_backend/shared/logger"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// PendingUploadSweepInterval is how often stale pending uploads are expired
+	PendingUploadSweepInterval = 10 * time.Minute
+	// MultipartUploadSweepInterval is how often expired multipart uploads are aborted
+	MultipartUploadSweepInterval = 1 * time.Hour
+	// TusUploadSweepInterval is how often expired tus uploads are terminated
+	TusUploadSweepInterval = 1 * time.Hour
+	// ProcessingBackfillInterval is how often files missing thumbnails or metadata are processed
+	ProcessingBackfillInterval = 10 * time.Minute
+	// StorageReconcileInterval is how often cloud_files is compared with the objects in S3
+	StorageReconcileInterval = 24 * time.Hour
+	// TrashPurgeInterval is how often files past the trash retention period are purged
+	TrashPurgeInterval = 1 * time.Hour
+	// ExportJobInterval is how often pending archive exports are built
+	ExportJobInterval = 1 * time.Minute
+	// ExportPurgeInterval is how often expired archive exports are deleted
+	ExportPurgeInterval = 1 * time.Hour
+	// VersionPruneInterval is how often expired version uploads and versions past the retention policy are removed
+	VersionPruneInterval = 1 * time.Hour
+)
+
+// Start launches all background jobs of the cloud repository feature.
+// Jobs run until ctx is cancelled. Storage reconciliation only reports unless STORAGE_RECONCILE_REPAIR is "true".
+func Start(ctx context.Context, db *gorm.DB, bucket string) {
+	// Repositories
+	uploadRepo := repository.NewUploadCloudRepositoryRepository(db, bucket)
+	multipartUploadRepo := repository.NewMultipartUploadCloudRepositoryRepository(db, bucket)
+	tusUploadRepo := repository.NewTusUploadCloudRepositoryRepository(db, bucket)
+	userStatsRepo := repository.NewUserStatsCloudRepositoryRepository(db)
+	fileProcessingRepo := repository.NewFileProcessingCloudRepositoryRepository(db, bucket)
+	reconcileRepo := repository.NewReconcileCloudRepositoryRepository(db, bucket)
+	trashRepo := repository.NewTrashCloudRepositoryRepository(db, bucket)
+	fileGrantRepo := repository.NewFileGrantRepository(db, bucket)
+	archiveRepo := repository.NewArchiveRepository(db, bucket)
+	fileVersionRepo := repository.NewFileVersionRepository(db, bucket)
+
+	// UseCases
+	fileAuthorizer := usecase.NewFileAuthorizer(fileGrantRepo)
+	fileProcessingUC := usecase.NewFileProcessingCloudRepositoryUseCase(fileProcessingRepo, usecase.ProcessingTimeout)
+	uploadUC := usecase.NewUploadCloudRepositoryUseCase(uploadRepo, userStatsRepo, fileProcessingUC, fileAuthorizer, 30*time.Second)
+	multipartUploadUC := usecase.NewMultipartUploadCloudRepositoryUseCase(multipartUploadRepo, uploadRepo, uploadUC, userStatsRepo, 30*time.Second)
+	tusUploadUC := usecase.NewTusUploadCloudRepositoryUseCase(tusUploadRepo, uploadRepo, uploadUC, userStatsRepo, 30*time.Second)
+	reconcileUC := usecase.NewReconcileCloudRepositoryUseCase(reconcileRepo, usecase.ReconcileUserTimeout)
+	trashUC := usecase.NewTrashCloudRepositoryUseCase(trashRepo, usecase.TrashRetention(), 10*time.Minute)
+	archiveUC := usecase.NewArchiveUseCase(archiveRepo, fileAuthorizer, 10*time.Minute)
+	fileVersionUC := usecase.NewFileVersionUseCase(fileVersionRepo, userStatsRepo, fileProcessingUC, fileAuthorizer, usecase.MaxFileVersions(), usecase.FileVersionRetention(), 10*time.Minute)
+	reconcileDryRun := os.Getenv("STORAGE_RECONCILE_REPAIR") != "true"
+
+	go runPeriodic(ctx, "pending-upload-sweeper", PendingUploadSweepInterval, func(ctx context.Context) (int, error) {
+		return uploadUC.ExpirePendingUploads(ctx)
+	})
+	go runPeriodic(ctx, "multipart-upload-sweeper", MultipartUploadSweepInterval, func(ctx context.Context) (int, error) {
+		return multipartUploadUC.ExpireMultipartUploads(ctx)
+	})
+	go runPeriodic(ctx, "tus-upload-sweeper", TusUploadSweepInterval, func(ctx context.Context) (int, error) {
+		return tusUploadUC.ExpireTusUploads(ctx)
+	})
+	go runPeriodic(ctx, "processing-backfill", ProcessingBackfillInterval, func(ctx context.Context) (int, error) {
+		return fileProcessingUC.BackfillPendingFiles(ctx)
+	})
+	go runPeriodic(ctx, "trash-purge", TrashPurgeInterval, func(ctx context.Context) (int, error) {
+		return trashUC.PurgeExpiredFiles(ctx)
+	})
+	go runPeriodic(ctx, "archive-export", ExportJobInterval, func(ctx context.Context) (int, error) {
+		return archiveUC.ProcessExportJobs(ctx)
+	})
+	go runPeriodic(ctx, "archive-export-purge", ExportPurgeInterval, func(ctx context.Context) (int, error) {
+		return archiveUC.PurgeExpiredExports(ctx)
+	})
+	go runPeriodic(ctx, "version-prune", VersionPruneInterval, func(ctx context.Context) (int, error) {
+		return fileVersionUC.PruneVersions(ctx)
+	})
+	go runPeriodic(ctx, "storage-reconcile", StorageReconcileInterval, func(ctx context.Context) (int, error) {
+		report, err := reconcileUC.Reconcile(ctx, reconcileDryRun)
+		if err != nil {
+			return 0, err
+		}
+		if report.OrphanCount+report.MissingCount+report.SizeMismatchCount > 0 || len(report.Errors) > 0 {
+			logger.Warn("Storage reconciliation found issues",
+				zap.Bool("dry_run", report.DryRun),
+				zap.Int("users", report.UsersScanned),
+				zap.Int("objects", report.ObjectsScanned),
+				zap.Int("orphans", report.OrphanCount),
+				zap.Int("missing", report.MissingCount),
+				zap.Int("size_mismatches", report.SizeMismatchCount),
+				zap.Strings("errors", report.Errors),
+			)
+		}
+		return report.RepairedCount, nil
+	})
+}
+
+// runPeriodic runs fn every interval until ctx is cancelled.
+// fn returns the number of processed items, which is logged when non-zero.
+func runPeriodic(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) (int, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info("Background job started", zap.String("job", name), zap.Duration("interval", interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Background job stopped", zap.String("job", name))
+			return
+		case <-ticker.C:
+			processed, err := fn(ctx)
+			if err != nil {
+				logger.Error("Background job failed", zap.String("job", name), zap.Error(err))
+				continue
+			}
+			if processed > 0 {
+				logger.Info("Background job completed", zap.String("job", name), zap.Int("processed", processed))
+			}
+		}
+	}
+}
@@ -6,10 +6,14 @@ import "time"
 type ActivityType string
 
 const (
-	ActivityTypeUpload   ActivityType = "upload"
-	ActivityTypeDownload ActivityType = "download"
-	ActivityTypeTagAdd   ActivityType = "tag_add"
-	ActivityTypeTagDel   ActivityType = "tag_del"
+	ActivityTypeUpload         ActivityType = "upload"
+	ActivityTypeDownload       ActivityType = "download"
+	ActivityTypeTagAdd         ActivityType = "tag_add"
+	ActivityTypeTagDel         ActivityType = "tag_del"
+	ActivityTypeTagRename      ActivityType = "tag_rename"
+	ActivityTypeTagMerge       ActivityType = "tag_merge"
+	ActivityTypeVersionUpload  ActivityType = "version_upload"
+	ActivityTypeVersionRestore ActivityType = "version_restore"
 )
 
 // ActivityLog represents user activity logs
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Album groups a user's files in a manual order. Albums can be nested through ParentID.
+type Album struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	UserID      uint      `gorm:"not null;index:idx_albums_user_parent" json:"user_id"`
+	ParentID    *uint     `gorm:"index:idx_albums_user_parent" json:"parent_id"` // nil for top-level albums
+	Name        string    `gorm:"size:255;not null" json:"name"`
+	CoverFileID *uint     `json:"cover_file_id"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Album
+func (Album) TableName() string {
+	return "albums"
+}
+
+// AlbumFile is the membership of a file in an album
+type AlbumFile struct {
+	AlbumID  uint      `gorm:"primaryKey" json:"album_id"`
+	FileID   uint      `gorm:"primaryKey;index" json:"file_id"`
+	Position int       `gorm:"not null" json:"position"` // Manual order within the album, ascending
+	AddedAt  time.Time `gorm:"autoCreateTime" json:"added_at"`
+}
+
+// TableName specifies the table name for AlbumFile
+func (AlbumFile) TableName() string {
+	return "album_files"
+}
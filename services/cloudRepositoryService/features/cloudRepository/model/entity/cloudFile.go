@@ -10,24 +10,99 @@ const (
 	FileTypeVideo FileType = "video"
 )
 
+// UploadStatus represents the lifecycle state of an uploaded file
+type UploadStatus string
+
+const (
+	UploadStatusPending   UploadStatus = "pending"   // Row created, waiting for the client to finish the S3 upload
+	UploadStatusCommitted UploadStatus = "committed" // Object verified in S3 and visible to the user
+	UploadStatusFailed    UploadStatus = "failed"    // Verification failed or the upload expired
+)
+
+// ThumbnailStatus represents the state of server-side thumbnail generation
+type ThumbnailStatus string
+
+const (
+	ThumbnailStatusPending     ThumbnailStatus = "pending"     // Waiting for generation (after commit or by the backfill job)
+	ThumbnailStatusReady       ThumbnailStatus = "ready"       // All thumbnail sizes are stored
+	ThumbnailStatusFailed      ThumbnailStatus = "failed"      // The original could not be decoded
+	ThumbnailStatusUnsupported ThumbnailStatus = "unsupported" // File type cannot be thumbnailed (e.g. video)
+)
+
+// MetadataStatus represents the state of server-side media metadata extraction
+type MetadataStatus string
+
+const (
+	MetadataStatusPending     MetadataStatus = "pending"     // Waiting for extraction (after commit or by the backfill job)
+	MetadataStatusReady       MetadataStatus = "ready"       // Metadata was extracted (video fields on the file, EXIF in FileExif)
+	MetadataStatusFailed      MetadataStatus = "failed"      // The container or EXIF block could not be parsed
+	MetadataStatusUnsupported MetadataStatus = "unsupported" // File type or container has no extractor
+)
+
+// AnalysisStatus represents the state of server-side image analysis (perceptual hash, quality scores and palette)
+type AnalysisStatus string
+
+const (
+	AnalysisStatusPending     AnalysisStatus = "pending"     // Waiting for analysis (after commit or by the backfill job)
+	AnalysisStatusReady       AnalysisStatus = "ready"       // Analysis results are stored on the file
+	AnalysisStatusFailed      AnalysisStatus = "failed"      // The image could not be decoded
+	AnalysisStatusUnsupported AnalysisStatus = "unsupported" // File type cannot be analyzed (e.g. video)
+)
+
+const (
+	// LowSharpnessScore is the sharpness below which an image counts as blurry
+	LowSharpnessScore = 100.0
+	// LowExposureScore is the exposure score below which an image counts as badly exposed
+	LowExposureScore = 0.3
+)
+
+// CustomFields are user-defined key/value pairs of a file
+type CustomFields map[string]string
+
 // CloudFile represents a file stored in cloud storage
 type CloudFile struct {
-	ID           uint       `gorm:"primaryKey" json:"id"`
-	UserID       uint       `gorm:"not null;index" json:"user_id"`
-	FileName     string     `gorm:"size:255;not null" json:"file_name"`
-	S3Key        string     `gorm:"size:512;not null;uniqueIndex" json:"s3_key"`
-	ThumbnailKey string     `gorm:"size:512" json:"thumbnail_key,omitempty"`
-	FileType     FileType   `gorm:"size:20;not null;index" json:"file_type"`
-	ContentType  string     `gorm:"size:100;not null" json:"content_type"`
-	FileSize     int64      `gorm:"not null" json:"file_size"`
-	Duration     *float64   `gorm:"type:decimal(10,2)" json:"duration,omitempty"` // Video duration in seconds
-	Tags         []Tag      `gorm:"many2many:file_tags;" json:"tags,omitempty"`
-	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt    *time.Time `gorm:"index" json:"deleted_at,omitempty"`
+	ID               uint            `gorm:"primaryKey" json:"id"`
+	UserID           uint            `gorm:"not null;index;index:idx_cloud_files_user_sha256,priority:1;index:idx_cloud_files_user_created_at,priority:1;index:idx_cloud_files_user_file_name,priority:1;index:idx_cloud_files_user_file_size,priority:1" json:"user_id"`
+	FileName         string          `gorm:"size:255;not null;index:ftx_cloud_files_search,class:FULLTEXT,option:WITH PARSER ngram,priority:1;index:idx_cloud_files_user_file_name,priority:2" json:"file_name"`
+	Description      string          `gorm:"size:2000;not null;default:'';index:ftx_cloud_files_search,class:FULLTEXT,option:WITH PARSER ngram,priority:2" json:"description,omitempty"`
+	CustomFields     CustomFields    `gorm:"serializer:json;type:json" json:"custom_fields,omitempty"` // User-defined key/value pairs
+	S3Key            string          `gorm:"size:512;not null;uniqueIndex" json:"s3_key"`
+	ThumbnailKey     string          `gorm:"size:512" json:"thumbnail_key,omitempty"` // Smallest generated thumbnail; all sizes are in Thumbnails
+	FileType         FileType        `gorm:"size:20;not null;index" json:"file_type"`
+	ContentType      string          `gorm:"size:100;not null" json:"content_type"`
+	FileSize         int64           `gorm:"not null;index:idx_cloud_files_user_file_size,priority:2" json:"file_size"`
+	SHA256           string          `gorm:"column:sha256;size:64;index:idx_cloud_files_user_sha256,priority:2" json:"sha256,omitempty"` // Hex content hash, enforced by S3 on single uploads
+	Duration         *float64        `gorm:"type:decimal(10,2);index" json:"duration,omitempty"`                                         // Video duration in seconds, extracted by the server when possible
+	Width            *int            `json:"width,omitempty"`                                                                            // Frame width in pixels, before applying Rotation
+	Height           *int            `json:"height,omitempty"`                                                                           // Frame height in pixels, before applying Rotation
+	VideoCodec       string          `gorm:"size:50" json:"video_codec,omitempty"`
+	Rotation         int             `gorm:"not null;default:0" json:"rotation"` // Clockwise display rotation in degrees
+	UploadStatus     UploadStatus    `gorm:"size:20;not null;default:committed;index" json:"upload_status"`
+	ThumbnailStatus  ThumbnailStatus `gorm:"size:20;not null;default:pending;index" json:"thumbnail_status"`
+	MetadataStatus   MetadataStatus  `gorm:"size:20;not null;default:pending;index" json:"metadata_status"`
+	AnalysisStatus   AnalysisStatus  `gorm:"size:20;not null;default:pending;index" json:"analysis_status"`
+	PerceptualHash   *uint64         `json:"perceptual_hash,omitempty"`         // 64-bit difference hash of an image, compared by Hamming distance
+	SharpnessScore   *float64        `json:"sharpness_score,omitempty"`         // Variance of the Laplacian of the image at 1024px; blurry images score low
+	ExposureScore    *float64        `json:"exposure_score,omitempty"`          // 0-1 from the brightness histogram; dark, washed out or clipped images score low
+	Version          int             `gorm:"not null;default:1" json:"version"` // Number of the current content
+	VersionCreatedAt *time.Time      `json:"version_created_at,omitempty"`      // When the current content was uploaded; nil for the first version, uploaded at CreatedAt
+	Versions         []FileVersion   `gorm:"foreignKey:FileID" json:"-"`
+	Thumbnails       []FileThumbnail `gorm:"foreignKey:FileID" json:"thumbnails,omitempty"`
+	Exif             *FileExif       `gorm:"foreignKey:FileID" json:"exif,omitempty"`
+	Palette          []FileColor     `gorm:"foreignKey:FileID" json:"palette,omitempty"` // Dominant colors, largest share first
+	Tags             []Tag           `gorm:"many2many:file_tags;" json:"tags,omitempty"`
+	CreatedAt        time.Time       `gorm:"autoCreateTime;index:idx_cloud_files_user_created_at,priority:2" json:"created_at"`
+	UpdatedAt        time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt        *time.Time      `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName specifies the table name for CloudFile
 func (CloudFile) TableName() string {
 	return "cloud_files"
 }
+
+// SimilarImage is an image whose perceptual hash is within some Hamming distance of another image's
+type SimilarImage struct {
+	File     CloudFile
+	Distance int // Differing hash bits
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// ExportStatus represents the state of an archive export
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"   // Waiting for the export job
+	ExportStatusRunning   ExportStatus = "running"   // Archive is being written to S3
+	ExportStatusCompleted ExportStatus = "completed" // Archive can be downloaded until expires_at
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+// ExportJob is a ZIP archive of many files built in the background and stored in S3 for a limited time
+type ExportJob struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	UserID      uint         `gorm:"not null;index" json:"user_id"`
+	Status      ExportStatus `gorm:"size:20;not null;index" json:"status"`
+	FileIDs     string       `gorm:"type:mediumtext;not null" json:"-"` // JSON array of the selected file IDs
+	FileCount   int          `gorm:"not null" json:"file_count"`
+	TotalSize   int64        `gorm:"not null" json:"total_size"` // Size of the selected files
+	S3Key       string       `gorm:"size:512" json:"-"`
+	ArchiveSize int64        `gorm:"not null;default:0" json:"archive_size"`
+	Error       string       `gorm:"size:500" json:"error,omitempty"`
+	StartedAt   *time.Time   `json:"started_at,omitempty"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time   `gorm:"index" json:"expires_at,omitempty"`
+	CreatedAt   time.Time    `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ExportJob
+func (ExportJob) TableName() string {
+	return "export_jobs"
+}
+
+// ArchivePlan lists the files written to a ZIP archive under unique entry names
+type ArchivePlan struct {
+	Entries   []ArchiveEntry
+	Skipped   []ArchiveSkippedFile
+	TotalSize int64
+}
+
+// ArchiveEntry is a file written to an archive
+type ArchiveEntry struct {
+	File CloudFile
+	Name string // Unique path inside the archive
+}
+
+// ArchiveSkippedFile is a selected file that is left out of an archive
+type ArchiveSkippedFile struct {
+	FileID uint
+	Reason string
+}
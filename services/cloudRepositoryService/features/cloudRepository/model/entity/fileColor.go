@@ -0,0 +1,62 @@
+package entity
+
+import "math"
+
+const (
+	// DefaultColorDistance is how far (CIE76 ΔE in Lab space) a palette color may be from a requested color to match
+	DefaultColorDistance = 20.0
+	// MinColorMatchWeight is the smallest share of an image a palette color must cover to match a requested color
+	MinColorMatchWeight = 0.05
+)
+
+// FileColor is one dominant color of an image, found by k-means clustering of its pixels
+type FileColor struct {
+	ID     uint    `gorm:"primaryKey" json:"-"`
+	FileID uint    `gorm:"not null;index" json:"-"`
+	Hex    string  `gorm:"size:7;not null" json:"hex"`     // sRGB, e.g. #3a7bd5
+	L      float64 `gorm:"column:lab_l;not null" json:"-"` // CIELAB lightness, used by the color filter
+	A      float64 `gorm:"column:lab_a;not null" json:"-"` // CIELAB green-red axis
+	B      float64 `gorm:"column:lab_b;not null" json:"-"` // CIELAB blue-yellow axis
+	Weight float64 `gorm:"not null" json:"weight"`         // Share of the image's pixels, 0-1
+}
+
+// TableName specifies the table name for FileColor
+func (FileColor) TableName() string {
+	return "file_colors"
+}
+
+// LabColor is a color in CIELAB (D65 white point), where Euclidean distance approximates perceived difference
+type LabColor struct {
+	L, A, B float64
+}
+
+// LabFromRGB converts an sRGB color to CIELAB
+func LabFromRGB(r, g, b uint8) LabColor {
+	linear := func(c uint8) float64 {
+		v := float64(c) / 255
+		if v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	rl, gl, bl := linear(r), linear(g), linear(b)
+
+	// Linear sRGB to XYZ, relative to the D65 white point
+	x := (0.4124564*rl + 0.3575761*gl + 0.1804375*bl) / 0.95047
+	y := 0.2126729*rl + 0.7151522*gl + 0.0721750*bl
+	z := (0.0193339*rl + 0.1191920*gl + 0.9503041*bl) / 1.08883
+
+	f := func(t float64) float64 {
+		if t > 216.0/24389 {
+			return math.Cbrt(t)
+		}
+		return (24389.0/27*t + 16) / 116
+	}
+	fx, fy, fz := f(x), f(y), f(z)
+	return LabColor{L: 116*fy - 16, A: 500 * (fx - fy), B: 200 * (fy - fz)}
+}
+
+// Distance returns the CIE76 color difference (ΔE) between two colors; about 2.3 is just noticeable
+func (c LabColor) Distance(other LabColor) float64 {
+	return math.Sqrt((c.L-other.L)*(c.L-other.L) + (c.A-other.A)*(c.A-other.A) + (c.B-other.B)*(c.B-other.B))
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// FileExif holds the EXIF metadata extracted from an image CloudFile
+type FileExif struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	FileID       uint       `gorm:"not null;uniqueIndex" json:"file_id"`
+	TakenAt      *time.Time `gorm:"index" json:"taken_at,omitempty"` // Capture time in UTC; camera wall clock when no offset was recorded
+	CameraMake   string     `gorm:"size:100" json:"camera_make,omitempty"`
+	CameraModel  string     `gorm:"size:100" json:"camera_model,omitempty"`
+	LensModel    string     `gorm:"size:150" json:"lens_model,omitempty"`
+	ExposureTime *float64   `json:"exposure_time,omitempty"` // Seconds
+	FNumber      *float64   `gorm:"type:decimal(5,2)" json:"f_number,omitempty"`
+	ISO          *int       `gorm:"column:iso" json:"iso,omitempty"`
+	FocalLength  *float64   `gorm:"type:decimal(7,2)" json:"focal_length,omitempty"` // Millimeters
+	Orientation  int        `gorm:"not null;default:0" json:"orientation"`           // EXIF orientation 1-8, 0 if absent
+	Latitude     *float64   `json:"latitude,omitempty"`
+	Longitude    *float64   `json:"longitude,omitempty"`
+	Altitude     *float64   `json:"altitude,omitempty"`                         // Meters above sea level
+	Geohash      string     `gorm:"size:12;not null;default:'';index" json:"-"` // Location cell used by the map; empty without GPS coordinates
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt    time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for FileExif
+func (FileExif) TableName() string {
+	return "file_exif"
+}
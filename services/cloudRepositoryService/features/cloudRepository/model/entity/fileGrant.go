@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// FilePermission is a level of access to a file. Each level includes the ones below it.
+type FilePermission string
+
+const (
+	FilePermissionView     FilePermission = "view"     // See the file and its thumbnails
+	FilePermissionDownload FilePermission = "download" // View and download the original
+	FilePermissionEdit     FilePermission = "edit"     // Download and change the file's tags and metadata
+	FilePermissionOwner    FilePermission = "owner"    // Delete, share and grant access; never granted
+)
+
+// filePermissionLevels orders the permissions; unknown permissions have level 0
+var filePermissionLevels = map[FilePermission]int{
+	FilePermissionView:     1,
+	FilePermissionDownload: 2,
+	FilePermissionEdit:     3,
+	FilePermissionOwner:    4,
+}
+
+// Allows reports whether p includes the required permission
+func (p FilePermission) Allows(required FilePermission) bool {
+	level := filePermissionLevels[required]
+	return level > 0 && filePermissionLevels[p] >= level
+}
+
+// IsGrantable reports whether an owner can grant p to another user
+func (p FilePermission) IsGrantable() bool {
+	return p == FilePermissionView || p == FilePermissionDownload || p == FilePermissionEdit
+}
+
+// FileGrant gives another user access to a file
+type FileGrant struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	FileID     uint           `gorm:"not null;uniqueIndex:uniq_file_grantee" json:"file_id"`
+	OwnerID    uint           `gorm:"not null;index" json:"owner_id"`
+	GranteeID  uint           `gorm:"not null;uniqueIndex:uniq_file_grantee;index:idx_file_grants_grantee_created_at" json:"grantee_id"`
+	Permission FilePermission `gorm:"size:20;not null" json:"permission"`
+	File       *CloudFile     `gorm:"foreignKey:FileID" json:"file,omitempty"`
+	CreatedAt  time.Time      `gorm:"autoCreateTime;index:idx_file_grants_grantee_created_at" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for FileGrant
+func (FileGrant) TableName() string {
+	return "file_grants"
+}
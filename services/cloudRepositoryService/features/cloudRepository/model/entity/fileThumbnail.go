@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// FileThumbnail is a server-generated thumbnail of a CloudFile at a given size
+type FileThumbnail struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	FileID    uint      `gorm:"not null;uniqueIndex:uniq_file_thumbnail_size" json:"file_id"`
+	Size      int       `gorm:"not null;uniqueIndex:uniq_file_thumbnail_size" json:"size"` // Bounding box in px (longest edge)
+	S3Key     string    `gorm:"size:512;not null" json:"s3_key"`
+	Width     int       `gorm:"not null" json:"width"`
+	Height    int       `gorm:"not null" json:"height"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for FileThumbnail
+func (FileThumbnail) TableName() string {
+	return "file_thumbnails"
+}
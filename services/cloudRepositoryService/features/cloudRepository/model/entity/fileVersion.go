@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// VersionStatus represents the state of a file version
+type VersionStatus string
+
+const (
+	VersionStatusPending  VersionStatus = "pending"  // Upload of a new version requested, waiting for the client to finish it
+	VersionStatusArchived VersionStatus = "archived" // Previous content of the file, can be downloaded or restored
+	VersionStatusFailed   VersionStatus = "failed"   // Verification failed or the upload expired
+)
+
+// FileVersion is content of a file other than its current one.
+// The current content stays on the CloudFile; making a version current swaps the two, so versions keep their number
+// and the storage of a file and its versions never changes by restoring.
+type FileVersion struct {
+	ID          uint          `gorm:"primaryKey" json:"id"`
+	FileID      uint          `gorm:"not null;index:idx_file_versions_file_status" json:"file_id"`
+	UserID      uint          `gorm:"not null;index" json:"user_id"`     // Owner of the file, whose quota holds the version
+	Version     int           `gorm:"not null;default:0" json:"version"` // 0 while pending; numbered when it becomes current
+	S3Key       string        `gorm:"size:512;not null;uniqueIndex" json:"s3_key"`
+	FileSize    int64         `gorm:"not null" json:"file_size"`
+	ContentType string        `gorm:"size:100;not null" json:"content_type"`
+	SHA256      string        `gorm:"column:sha256;size:64" json:"sha256,omitempty"`
+	Status      VersionStatus `gorm:"size:20;not null;index:idx_file_versions_file_status" json:"status"`
+	CreatedAt   time.Time     `json:"created_at"`                         // When this content was uploaded
+	ArchivedAt  *time.Time    `gorm:"index" json:"archived_at,omitempty"` // When it stopped being the current content
+}
+
+// TableName specifies the table name for FileVersion
+func (FileVersion) TableName() string {
+	return "file_versions"
+}
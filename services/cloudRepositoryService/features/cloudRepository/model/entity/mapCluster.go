@@ -0,0 +1,24 @@
+package entity
+
+// MapArea selects geotagged files by bounding box or by geohash cell
+type MapArea struct {
+	MinLatitude  float64
+	MinLongitude float64 // Greater than MaxLongitude when the box crosses the antimeridian
+	MaxLatitude  float64
+	MaxLongitude float64
+	Geohash      string // Cell prefix; when set the bounding box is ignored
+}
+
+// MapCluster aggregates the geotagged files of one geohash cell
+type MapCluster struct {
+	Geohash          string
+	Count            int
+	Latitude         float64 // Centroid of the files
+	Longitude        float64
+	MinLatitude      float64
+	MinLongitude     float64
+	MaxLatitude      float64
+	MaxLongitude     float64
+	RepresentativeID *uint  // Most recent file with a thumbnail
+	ThumbnailKey     string // Thumbnail of the representative file
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// MultipartStatus represents the state of an S3 multipart upload
+type MultipartStatus string
+
+const (
+	MultipartStatusInProgress MultipartStatus = "in_progress"
+	MultipartStatusCompleted  MultipartStatus = "completed"
+	MultipartStatusAborted    MultipartStatus = "aborted"
+)
+
+// MultipartUpload tracks an S3 multipart upload for a pending CloudFile
+type MultipartUpload struct {
+	ID         uint                  `gorm:"primaryKey" json:"id"`
+	UserID     uint                  `gorm:"not null;index" json:"user_id"`
+	FileID     uint                  `gorm:"not null;uniqueIndex" json:"file_id"`
+	S3UploadID string                `gorm:"size:1024;not null" json:"-"`
+	S3Key      string                `gorm:"size:512;not null" json:"s3_key"`
+	PartSize   int64                 `gorm:"not null" json:"part_size"`
+	TotalParts int32                 `gorm:"not null" json:"total_parts"`
+	Status     MultipartStatus       `gorm:"size:20;not null;index" json:"status"`
+	Parts      []MultipartUploadPart `gorm:"foreignKey:MultipartUploadID" json:"parts,omitempty"`
+	ExpiresAt  time.Time             `gorm:"not null;index" json:"expires_at"`
+	CreatedAt  time.Time             `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time             `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for MultipartUpload
+func (MultipartUpload) TableName() string {
+	return "multipart_uploads"
+}
+
+// MultipartUploadPart records a part that has been stored in S3
+type MultipartUploadPart struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	MultipartUploadID uint      `gorm:"not null;uniqueIndex:uniq_upload_part" json:"multipart_upload_id"`
+	PartNumber        int32     `gorm:"not null;uniqueIndex:uniq_upload_part" json:"part_number"`
+	ETag              string    `gorm:"column:etag;size:128;not null" json:"etag"`
+	Size              int64     `gorm:"not null" json:"size"`
+	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for MultipartUploadPart
+func (MultipartUploadPart) TableName() string {
+	return "multipart_upload_parts"
+}
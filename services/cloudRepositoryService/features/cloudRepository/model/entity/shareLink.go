@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// ShareLink gives anyone holding its token access to a set of the owner's files.
+// Only the SHA-256 of the token is stored; the token itself is returned once when the link is created.
+type ShareLink struct {
+	ID            uint            `gorm:"primaryKey" json:"id"`
+	UserID        uint            `gorm:"not null;index" json:"user_id"`
+	TokenHash     string          `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	PasswordHash  string          `gorm:"size:255" json:"-"` // bcrypt, empty when the link has no password
+	ExpiresAt     *time.Time      `json:"expires_at,omitempty"`
+	MaxDownloads  *int            `json:"max_downloads,omitempty"`
+	DownloadCount int             `gorm:"not null;default:0" json:"download_count"`
+	RevokedAt     *time.Time      `json:"revoked_at,omitempty"`
+	Files         []ShareLinkFile `gorm:"foreignKey:ShareLinkID" json:"files,omitempty"`
+	CreatedAt     time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for ShareLink
+func (ShareLink) TableName() string {
+	return "share_links"
+}
+
+// ShareLinkFile is a file shared by a ShareLink
+type ShareLinkFile struct {
+	ShareLinkID uint `gorm:"primaryKey" json:"share_link_id"`
+	FileID      uint `gorm:"primaryKey;index" json:"file_id"`
+}
+
+// TableName specifies the table name for ShareLinkFile
+func (ShareLinkFile) TableName() string {
+	return "share_link_files"
+}
+
+// ShareAccessOutcome is the result of resolving a share link
+type ShareAccessOutcome string
+
+const (
+	ShareAccessGranted          ShareAccessOutcome = "granted"
+	ShareAccessRevoked          ShareAccessOutcome = "revoked"
+	ShareAccessExpired          ShareAccessOutcome = "expired"
+	ShareAccessLimitReached     ShareAccessOutcome = "limit_reached"
+	ShareAccessPasswordRequired ShareAccessOutcome = "password_required"
+	ShareAccessWrongPassword    ShareAccessOutcome = "wrong_password"
+	ShareAccessNoFiles          ShareAccessOutcome = "no_files" // Every shared file was deleted
+)
+
+// ShareLinkAccess logs one attempt to resolve a share link
+type ShareLinkAccess struct {
+	ID          uint               `gorm:"primaryKey" json:"id"`
+	ShareLinkID uint               `gorm:"not null;index:idx_share_link_accessed_at" json:"share_link_id"`
+	Outcome     ShareAccessOutcome `gorm:"size:20;not null" json:"outcome"`
+	IPAddress   string             `gorm:"size:45" json:"ip_address"`
+	UserAgent   string             `gorm:"size:255" json:"user_agent"`
+	AccessedAt  time.Time          `gorm:"autoCreateTime;index:idx_share_link_accessed_at" json:"accessed_at"`
+}
+
+// TableName specifies the table name for ShareLinkAccess
+func (ShareLinkAccess) TableName() string {
+	return "share_link_accesses"
+}
@@ -6,7 +6,7 @@ import "time"
 type Tag struct {
 	ID        uint        `gorm:"primaryKey" json:"id"`
 	UserID    uint        `gorm:"not null;index" json:"user_id"`
-	Name      string      `gorm:"size:50;not null" json:"name"`
+	Name      string      `gorm:"size:50;not null;index:ftx_tags_name,class:FULLTEXT,option:WITH PARSER ngram" json:"name"`
 	Files     []CloudFile `gorm:"many2many:file_tags;" json:"files,omitempty"`
 	CreatedAt time.Time   `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -15,3 +15,11 @@ type Tag struct {
 func (Tag) TableName() string {
 	return "tags"
 }
+
+// TagFileCount is a tag with the number of files it is attached to
+type TagFileCount struct {
+	ID        uint
+	Name      string
+	FileCount int64
+	CreatedAt time.Time
+}
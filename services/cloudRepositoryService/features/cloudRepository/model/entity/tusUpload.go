@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// TusUpload tracks a tus resumable upload that the server streams into an S3 multipart upload.
+// Bytes that do not yet fill a part are kept in a separate "incomplete part" object until the next PATCH.
+type TusUpload struct {
+	ID                 uint            `gorm:"primaryKey" json:"id"`
+	UserID             uint            `gorm:"not null;index" json:"user_id"`
+	FileID             uint            `gorm:"not null;uniqueIndex" json:"file_id"`
+	S3UploadID         string          `gorm:"size:1024;not null" json:"-"`
+	S3Key              string          `gorm:"size:512;not null" json:"s3_key"`
+	UploadLength       int64           `gorm:"not null" json:"upload_length"`
+	UploadOffset       int64           `gorm:"not null;default:0" json:"upload_offset"`
+	PartCount          int32           `gorm:"not null;default:0" json:"part_count"`
+	IncompletePartSize int64           `gorm:"not null;default:0" json:"incomplete_part_size"`
+	Metadata           string          `gorm:"type:text" json:"metadata"` // Raw Upload-Metadata header, echoed on HEAD
+	Status             MultipartStatus `gorm:"size:20;not null;index" json:"status"`
+	ExpiresAt          time.Time       `gorm:"not null;index" json:"expires_at"`
+	CreatedAt          time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for TusUpload
+func (TusUpload) TableName() string {
+	return "tus_uploads"
+}
+
+// IncompletePartKey returns the S3 key holding bytes not yet uploaded as a part
+func (t *TusUpload) IncompletePartKey() string {
+	return t.S3Key + ".part"
+}
@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// IAlbumRepository defines methods for album operations
+type IAlbumRepository interface {
+	CreateAlbum(ctx context.Context, album *entity.Album) error
+	GetUserAlbums(ctx context.Context, userID uint) ([]entity.Album, error)
+	UpdateAlbum(ctx context.Context, userID, albumID uint, updates map[string]interface{}) error
+	DeleteAlbums(ctx context.Context, userID uint, albumIDs []uint) error
+	CountAlbumFiles(ctx context.Context, albumIDs []uint) (map[uint]int64, error)
+	GetLiveFiles(ctx context.Context, userID uint, fileIDs []uint) ([]entity.CloudFile, error)
+	GetAlbumFileIDs(ctx context.Context, albumID uint) ([]uint, error)
+	HasAlbumFile(ctx context.Context, albumID, fileID uint) (bool, error)
+	AddAlbumFiles(ctx context.Context, albumID uint, fileIDs []uint) (int, error)
+	RemoveAlbumFile(ctx context.Context, albumID, fileID uint) error
+	SetAlbumFilePositions(ctx context.Context, albumID uint, fileIDs []uint) error
+}
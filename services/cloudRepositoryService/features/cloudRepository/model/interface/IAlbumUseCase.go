@@ -0,0 +1,20 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IAlbumUseCase defines methods for album business logic
+type IAlbumUseCase interface {
+	CreateAlbum(ctx context.Context, userID uint, req request.CreateAlbumRequestDTO) (*response.AlbumDTO, error)
+	ListAlbums(ctx context.Context, userID uint, req request.ListAlbumsRequestDTO) (*response.ListAlbumsResponseDTO, error)
+	GetAlbum(ctx context.Context, userID, albumID uint) (*response.AlbumDetailResponseDTO, error)
+	UpdateAlbum(ctx context.Context, userID, albumID uint, req request.UpdateAlbumRequestDTO) (*response.AlbumDTO, error)
+	DeleteAlbum(ctx context.Context, userID, albumID uint) error
+	AddFiles(ctx context.Context, userID, albumID uint, req request.AddAlbumFilesRequestDTO) (*response.AddAlbumFilesResponseDTO, error)
+	RemoveFile(ctx context.Context, userID, albumID, fileID uint) error
+	ReorderFiles(ctx context.Context, userID, albumID uint, req request.ReorderAlbumFilesRequestDTO) error
+}
@@ -0,0 +1,31 @@
+package _interface
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+// IArchiveRepository defines methods for archive downloads and exports
+type IArchiveRepository interface {
+	GetLiveFiles(ctx context.Context, fileIDs []uint) ([]entity.CloudFile, error)
+	GetTaggedFileIDs(ctx context.Context, userID uint, tag string, limit int) ([]uint, error)
+	OpenObject(ctx context.Context, s3Key string) (io.ReadCloser, error)
+	CreateExportJob(ctx context.Context, job *entity.ExportJob) error
+	GetExportJob(ctx context.Context, userID, jobID uint) (*entity.ExportJob, error)
+	GetUserExportJobs(ctx context.Context, userID uint, offset, limit int) ([]entity.ExportJob, int64, error)
+	ClaimExportJob(ctx context.Context, staleBefore time.Time) (*entity.ExportJob, error)
+	CompleteExportJob(ctx context.Context, jobID uint, s3Key string, archiveSize int64, expiresAt time.Time) error
+	FailExportJob(ctx context.Context, jobID uint, reason string) error
+	GetExpiredExportJobs(ctx context.Context, now time.Time, limit int) ([]entity.ExportJob, error)
+	DeleteExportJob(ctx context.Context, jobID uint) error
+	CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error)
+	UploadPart(ctx context.Context, s3Key, s3UploadID string, partNumber int32, body []byte) (string, error)
+	CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error
+	DeleteFromS3(ctx context.Context, s3Key string) error
+	GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error)
+}
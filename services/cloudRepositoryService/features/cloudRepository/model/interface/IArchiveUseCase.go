@@ -0,0 +1,20 @@
+package _interface
+
+import (
+	"context"
+	"io"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IArchiveUseCase defines methods for archive download business logic
+type IArchiveUseCase interface {
+	PrepareArchive(ctx context.Context, userID uint, req request.CreateArchiveRequestDTO) (*entity.ArchivePlan, *response.ExportJobDTO, error)
+	WriteArchive(ctx context.Context, plan *entity.ArchivePlan, w io.Writer) error
+	GetExport(ctx context.Context, userID, jobID uint) (*response.ExportJobDTO, error)
+	ListExports(ctx context.Context, userID uint, req request.ListExportsRequestDTO) (*response.ListExportJobsResponseDTO, error)
+	ProcessExportJobs(ctx context.Context) (int, error)
+	PurgeExpiredExports(ctx context.Context) (int, error)
+}
@@ -0,0 +1,19 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+)
+
+// IBulkFileRepository defines methods for bulk file operations
+type IBulkFileRepository interface {
+	GetFileIDsByFilter(ctx context.Context, userID uint, filter request.ListFilesRequestDTO, limit int) ([]uint, error)
+	GetLiveFiles(ctx context.Context, fileIDs []uint) ([]entity.CloudFile, error)
+	TrashFiles(ctx context.Context, userID uint, fileIDs []uint) error
+	AddTags(ctx context.Context, files []entity.CloudFile, names []string) error
+	RemoveTags(ctx context.Context, fileIDs []uint, names []string) error
+	AddFavorites(ctx context.Context, userID uint, fileIDs []uint) error
+	RemoveFavorites(ctx context.Context, userID uint, fileIDs []uint) error
+}
@@ -0,0 +1,13 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IBulkFileUseCase defines methods for bulk file business logic
+type IBulkFileUseCase interface {
+	Execute(ctx context.Context, userID uint, req request.BulkFileOperationRequestDTO) (*response.BulkFileOperationResponseDTO, error)
+}
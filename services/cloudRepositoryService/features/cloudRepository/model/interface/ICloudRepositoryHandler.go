@@ -6,6 +6,23 @@ import (
 
 type IUploadCloudRepositoryHandler interface {
 	RequestUploadURL(c echo.Context) error
+	CompleteUpload(c echo.Context) error
+}
+
+type IMultipartUploadCloudRepositoryHandler interface {
+	InitiateMultipartUpload(c echo.Context) error
+	GetPartUploadURLs(c echo.Context) error
+	GetUploadStatus(c echo.Context) error
+	CompleteMultipartUpload(c echo.Context) error
+	AbortMultipartUpload(c echo.Context) error
+}
+
+type ITusUploadCloudRepositoryHandler interface {
+	Options(c echo.Context) error
+	CreateUpload(c echo.Context) error
+	GetUploadOffset(c echo.Context) error
+	WriteChunk(c echo.Context) error
+	TerminateUpload(c echo.Context) error
 }
 
 type IBatchUploadCloudRepositoryHandler interface {
@@ -14,6 +31,7 @@ type IBatchUploadCloudRepositoryHandler interface {
 
 type IDownloadCloudRepositoryHandler interface {
 	RequestDownloadURL(c echo.Context) error
+	RequestThumbnailURL(c echo.Context) error
 }
 
 type IListCloudRepositoryHandler interface {
@@ -23,3 +41,9 @@ type IListCloudRepositoryHandler interface {
 type IDeleteCloudRepositoryHandler interface {
 	DeleteFile(c echo.Context) error
 }
+
+type ITrashCloudRepositoryHandler interface {
+	ListTrash(c echo.Context) error
+	RestoreFile(c echo.Context) error
+	EmptyTrash(c echo.Context) error
+}
@@ -6,11 +6,49 @@ import (
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
 )
 
 type IUploadCloudRepositoryRepository interface {
 	GeneratePresignedUploadURL(ctx context.Context, s3Key, contentType string, expiration time.Duration) (string, error)
+	GeneratePresignedUploadURLWithChecksum(ctx context.Context, s3Key, contentType, checksumSHA256 string, expiration time.Duration) (string, error)
 	CreateFile(ctx context.Context, file *entity.CloudFile) error
+	GetCommittedFileBySHA256(ctx context.Context, userID uint, sha256 string) (*entity.CloudFile, error)
+	HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error)
+	GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error)
+	DeleteFromS3(ctx context.Context, s3Key string) error
+	GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error)
+	UpdateUploadStatus(ctx context.Context, id uint, from, to entity.UploadStatus) error
+	GetStalePendingFiles(ctx context.Context, createdBefore time.Time, limit int) ([]entity.CloudFile, error)
+}
+
+type IMultipartUploadCloudRepositoryRepository interface {
+	CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error)
+	GeneratePresignedUploadPartURL(ctx context.Context, s3Key, s3UploadID string, partNumber int32, expiration time.Duration) (string, error)
+	ListUploadedParts(ctx context.Context, s3Key, s3UploadID string) ([]sharedAws.UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error
+	CreateUpload(ctx context.Context, upload *entity.MultipartUpload) error
+	GetUploadByID(ctx context.Context, id uint) (*entity.MultipartUpload, error)
+	SaveParts(ctx context.Context, parts []entity.MultipartUploadPart) error
+	UpdateUploadStatus(ctx context.Context, id uint, from, to entity.MultipartStatus) error
+	GetExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.MultipartUpload, error)
+}
+
+type ITusUploadCloudRepositoryRepository interface {
+	CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error)
+	UploadPart(ctx context.Context, s3Key, s3UploadID string, partNumber int32, body []byte) error
+	ListUploadedParts(ctx context.Context, s3Key, s3UploadID string) ([]sharedAws.UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error
+	AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error
+	PutIncompletePart(ctx context.Context, s3Key string, body []byte) error
+	GetIncompletePart(ctx context.Context, s3Key string) ([]byte, error)
+	DeleteFromS3(ctx context.Context, s3Key string) error
+	CreateUpload(ctx context.Context, upload *entity.TusUpload) error
+	GetUploadByID(ctx context.Context, id uint) (*entity.TusUpload, error)
+	UpdateProgress(ctx context.Context, id uint, fromOffset int64, upload *entity.TusUpload) error
+	UpdateUploadStatus(ctx context.Context, id uint, from, to entity.MultipartStatus) error
+	GetExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.TusUpload, error)
 }
 
 type IBatchUploadCloudRepositoryRepository interface {
@@ -22,10 +60,11 @@ type IDownloadCloudRepositoryRepository interface {
 	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
 	GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error)
 	GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error)
+	GetThumbnail(ctx context.Context, fileID uint, size int) (*entity.FileThumbnail, error)
 }
 
 type IListCloudRepositoryRepository interface {
-	GetFilesByUserID(ctx context.Context, userID uint, filter request.ListFilesRequestDTO) ([]entity.CloudFile, int64, error)
+	GetFilesByUserID(ctx context.Context, userID uint, filter request.ListFilesRequestDTO) ([]entity.CloudFile, int64, string, error)
 	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
 }
 
@@ -35,8 +74,45 @@ type IDeleteCloudRepositoryRepository interface {
 	GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error)
 }
 
+type IFileProcessingCloudRepositoryRepository interface {
+	GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error)
+	HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error)
+	GetObject(ctx context.Context, s3Key string) ([]byte, error)
+	GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error)
+	PutObject(ctx context.Context, s3Key, contentType string, body []byte) error
+	DeleteFromS3(ctx context.Context, s3Key string) error
+	SaveThumbnails(ctx context.Context, fileID uint, thumbnails []entity.FileThumbnail) error
+	UpdateThumbnailStatus(ctx context.Context, fileID uint, status entity.ThumbnailStatus) error
+	SaveVideoMetadata(ctx context.Context, file *entity.CloudFile) error
+	SaveExif(ctx context.Context, exif *entity.FileExif) error
+	UpdateMetadataStatus(ctx context.Context, fileID uint, status entity.MetadataStatus) error
+	SaveImageAnalysis(ctx context.Context, file *entity.CloudFile) error
+	UpdateAnalysisStatus(ctx context.Context, fileID uint, status entity.AnalysisStatus) error
+	GetFilesPendingProcessing(ctx context.Context, createdBefore time.Time, limit int) ([]entity.CloudFile, error)
+}
+
+type ITrashCloudRepositoryRepository interface {
+	GetTrashedFiles(ctx context.Context, userID uint, offset, limit int) ([]entity.CloudFile, int64, error)
+	RestoreFile(ctx context.Context, userID, fileID uint) error
+	GetUserTrashedFiles(ctx context.Context, userID uint, limit int) ([]entity.CloudFile, error)
+	GetExpiredTrashedFiles(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.CloudFile, error)
+	PurgeFile(ctx context.Context, file *entity.CloudFile) error
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+	DeleteFromS3(ctx context.Context, s3Key string) error
+}
+
+type IReconcileCloudRepositoryRepository interface {
+	ListObjects(ctx context.Context, prefix, delimiter, continuationToken string) (*sharedAws.ObjectPage, error)
+	DeleteFromS3(ctx context.Context, s3Key string) error
+	GetUserIDsWithFiles(ctx context.Context) ([]uint, error)
+	GetUserFiles(ctx context.Context, userID uint) ([]entity.CloudFile, error)
+	GetInProgressTusPartKeys(ctx context.Context, userID uint) ([]string, error)
+	FailCommittedFile(ctx context.Context, fileID uint) error
+	ResetThumbnails(ctx context.Context, fileID uint) error
+}
+
 type IUserStatsCloudRepositoryRepository interface {
-	GetTotalStorageUsed(ctx context.Context, userID uint) (int64, error)
+	GetStorageUsage(ctx context.Context, userID uint) (int64, int64, int64, error)
 	GetMonthlyUploadCount(ctx context.Context, userID uint, year int, month int) (int, error)
 	GetMonthlyDownloadCount(ctx context.Context, userID uint, year int, month int) (int, error)
 	GetMonthlyTagsCreatedCount(ctx context.Context, userID uint, year int, month int) (int, error)
@@ -2,6 +2,7 @@ package _interface
 
 import (
 	"context"
+	"io"
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
@@ -9,6 +10,25 @@ import (
 
 type IUploadCloudRepositoryUseCase interface {
 	RequestUploadURL(ctx context.Context, userID uint, req *request.UploadRequestDTO) (*response.UploadResponseDTO, error)
+	CompleteUpload(ctx context.Context, userID uint, fileID uint) (*response.CompleteUploadResponseDTO, error)
+	ExpirePendingUploads(ctx context.Context) (int, error)
+}
+
+type IMultipartUploadCloudRepositoryUseCase interface {
+	InitiateMultipartUpload(ctx context.Context, userID uint, req *request.InitiateMultipartUploadRequestDTO) (*response.InitiateMultipartUploadResponseDTO, error)
+	GetPartUploadURLs(ctx context.Context, userID uint, uploadID uint, req *request.PartUploadURLsRequestDTO) (*response.PartUploadURLsResponseDTO, error)
+	GetUploadStatus(ctx context.Context, userID uint, uploadID uint) (*response.MultipartUploadStatusResponseDTO, error)
+	CompleteMultipartUpload(ctx context.Context, userID uint, uploadID uint) (*response.CompleteUploadResponseDTO, error)
+	AbortMultipartUpload(ctx context.Context, userID uint, uploadID uint) error
+	ExpireMultipartUploads(ctx context.Context) (int, error)
+}
+
+type ITusUploadCloudRepositoryUseCase interface {
+	CreateUpload(ctx context.Context, userID uint, req *request.TusCreateUploadRequestDTO) (*response.TusUploadDTO, error)
+	GetUpload(ctx context.Context, userID uint, uploadID uint) (*response.TusUploadDTO, error)
+	WriteChunk(ctx context.Context, userID uint, uploadID uint, offset int64, body io.Reader) (*response.TusUploadDTO, error)
+	TerminateUpload(ctx context.Context, userID uint, uploadID uint) error
+	ExpireTusUploads(ctx context.Context) (int, error)
 }
 
 type IBatchUploadCloudRepositoryUseCase interface {
@@ -17,6 +37,7 @@ type IBatchUploadCloudRepositoryUseCase interface {
 
 type IDownloadCloudRepositoryUseCase interface {
 	RequestDownloadURL(ctx context.Context, userID uint, fileID uint) (*response.DownloadResponseDTO, error)
+	RequestThumbnailURL(ctx context.Context, userID uint, fileID uint, req *request.ThumbnailRequestDTO) (*response.ThumbnailResponseDTO, error)
 }
 
 type IListCloudRepositoryUseCase interface {
@@ -27,6 +48,24 @@ type IDeleteCloudRepositoryUseCase interface {
 	DeleteFile(ctx context.Context, userID uint, fileID uint) error
 }
 
+type IFileProcessingCloudRepositoryUseCase interface {
+	ProcessFile(ctx context.Context, fileID uint) error
+	ProcessFileAsync(fileID uint)
+	BackfillPendingFiles(ctx context.Context) (int, error)
+}
+
+type ITrashCloudRepositoryUseCase interface {
+	ListTrash(ctx context.Context, userID uint, req request.ListTrashRequestDTO) (*response.ListTrashResponseDTO, error)
+	RestoreFile(ctx context.Context, userID, fileID uint) error
+	EmptyTrash(ctx context.Context, userID uint) (int, error)
+	PurgeExpiredFiles(ctx context.Context) (int, error)
+}
+
+type IReconcileCloudRepositoryUseCase interface {
+	Reconcile(ctx context.Context, dryRun bool) (*response.ReconcileReportDTO, error)
+	ReconcileUser(ctx context.Context, userID uint, dryRun bool) (*response.ReconcileReportDTO, error)
+}
+
 type IUserStatsCloudRepositoryUseCase interface {
 	GetUserStats(ctx context.Context, userID uint) (*response.UserStatsResponseDTO, error)
 }
@@ -11,6 +11,6 @@ import (
 type IFavoriteRepository interface {
 	AddFavorite(ctx context.Context, userID, fileID uint) (*entity.Favorite, error)
 	RemoveFavorite(ctx context.Context, userID, fileID uint) error
-	GetFavoritesByUserID(ctx context.Context, userID uint, filter request.ListFavoritesRequestDTO) ([]entity.CloudFile, int64, error)
+	GetFavoritesByUserID(ctx context.Context, userID uint, filter request.ListFavoritesRequestDTO) ([]entity.CloudFile, int64, string, error)
 	CheckIsFavorited(ctx context.Context, userID, fileID uint) (bool, error)
 }
@@ -0,0 +1,19 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// IFileGrantRepository defines methods for file grant operations
+type IFileGrantRepository interface {
+	GetGrant(ctx context.Context, fileID, granteeID uint) (*entity.FileGrant, error)
+	GetFileGrants(ctx context.Context, fileID uint) ([]entity.FileGrant, error)
+	UpsertGrant(ctx context.Context, grant *entity.FileGrant) error
+	DeleteGrant(ctx context.Context, fileID, granteeID uint) error
+	GetSharedWithUser(ctx context.Context, granteeID uint, permissions []entity.FilePermission, offset, limit int) ([]entity.FileGrant, int64, error)
+	UserExists(ctx context.Context, userID uint) (bool, error)
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+}
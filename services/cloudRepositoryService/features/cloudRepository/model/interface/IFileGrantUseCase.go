@@ -0,0 +1,23 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IFileAuthorizer decides what a user may do with a file, honoring grants from its owner
+type IFileAuthorizer interface {
+	Permission(ctx context.Context, userID uint, file *entity.CloudFile) (entity.FilePermission, error)
+	Authorize(ctx context.Context, userID uint, file *entity.CloudFile, required entity.FilePermission) error
+}
+
+// IFileGrantUseCase defines methods for file grant business logic
+type IFileGrantUseCase interface {
+	GrantAccess(ctx context.Context, ownerID, fileID, granteeID uint, req request.GrantFileRequestDTO) (*response.FileGrantDTO, error)
+	RevokeAccess(ctx context.Context, ownerID, fileID, granteeID uint) error
+	ListGrants(ctx context.Context, ownerID, fileID uint) (*response.ListFileGrantsResponseDTO, error)
+	ListSharedWithMe(ctx context.Context, userID uint, req request.ListSharedWithMeRequestDTO) (*response.ListSharedWithMeResponseDTO, error)
+}
@@ -0,0 +1,15 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// IFileMetadataRepository defines methods for reading and editing a single file
+type IFileMetadataRepository interface {
+	GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error)
+	UpdateFile(ctx context.Context, fileID uint, apply func(file *entity.CloudFile) error) (*entity.CloudFile, error)
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+}
@@ -0,0 +1,14 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IFileMetadataUseCase defines methods for reading and editing a single file
+type IFileMetadataUseCase interface {
+	GetFile(ctx context.Context, userID, fileID uint) (*response.FileDetailDTO, error)
+	UpdateFile(ctx context.Context, userID, fileID uint, ifMatch string, req *request.UpdateFileRequestDTO) (*response.FileDetailDTO, error)
+}
@@ -0,0 +1,30 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+// IFileVersionRepository defines methods for file version data access
+type IFileVersionRepository interface {
+	GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error)
+	GetPendingVersion(ctx context.Context, fileID, versionID uint) (*entity.FileVersion, error)
+	GetArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.FileVersion, error)
+	GetArchivedVersions(ctx context.Context, fileID uint) ([]entity.FileVersion, error)
+	CreatePendingVersion(ctx context.Context, version *entity.FileVersion) error
+	FailPendingVersion(ctx context.Context, versionID uint) error
+	CompletePendingVersion(ctx context.Context, fileID, versionID uint) (*entity.CloudFile, error)
+	RestoreArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.CloudFile, error)
+	DeleteArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.FileVersion, error)
+	GetStalePendingVersions(ctx context.Context, createdBefore time.Time, limit int) ([]entity.FileVersion, error)
+	GetPrunableVersions(ctx context.Context, fileID uint, keep int, archivedBefore time.Time, limit int) ([]entity.FileVersion, error)
+	HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error)
+	GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error)
+	GeneratePresignedUploadURL(ctx context.Context, s3Key, contentType string, expiration time.Duration) (string, error)
+	GeneratePresignedUploadURLWithChecksum(ctx context.Context, s3Key, contentType, checksumSHA256 string, expiration time.Duration) (string, error)
+	GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error)
+	DeleteFromS3(ctx context.Context, s3Key string) error
+}
@@ -0,0 +1,19 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IFileVersionUseCase defines methods for file version business logic
+type IFileVersionUseCase interface {
+	ListVersions(ctx context.Context, userID, fileID uint) (*response.ListFileVersionsResponseDTO, error)
+	RequestVersionUpload(ctx context.Context, userID, fileID uint, req *request.UploadVersionRequestDTO) (*response.UploadVersionResponseDTO, error)
+	CompleteVersionUpload(ctx context.Context, userID, fileID, uploadID uint) (*response.FileVersionDTO, error)
+	DownloadVersion(ctx context.Context, userID, fileID uint, number int) (*response.DownloadResponseDTO, error)
+	RestoreVersion(ctx context.Context, userID, fileID uint, number int) (*response.FileVersionDTO, error)
+	DeleteVersion(ctx context.Context, userID, fileID uint, number int) error
+	PruneVersions(ctx context.Context) (int, error)
+}
@@ -0,0 +1,15 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// IMapRepository defines methods for placing geotagged files on a map
+type IMapRepository interface {
+	GetClusters(ctx context.Context, userID uint, area entity.MapArea, precision, limit int) ([]entity.MapCluster, error)
+	GetFiles(ctx context.Context, userID uint, area entity.MapArea, limit int) ([]entity.CloudFile, error)
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+}
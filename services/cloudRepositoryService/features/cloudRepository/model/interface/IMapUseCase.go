@@ -0,0 +1,13 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IMapUseCase defines methods for placing geotagged files on a map
+type IMapUseCase interface {
+	GetMap(ctx context.Context, userID uint, req *request.MapRequestDTO) (*response.MapResponseDTO, error)
+}
@@ -0,0 +1,23 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// IShareLinkRepository defines methods for share link operations
+type IShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *entity.ShareLink, fileIDs []uint) error
+	GetShareLink(ctx context.Context, userID, linkID uint) (*entity.ShareLink, error)
+	GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLink, error)
+	GetUserShareLinks(ctx context.Context, userID uint, offset, limit int) ([]entity.ShareLink, int64, error)
+	RevokeShareLink(ctx context.Context, userID, linkID uint) error
+	ConsumeDownload(ctx context.Context, linkID uint, now time.Time) (bool, error)
+	LogAccess(ctx context.Context, access *entity.ShareLinkAccess) error
+	GetAccesses(ctx context.Context, linkID uint, offset, limit int) ([]entity.ShareLinkAccess, int64, error)
+	GetLiveFiles(ctx context.Context, userID uint, fileIDs []uint) ([]entity.CloudFile, error)
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+	GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error)
+}
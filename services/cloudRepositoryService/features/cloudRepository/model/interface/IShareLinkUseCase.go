@@ -0,0 +1,17 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// IShareLinkUseCase defines methods for share link business logic
+type IShareLinkUseCase interface {
+	CreateShareLink(ctx context.Context, userID uint, req request.CreateShareLinkRequestDTO) (*response.CreateShareLinkResponseDTO, error)
+	ListShareLinks(ctx context.Context, userID uint, req request.ListShareLinksRequestDTO) (*response.ListShareLinksResponseDTO, error)
+	RevokeShareLink(ctx context.Context, userID, linkID uint) error
+	ListAccesses(ctx context.Context, userID, linkID uint, req request.ListShareAccessesRequestDTO) (*response.ListShareAccessesResponseDTO, error)
+	ResolveShareLink(ctx context.Context, token, password, ipAddress, userAgent string) (*response.ResolveShareLinkResponseDTO, error)
+}
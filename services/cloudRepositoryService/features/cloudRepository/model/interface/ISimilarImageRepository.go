@@ -0,0 +1,16 @@
+package _interface
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// ISimilarImageRepository defines methods for comparing images by perceptual hash
+type ISimilarImageRepository interface {
+	GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error)
+	GetSimilarImages(ctx context.Context, userID, excludeFileID uint, hash uint64, maxDistance, limit int) ([]entity.SimilarImage, error)
+	GetAnalyzedImages(ctx context.Context, userID uint, limit int) ([]entity.CloudFile, error)
+	GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error)
+}
@@ -0,0 +1,14 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// ISimilarImageUseCase defines methods for finding similar images
+type ISimilarImageUseCase interface {
+	ListSimilarFiles(ctx context.Context, userID, fileID uint, req *request.SimilarFilesRequestDTO) (*response.ListSimilarFilesResponseDTO, error)
+	ListSimilarGroups(ctx context.Context, userID uint, req *request.SimilarGroupsRequestDTO) (*response.SimilarGroupsResponseDTO, error)
+}
@@ -0,0 +1,22 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+// ITagRepository defines methods for tag operations
+type ITagRepository interface {
+	GetUserTags(ctx context.Context, userID uint) ([]entity.TagFileCount, error)
+	GetTagFileCount(ctx context.Context, tagID uint) (int64, error)
+	GetTag(ctx context.Context, userID, tagID uint) (*entity.Tag, error)
+	GetTagByName(ctx context.Context, userID uint, name string) (*entity.Tag, error)
+	RenameTag(ctx context.Context, tagID uint, name string) error
+	MergeTags(ctx context.Context, sourceID, targetID uint) error
+	DeleteTag(ctx context.Context, tagID uint) error
+	FindOrCreateTags(ctx context.Context, userID uint, names []string) ([]entity.Tag, error)
+	AddFileTags(ctx context.Context, fileID uint, tagIDs []uint) error
+	RemoveFileTag(ctx context.Context, fileID, tagID uint) error
+	GetFileTags(ctx context.Context, fileID uint) ([]entity.Tag, error)
+}
@@ -0,0 +1,18 @@
+package _interface
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// ITagUseCase defines methods for tag business logic
+type ITagUseCase interface {
+	ListTags(ctx context.Context, userID uint) (*response.ListTagsResponseDTO, error)
+	RenameTag(ctx context.Context, userID, tagID uint, req request.RenameTagRequestDTO) (*response.TagSummaryDTO, error)
+	MergeTag(ctx context.Context, userID, tagID uint, req request.MergeTagRequestDTO) (*response.TagSummaryDTO, error)
+	DeleteTag(ctx context.Context, userID, tagID uint) error
+	AddFileTags(ctx context.Context, userID, fileID uint, req request.AddFileTagsRequestDTO) (*response.FileTagsResponseDTO, error)
+	RemoveFileTag(ctx context.Context, userID, fileID, tagID uint) (*response.FileTagsResponseDTO, error)
+}
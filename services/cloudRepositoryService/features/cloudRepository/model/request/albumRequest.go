@@ -0,0 +1,31 @@
+package request
+
+// CreateAlbumRequestDTO for creating an album
+type CreateAlbumRequestDTO struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID uint   `json:"parent_id"` // 0 creates a top-level album
+}
+
+// UpdateAlbumRequestDTO for renaming, moving or changing the cover of an album.
+// Omitted fields are left unchanged.
+type UpdateAlbumRequestDTO struct {
+	Name        *string `json:"name" validate:"omitempty,max=255"`
+	ParentID    *uint   `json:"parent_id"`     // 0 moves the album to the top level
+	CoverFileID *uint   `json:"cover_file_id"` // 0 clears the cover
+}
+
+// ListAlbumsRequestDTO for listing the albums at one level
+type ListAlbumsRequestDTO struct {
+	ParentID uint `query:"parent_id"` // 0 lists top-level albums
+}
+
+// AddAlbumFilesRequestDTO for adding files to an album
+type AddAlbumFilesRequestDTO struct {
+	FileIDs []uint `json:"file_ids" validate:"required,min=1,max=100"`
+}
+
+// ReorderAlbumFilesRequestDTO for setting the manual order of an album.
+// Listed files are moved to the front in the given order; the others keep their relative order after them.
+type ReorderAlbumFilesRequestDTO struct {
+	FileIDs []uint `json:"file_ids" validate:"required,min=1,max=1000"`
+}
@@ -0,0 +1,15 @@
+package request
+
+// CreateArchiveRequestDTO for downloading many files as one ZIP archive.
+// Files are selected by file_ids or by the name of one of the user's tags, not both.
+type CreateArchiveRequestDTO struct {
+	FileIDs []uint `json:"file_ids" validate:"omitempty,max=10000"`
+	Tag     string `json:"tag" validate:"omitempty,max=50"`
+	Async   bool   `json:"async"` // Always build the archive as an export job
+}
+
+// ListExportsRequestDTO for listing archive exports
+type ListExportsRequestDTO struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
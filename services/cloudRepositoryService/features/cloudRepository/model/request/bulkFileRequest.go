@@ -0,0 +1,21 @@
+package request
+
+// BulkOperation is an action applied to many files at once
+type BulkOperation string
+
+const (
+	BulkOperationDelete     BulkOperation = "delete"
+	BulkOperationAddTags    BulkOperation = "add-tags"
+	BulkOperationRemoveTags BulkOperation = "remove-tags"
+	BulkOperationFavorite   BulkOperation = "favorite"
+	BulkOperationUnfavorite BulkOperation = "unfavorite"
+)
+
+// BulkFileOperationRequestDTO for applying one operation to many files.
+// Files are selected by file_ids or by a filter, not both.
+type BulkFileOperationRequestDTO struct {
+	Operation BulkOperation        `json:"operation" validate:"required,oneof=delete add-tags remove-tags favorite unfavorite"`
+	FileIDs   []uint               `json:"file_ids" validate:"omitempty,max=1000"`
+	Filter    *ListFilesRequestDTO `json:"filter"`                                                // Same filters as GET /files; sort and pagination are ignored
+	Tags      []string             `json:"tags" validate:"omitempty,max=50,dive,required,max=50"` // For add-tags and remove-tags
+}
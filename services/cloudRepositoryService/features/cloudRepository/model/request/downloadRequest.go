@@ -0,0 +1,6 @@
+package request
+
+// ThumbnailRequestDTO selects which generated thumbnail size to return
+type ThumbnailRequestDTO struct {
+	Size int `query:"size" validate:"omitempty,oneof=256 1024"` // Longest edge in px (default: 256)
+}
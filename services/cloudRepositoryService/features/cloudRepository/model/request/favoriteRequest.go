@@ -7,11 +7,13 @@ type AddFavoriteRequestDTO struct {
 
 // ListFavoritesRequestDTO for listing favorites with pagination and filtering
 type ListFavoritesRequestDTO struct {
-	Page  int    `query:"page" validate:"omitempty,min=1"`
-	Size  int    `query:"size" validate:"omitempty,min=1,max=100"`
-	Sort  string `query:"sort" validate:"omitempty,oneof=uploadDate fileName"`
-	Order string `query:"order" validate:"omitempty,oneof=asc desc"`
-	Q     string `query:"q" validate:"omitempty,max=255"`     // Filename search
-	Ext   string `query:"ext" validate:"omitempty,max=10"`    // File extension filter
-	Tag   string `query:"tag" validate:"omitempty,max=50"`    // Tag filter
+	Page      int    `query:"page" validate:"omitempty,min=1"`
+	Size      int    `query:"size" validate:"omitempty,min=1,max=100"`
+	Sort      string `query:"sort" validate:"omitempty,oneof=uploadDate fileName fileSize"`
+	Order     string `query:"order" validate:"omitempty,oneof=asc desc"`
+	Q         string `query:"q" validate:"omitempty,max=255"`       // Filename search
+	Ext       string `query:"ext" validate:"omitempty,max=10"`      // File extension filter
+	Tag       string `query:"tag" validate:"omitempty,max=50"`      // Tag filter
+	Cursor    string `query:"cursor" validate:"omitempty,max=1024"` // next_cursor of the previous page; replaces page
+	SkipTotal bool   `query:"skip_total"`                           // Leave out the total and total_pages, saving a COUNT query
 }
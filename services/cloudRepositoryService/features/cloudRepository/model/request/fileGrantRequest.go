@@ -0,0 +1,13 @@
+package request
+
+// GrantFileRequestDTO for granting another user access to a file
+type GrantFileRequestDTO struct {
+	Permission string `json:"permission" validate:"required,oneof=view download edit"`
+}
+
+// ListSharedWithMeRequestDTO for listing files other users granted access to
+type ListSharedWithMeRequestDTO struct {
+	Permission string `query:"permission" validate:"omitempty,oneof=view download edit"` // Minimum permission
+	Page       int    `query:"page"`
+	PageSize   int    `query:"page_size"`
+}
@@ -0,0 +1,9 @@
+package request
+
+// UpdateFileRequestDTO for changing the name, description and custom fields of a file.
+// Omitted fields are left unchanged. CustomFields is merged into the existing fields; a null value removes a field.
+type UpdateFileRequestDTO struct {
+	FileName     *string            `json:"file_name" validate:"omitempty,max=255"`
+	Description  *string            `json:"description" validate:"omitempty,max=2000"`
+	CustomFields map[string]*string `json:"custom_fields" validate:"omitempty,max=50"`
+}
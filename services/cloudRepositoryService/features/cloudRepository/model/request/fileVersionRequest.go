@@ -0,0 +1,9 @@
+package request
+
+// UploadVersionRequestDTO for requesting a presigned upload URL for a new version of a file.
+// The new content must be of the same file type (image or video) as the file.
+type UploadVersionRequestDTO struct {
+	ContentType string `json:"content_type" validate:"required"`
+	FileSize    int64  `json:"file_size" validate:"required,min=1"`
+	SHA256      string `json:"sha256" validate:"omitempty,len=64,hexadecimal"` // Optional hex SHA-256 of the content, enforced by S3
+}
@@ -1,13 +1,62 @@
 package request
 
-// ListFilesRequestDTO for filtering and pagination
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// ListFilesRequestDTO for filtering and pagination.
+// The json tags allow the same filter in request bodies, such as bulk operations.
 type ListFilesRequestDTO struct {
-	FileType  string   `query:"file_type" validate:"omitempty,oneof=image video"`
-	Keyword   string   `query:"keyword"` // Search in filename or tags
-	Tags      []string `query:"tags"`    // Filter by specific tags
-	Sort      string   `query:"sort" validate:"omitempty,oneof=latest oldest name size"`
-	StartDate string   `query:"start_date"` // YYYY-MM-DD
-	EndDate   string   `query:"end_date"`   // YYYY-MM-DD
-	Page      int      `query:"page"`
-	PageSize  int      `query:"page_size"`
+	FileType       string   `query:"file_type" json:"file_type" validate:"omitempty,oneof=image video"`
+	Keyword        string   `query:"keyword" json:"keyword"` // Full-text search in file name, description and tags
+	Tags           []string `query:"tags" json:"tags"`       // Filter by specific tags
+	Sort           string   `query:"sort" json:"sort" validate:"omitempty,oneof=latest oldest name size duration resolution taken relevance"`
+	StartDate      string   `query:"start_date" json:"start_date"`                                                                // YYYY-MM-DD
+	EndDate        string   `query:"end_date" json:"end_date"`                                                                    // YYYY-MM-DD
+	TakenStartDate string   `query:"taken_start_date" json:"taken_start_date"`                                                    // YYYY-MM-DD, EXIF capture date
+	TakenEndDate   string   `query:"taken_end_date" json:"taken_end_date"`                                                        // YYYY-MM-DD, EXIF capture date
+	MinDuration    float64  `query:"min_duration" json:"min_duration" validate:"omitempty,min=0"`                                 // Seconds, 0 = no limit
+	MaxDuration    float64  `query:"max_duration" json:"max_duration" validate:"omitempty,min=0"`                                 // Seconds, 0 = no limit
+	MinResolution  string   `query:"min_resolution" json:"min_resolution" validate:"omitempty,oneof=480p 720p 1080p 1440p 2160p"` // Minimum short side
+	Quality        string   `query:"quality" json:"quality" validate:"omitempty,oneof=low"`                                       // low: analyzed images that are blurry or badly exposed
+	Color          string   `query:"color" json:"color" validate:"omitempty,max=7"`                                               // Hex color (#RRGGBB, RRGGBB or RGB); images with a close palette color
+	ColorDistance  float64  `query:"color_distance" json:"color_distance" validate:"omitempty,min=1,max=100"`                     // Lab ΔE tolerance of the color filter (default: 20)
+	AlbumID        uint     `query:"album_id" json:"album_id"`                                                                    // Files in the album, in its manual order unless sort is given
+	Page           int      `query:"page" json:"page"`
+	PageSize       int      `query:"page_size" json:"page_size"`
+	Cursor         string   `query:"cursor" json:"cursor" validate:"omitempty,max=1024"` // next_cursor of the previous page; replaces page
+	SkipTotal      bool     `query:"skip_total" json:"skip_total"`                       // Leave out total_count, saving a COUNT query
+}
+
+// searchOperators are the FULLTEXT boolean mode operators, which are never part of a search term
+const searchOperators = `+-<>()~*"@`
+
+// SearchTerms splits a search keyword into its lower-cased terms, dropping FULLTEXT boolean mode operators
+func SearchTerms(keyword string) []string {
+	cleaned := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(searchOperators, r) {
+			return ' '
+		}
+		return r
+	}, strings.ToLower(keyword))
+	return strings.Fields(cleaned)
+}
+
+// ParseHexColor parses a hex color with or without a leading #, in #RRGGBB or #RGB form
+func ParseHexColor(value string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(value, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("invalid color %q: use #RRGGBB", value)
+	}
+	rgb, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid color %q: use #RRGGBB", value)
+	}
+	return color.RGBA{R: uint8(rgb >> 16), G: uint8(rgb >> 8), B: uint8(rgb), A: 255}, nil
 }
@@ -0,0 +1,9 @@
+package request
+
+// MapRequestDTO selects the part of the file map to return
+type MapRequestDTO struct {
+	BBox    string `query:"bbox" validate:"required_without=Cluster,max=100"` // min_lng,min_lat,max_lng,max_lat in degrees
+	Zoom    int    `query:"zoom" validate:"min=0,max=22"`                     // Map zoom level; from 18 files are returned instead of clusters
+	Cluster string `query:"cluster" validate:"omitempty,max=12"`              // Geohash of a cluster; lists its files instead of the bbox
+	Limit   int    `query:"limit" validate:"omitempty,min=1,max=500"`         // Files returned (default: 200)
+}
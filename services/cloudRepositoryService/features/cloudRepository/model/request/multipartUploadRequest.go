@@ -0,0 +1,12 @@
+package request
+
+// InitiateMultipartUploadRequestDTO for starting a multipart upload of a large file
+type InitiateMultipartUploadRequestDTO struct {
+	UploadRequestDTO
+	PartSize int64 `json:"part_size" validate:"omitempty,min=5242880,max=5368709120"` // Optional part size in bytes (5MB - 5GB)
+}
+
+// PartUploadURLsRequestDTO for requesting presigned URLs for specific parts (max 100 per request)
+type PartUploadURLsRequestDTO struct {
+	PartNumbers []int32 `json:"part_numbers" validate:"required,min=1,max=100,dive,min=1,max=10000"`
+}
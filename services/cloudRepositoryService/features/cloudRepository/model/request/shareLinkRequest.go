@@ -0,0 +1,23 @@
+package request
+
+import "time"
+
+// CreateShareLinkRequestDTO for sharing files with a link
+type CreateShareLinkRequestDTO struct {
+	FileIDs      []uint     `json:"file_ids" validate:"required,min=1,max=100"`
+	Password     string     `json:"password" validate:"omitempty,min=4,max=72"` // Optional, sent as X-Share-Password when resolving
+	ExpiresAt    *time.Time `json:"expires_at"`                                 // RFC3339, omit for no expiry
+	MaxDownloads *int       `json:"max_downloads" validate:"omitempty,min=1"`   // Omit for no limit
+}
+
+// ListShareLinksRequestDTO for listing share links with pagination
+type ListShareLinksRequestDTO struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
+
+// ListShareAccessesRequestDTO for listing the access log of a share link with pagination
+type ListShareAccessesRequestDTO struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
@@ -0,0 +1,13 @@
+package request
+
+// SimilarFilesRequestDTO for finding images similar to a file
+type SimilarFilesRequestDTO struct {
+	MaxDistance int `query:"max_distance" validate:"omitempty,min=1,max=16"` // Differing hash bits out of 64 (default: 6)
+	Limit       int `query:"limit" validate:"omitempty,min=1,max=100"`       // Default: 20
+}
+
+// SimilarGroupsRequestDTO for the report of similar image groups
+type SimilarGroupsRequestDTO struct {
+	MaxDistance int `query:"max_distance" validate:"omitempty,min=1,max=16"` // Differing hash bits out of 64 (default: 6)
+	Limit       int `query:"limit" validate:"omitempty,min=1,max=200"`       // Groups returned, largest savings first (default: 50)
+}
@@ -0,0 +1,16 @@
+package request
+
+// RenameTagRequestDTO for renaming a tag
+type RenameTagRequestDTO struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+// MergeTagRequestDTO for merging a tag into another one
+type MergeTagRequestDTO struct {
+	TargetTagID uint `json:"target_tag_id" validate:"required"` // Tag that remains after the merge
+}
+
+// AddFileTagsRequestDTO for adding tags to an existing file
+type AddFileTagsRequestDTO struct {
+	Tags []string `json:"tags" validate:"required,min=1,max=50,dive,required,max=50"` // Missing tags are created
+}
@@ -0,0 +1,7 @@
+package request
+
+// ListTrashRequestDTO for paginating the trash
+type ListTrashRequestDTO struct {
+	Page     int `query:"page"`
+	PageSize int `query:"page_size"`
+}
@@ -0,0 +1,7 @@
+package request
+
+// TusCreateUploadRequestDTO is built from the tus creation headers (Upload-Length, Upload-Metadata)
+type TusCreateUploadRequestDTO struct {
+	UploadRequestDTO
+	Metadata string `validate:"max=4096"` // Raw Upload-Metadata header
+}
@@ -2,12 +2,14 @@ package request
 
 // UploadRequestDTO for requesting presigned upload URL
 type UploadRequestDTO struct {
-	FileName    string    `json:"file_name" validate:"required"`
-	ContentType string    `json:"content_type" validate:"required"`
-	FileType    string    `json:"file_type" validate:"required,oneof=image video"`
-	FileSize    int64     `json:"file_size" validate:"required,min=1"`
-	Tags        []string  `json:"tags" validate:"omitempty,dive,max=50"`         // Optional tags (max 50 chars each)
-	Duration    *float64  `json:"duration" validate:"omitempty,min=0,max=86400"` // Optional video duration in seconds (max 24 hours)
+	FileName    string   `json:"file_name" validate:"required"`
+	ContentType string   `json:"content_type" validate:"required"`
+	FileType    string   `json:"file_type" validate:"required,oneof=image video"`
+	FileSize    int64    `json:"file_size" validate:"required,min=1"`
+	Description string   `json:"description" validate:"omitempty,max=2000"`      // Optional caption, included in keyword search
+	Tags        []string `json:"tags" validate:"omitempty,dive,max=50"`          // Optional tags (max 50 chars each)
+	Duration    *float64 `json:"duration" validate:"omitempty,min=0,max=86400"`  // Optional video duration in seconds (max 24 hours)
+	SHA256      string   `json:"sha256" validate:"omitempty,len=64,hexadecimal"` // Optional hex SHA-256 of the content, enforced by S3 and used for deduplication
 }
 
 // BatchUploadRequestDTO for requesting multiple presigned upload URLs (max 30 files)
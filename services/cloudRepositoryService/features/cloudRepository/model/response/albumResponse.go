@@ -0,0 +1,38 @@
+package response
+
+// AlbumDTO represents an album
+type AlbumDTO struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	ParentID    *uint  `json:"parent_id"`
+	CoverFileID *uint  `json:"cover_file_id"`
+	CoverURL    string `json:"cover_url,omitempty"` // Thumbnail of the cover file
+	FileCount   int64  `json:"file_count"`          // Files in the trash are not counted
+	AlbumCount  int    `json:"album_count"`         // Direct sub-albums
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// AlbumPathDTO is one level of the path from the top level to an album
+type AlbumPathDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListAlbumsResponseDTO for listing the albums at one level
+type ListAlbumsResponseDTO struct {
+	Albums []AlbumDTO `json:"albums"`
+}
+
+// AlbumDetailResponseDTO for a single album with its path and sub-albums.
+// The files are listed with GET /api/v1/files?album_id={id}.
+type AlbumDetailResponseDTO struct {
+	Album  AlbumDTO       `json:"album"`
+	Path   []AlbumPathDTO `json:"path"`
+	Albums []AlbumDTO     `json:"albums"`
+}
+
+// AddAlbumFilesResponseDTO for adding files to an album
+type AddAlbumFilesResponseDTO struct {
+	AddedCount int `json:"added_count"` // Files that were not in the album yet
+}
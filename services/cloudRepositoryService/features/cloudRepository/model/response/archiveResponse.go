@@ -0,0 +1,49 @@
+package response
+
+// ArchiveManifestDTO is written as manifest.json at the end of every archive
+type ArchiveManifestDTO struct {
+	GeneratedAt string                   `json:"generated_at"`
+	FileCount   int                      `json:"file_count"`
+	TotalSize   int64                    `json:"total_size"`
+	Files       []ArchiveManifestFileDTO `json:"files"`
+	Skipped     []ArchiveSkippedFileDTO  `json:"skipped"`
+}
+
+// ArchiveManifestFileDTO describes a file in an archive
+type ArchiveManifestFileDTO struct {
+	FileID      uint   `json:"file_id"`
+	Path        string `json:"path"` // Entry name inside the archive
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	FileSize    int64  `json:"file_size"`
+	SHA256      string `json:"sha256,omitempty"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// ArchiveSkippedFileDTO describes a selected file that is not in an archive
+type ArchiveSkippedFileDTO struct {
+	FileID uint   `json:"file_id"`
+	Reason string `json:"reason"`
+}
+
+// ExportJobDTO represents an archive export
+type ExportJobDTO struct {
+	ID          uint   `json:"id"`
+	Status      string `json:"status"`
+	FileCount   int    `json:"file_count"`
+	TotalSize   int64  `json:"total_size"`
+	ArchiveSize int64  `json:"archive_size,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"` // Only for completed exports
+	Error       string `json:"error,omitempty"`
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	ExpiresAt   string `json:"expires_at,omitempty"`
+}
+
+// ListExportJobsResponseDTO for listing archive exports
+type ListExportJobsResponseDTO struct {
+	Exports    []ExportJobDTO `json:"exports"`
+	TotalCount int64          `json:"total_count"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+}
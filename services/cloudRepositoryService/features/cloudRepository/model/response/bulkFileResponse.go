@@ -0,0 +1,17 @@
+package response
+
+// BulkFileResultDTO is the outcome of a bulk operation for one file
+type BulkFileResultDTO struct {
+	FileID  uint   `json:"file_id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BulkFileOperationResponseDTO returns the per-file results of a bulk operation
+type BulkFileOperationResponseDTO struct {
+	Operation    string              `json:"operation"`
+	Results      []BulkFileResultDTO `json:"results"`
+	TotalCount   int                 `json:"total_count"`
+	SuccessCount int                 `json:"success_count"`
+	FailedCount  int                 `json:"failed_count"`
+}
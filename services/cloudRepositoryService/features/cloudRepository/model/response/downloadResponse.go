@@ -6,3 +6,12 @@ type DownloadResponseDTO struct {
 	FileName    string `json:"file_name"`
 	ExpiresIn   int    `json:"expires_in"` // seconds
 }
+
+// ThumbnailResponseDTO returns presigned URL for a generated thumbnail
+type ThumbnailResponseDTO struct {
+	ThumbnailURL string `json:"thumbnail_url"`
+	Size         int    `json:"size"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	ExpiresIn    int    `json:"expires_in"` // seconds
+}
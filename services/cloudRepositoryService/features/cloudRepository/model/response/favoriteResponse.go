@@ -10,10 +10,11 @@ type FavoriteResponseDTO struct {
 
 // PaginationMeta represents pagination metadata
 type PaginationMeta struct {
-	Total      int64 `json:"total"`
-	Page       int   `json:"page"`
-	Size       int   `json:"size"`
-	TotalPages int   `json:"total_pages"`
+	Total      *int64 `json:"total,omitempty"` // Omitted with skip_total
+	Page       int    `json:"page"`
+	Size       int    `json:"size"`
+	TotalPages *int   `json:"total_pages,omitempty"` // Omitted with skip_total
+	NextCursor string `json:"next_cursor,omitempty"` // Omitted on the last page
 }
 
 // ListFavoritesResponseDTO for listing favorite files
@@ -0,0 +1,32 @@
+package response
+
+// FileGrantDTO represents a user's access to a file
+type FileGrantDTO struct {
+	FileID     uint   `json:"file_id"`
+	GranteeID  uint   `json:"grantee_id"`
+	Permission string `json:"permission"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// ListFileGrantsResponseDTO for listing the grants of a file
+type ListFileGrantsResponseDTO struct {
+	Grants []FileGrantDTO `json:"grants"`
+}
+
+// SharedFileInfoDTO represents a file another user granted access to.
+// DownloadURL is only set with download permission or higher.
+type SharedFileInfoDTO struct {
+	FileInfoDTO
+	OwnerID    uint   `json:"owner_id"`
+	Permission string `json:"permission"`
+	SharedAt   string `json:"shared_at"`
+}
+
+// ListSharedWithMeResponseDTO for listing files shared with the authenticated user
+type ListSharedWithMeResponseDTO struct {
+	Files      []SharedFileInfoDTO `json:"files"`
+	TotalCount int64               `json:"total_count"`
+	Page       int                 `json:"page"`
+	PageSize   int                 `json:"page_size"`
+}
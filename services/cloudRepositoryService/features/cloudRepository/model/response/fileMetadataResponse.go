@@ -0,0 +1,9 @@
+package response
+
+// FileDetailDTO represents a single file with the permission of the caller and its ETag
+type FileDetailDTO struct {
+	FileInfoDTO
+	Version    int    `json:"version"`    // Number of the current content
+	Permission string `json:"permission"` // view, download, edit or owner
+	ETag       string `json:"etag"`       // Send as If-Match when updating the file
+}
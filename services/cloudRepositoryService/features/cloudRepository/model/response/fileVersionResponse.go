@@ -0,0 +1,28 @@
+package response
+
+// FileVersionDTO represents the current or a previous content of a file
+type FileVersionDTO struct {
+	Version     int    `json:"version"`
+	Current     bool   `json:"current"`
+	FileSize    int64  `json:"file_size"`
+	ContentType string `json:"content_type"`
+	SHA256      string `json:"sha256,omitempty"`
+	CreatedAt   string `json:"created_at"`            // When this content was uploaded
+	ArchivedAt  string `json:"archived_at,omitempty"` // When it stopped being current
+}
+
+// ListFileVersionsResponseDTO lists the current content of a file followed by its previous versions, newest first
+type ListFileVersionsResponseDTO struct {
+	FileID       uint             `json:"file_id"`
+	Versions     []FileVersionDTO `json:"versions"`
+	RetainedSize int64            `json:"retained_size"` // Storage used by the previous versions
+}
+
+// UploadVersionResponseDTO returns the presigned upload URL of a new version
+type UploadVersionResponseDTO struct {
+	FileID          uint              `json:"file_id"`
+	UploadID        uint              `json:"upload_id"` // Pending version, completed with POST /files/{id}/versions/uploads/{uploadId}/complete
+	UploadURL       string            `json:"upload_url"`
+	ExpiresIn       int               `json:"expires_in"`                 // seconds
+	RequiredHeaders map[string]string `json:"required_headers,omitempty"` // Headers the client must send with the PUT
+}
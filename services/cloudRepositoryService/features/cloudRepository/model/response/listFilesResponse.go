@@ -6,25 +6,75 @@ type TagDTO struct {
 	Name string `json:"name"`
 }
 
+// ExifDTO represents the EXIF metadata of an image
+type ExifDTO struct {
+	CameraMake   string   `json:"camera_make,omitempty"`
+	CameraModel  string   `json:"camera_model,omitempty"`
+	LensModel    string   `json:"lens_model,omitempty"`
+	ExposureTime *float64 `json:"exposure_time,omitempty"` // Seconds
+	FNumber      *float64 `json:"f_number,omitempty"`
+	ISO          *int     `json:"iso,omitempty"`
+	FocalLength  *float64 `json:"focal_length,omitempty"` // Millimeters
+	Orientation  int      `json:"orientation,omitempty"`
+	Latitude     *float64 `json:"latitude,omitempty"`
+	Longitude    *float64 `json:"longitude,omitempty"`
+	Altitude     *float64 `json:"altitude,omitempty"`
+}
+
+// ImageQualityDTO represents the sharpness and exposure scores of an analyzed image
+type ImageQualityDTO struct {
+	Sharpness   float64 `json:"sharpness"` // Variance of the Laplacian; below 100 is blurry
+	Exposure    float64 `json:"exposure"`  // 0-1; below 0.3 is badly exposed
+	Blurry      bool    `json:"blurry"`
+	BadExposure bool    `json:"bad_exposure"`
+	Low         bool    `json:"low"` // Blurry or badly exposed, matched by quality=low
+}
+
+// ColorDTO represents a dominant color of an image
+type ColorDTO struct {
+	Hex    string  `json:"hex"`    // sRGB, e.g. #3a7bd5
+	Weight float64 `json:"weight"` // Share of the image's pixels, 0-1
+}
+
 // FileInfoDTO represents file metadata
 type FileInfoDTO struct {
-	ID           uint     `json:"id"`
-	FileName     string   `json:"file_name"`
-	FileType     string   `json:"file_type"`
-	ContentType  string   `json:"content_type"`
-	FileSize     int64    `json:"file_size"`
-	Duration     *float64 `json:"duration,omitempty"` // Video duration in seconds
-	Tags         []TagDTO `json:"tags"`
-	DownloadURL  string   `json:"download_url"`
-	ThumbnailURL string   `json:"thumbnail_url,omitempty"`
-	CreatedAt    string   `json:"created_at"`
-	UpdatedAt    string   `json:"updated_at"`
+	ID           uint                `json:"id"`
+	FileName     string              `json:"file_name"`
+	Description  string              `json:"description,omitempty"`
+	CustomFields map[string]string   `json:"custom_fields,omitempty"`
+	FileType     string              `json:"file_type"`
+	ContentType  string              `json:"content_type"`
+	FileSize     int64               `json:"file_size"`
+	Duration     *float64            `json:"duration,omitempty"` // Video duration in seconds
+	Width        *int                `json:"width,omitempty"`
+	Height       *int                `json:"height,omitempty"`
+	VideoCodec   string              `json:"video_codec,omitempty"`
+	Rotation     int                 `json:"rotation,omitempty"` // Clockwise display rotation in degrees
+	TakenAt      string              `json:"taken_at,omitempty"` // EXIF capture time
+	Exif         *ExifDTO            `json:"exif,omitempty"`
+	Quality      *ImageQualityDTO    `json:"quality,omitempty"` // Images only, once analyzed
+	Palette      []ColorDTO          `json:"palette,omitempty"` // Dominant colors, largest share first; images only, once analyzed
+	Tags         []TagDTO            `json:"tags"`
+	DownloadURL  string              `json:"download_url"`
+	ThumbnailURL string              `json:"thumbnail_url,omitempty"`
+	CreatedAt    string              `json:"created_at"`
+	UpdatedAt    string              `json:"updated_at"`
+	Highlights   *SearchHighlightDTO `json:"highlights,omitempty"` // Only when searching by keyword
+}
+
+// SearchHighlightDTO shows where a file matched a keyword search.
+// Text is HTML-escaped with matches wrapped in <mark>; fields without a match are omitted.
+type SearchHighlightDTO struct {
+	FileName    string   `json:"file_name,omitempty"`
+	Description string   `json:"description,omitempty"` // Snippet around the first match
+	Tags        []string `json:"tags,omitempty"`
 }
 
 // ListFilesResponseDTO for listing files
 type ListFilesResponseDTO struct {
 	Files      []FileInfoDTO `json:"files"`
-	TotalCount int64         `json:"total_count"`
+	TotalCount *int64        `json:"total_count,omitempty"` // Omitted with skip_total
 	Page       int           `json:"page"`
 	PageSize   int           `json:"page_size"`
+	NextCursor string        `json:"next_cursor,omitempty"` // Omitted on the last page and for orders without cursor support
 }
@@ -0,0 +1,41 @@
+package response
+
+// MapBoundsDTO is a box around geotagged files
+type MapBoundsDTO struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+// MapClusterDTO groups the geotagged files of one geohash cell
+type MapClusterDTO struct {
+	Geohash      string       `json:"geohash"` // Pass as cluster to list the files of the cell
+	Count        int          `json:"count"`
+	Latitude     float64      `json:"latitude"` // Centroid of the files
+	Longitude    float64      `json:"longitude"`
+	Bounds       MapBoundsDTO `json:"bounds"`            // Box around the files, to zoom in on the cluster
+	FileID       uint         `json:"file_id,omitempty"` // Representative file: the most recent upload with a thumbnail
+	ThumbnailURL string       `json:"thumbnail_url,omitempty"`
+}
+
+// MapFileDTO is a geotagged file placed on the map
+type MapFileDTO struct {
+	ID           uint    `json:"id"`
+	FileName     string  `json:"file_name"`
+	FileType     string  `json:"file_type"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	TakenAt      string  `json:"taken_at,omitempty"` // EXIF capture time
+	ThumbnailURL string  `json:"thumbnail_url,omitempty"`
+}
+
+// MapResponseDTO returns clusters when zoomed out and files when zoomed in or for a cluster
+type MapResponseDTO struct {
+	Mode      string          `json:"mode"` // clusters or files
+	Zoom      int             `json:"zoom"`
+	Precision int             `json:"precision,omitempty"` // Geohash length of the clusters
+	Clusters  []MapClusterDTO `json:"clusters,omitempty"`
+	Files     []MapFileDTO    `json:"files,omitempty"`
+	Truncated bool            `json:"truncated"` // More clusters or files exist than were returned
+}
@@ -0,0 +1,45 @@
+package response
+
+// InitiateMultipartUploadResponseDTO returns the multipart upload session
+type InitiateMultipartUploadResponseDTO struct {
+	UploadID     uint   `json:"upload_id"`
+	FileID       uint   `json:"file_id"`
+	S3Key        string `json:"s3_key"`
+	PartSize     int64  `json:"part_size"`
+	TotalParts   int32  `json:"total_parts"`
+	ExpiresAt    string `json:"expires_at"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"` // Videos only: PUT a JPEG poster frame here before completing the upload
+	ThumbnailKey string `json:"thumbnail_key,omitempty"`
+}
+
+// PartUploadURLDTO is a presigned URL for a single part
+type PartUploadURLDTO struct {
+	PartNumber int32  `json:"part_number"`
+	UploadURL  string `json:"upload_url"`
+}
+
+// PartUploadURLsResponseDTO returns presigned URLs for the requested parts
+type PartUploadURLsResponseDTO struct {
+	Parts     []PartUploadURLDTO `json:"parts"`
+	ExpiresIn int                `json:"expires_in"` // seconds
+}
+
+// UploadedPartDTO represents a part already stored in S3
+type UploadedPartDTO struct {
+	PartNumber int32  `json:"part_number"`
+	ETag       string `json:"etag"`
+	Size       int64  `json:"size"`
+}
+
+// MultipartUploadStatusResponseDTO returns the progress of a multipart upload for resuming
+type MultipartUploadStatusResponseDTO struct {
+	UploadID      uint              `json:"upload_id"`
+	FileID        uint              `json:"file_id"`
+	Status        string            `json:"status"`
+	PartSize      int64             `json:"part_size"`
+	TotalParts    int32             `json:"total_parts"`
+	UploadedParts []UploadedPartDTO `json:"uploaded_parts"`
+	UploadedBytes int64             `json:"uploaded_bytes"`
+	MissingParts  []int32           `json:"missing_parts"`
+	ExpiresAt     string            `json:"expires_at"`
+}
@@ -0,0 +1,41 @@
+package response
+
+// ReconcileReportDTO summarises a reconciliation run between cloud_files and S3.
+// Counts cover every issue found; the lists are capped and may be shorter.
+type ReconcileReportDTO struct {
+	DryRun            bool                       `json:"dry_run"`
+	UsersScanned      int                        `json:"users_scanned"`
+	ObjectsScanned    int                        `json:"objects_scanned"`
+	OrphanCount       int                        `json:"orphan_count"`
+	MissingCount      int                        `json:"missing_count"`
+	SizeMismatchCount int                        `json:"size_mismatch_count"`
+	RepairedCount     int                        `json:"repaired_count"` // Always 0 in dry-run mode
+	OrphanObjects     []ReconcileObjectDTO       `json:"orphan_objects"`
+	MissingObjects    []ReconcileMissingDTO      `json:"missing_objects"`
+	SizeMismatches    []ReconcileSizeMismatchDTO `json:"size_mismatches"`
+	Errors            []string                   `json:"errors,omitempty"`
+}
+
+// ReconcileObjectDTO is an S3 object without a live file record
+type ReconcileObjectDTO struct {
+	Key          string `json:"key"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"last_modified"`
+}
+
+// ReconcileMissingDTO is a committed file or thumbnail whose object is missing from S3
+type ReconcileMissingDTO struct {
+	FileID uint   `json:"file_id"`
+	UserID uint   `json:"user_id"`
+	Kind   string `json:"kind"` // file or thumbnail
+	S3Key  string `json:"s3_key"`
+}
+
+// ReconcileSizeMismatchDTO is a committed file whose object size differs from the recorded size
+type ReconcileSizeMismatchDTO struct {
+	FileID       uint   `json:"file_id"`
+	UserID       uint   `json:"user_id"`
+	S3Key        string `json:"s3_key"`
+	ExpectedSize int64  `json:"expected_size"`
+	ActualSize   int64  `json:"actual_size"`
+}
@@ -0,0 +1,65 @@
+package response
+
+// ShareLinkDTO represents a share link of the authenticated user
+type ShareLinkDTO struct {
+	ID            uint   `json:"id"`
+	FileIDs       []uint `json:"file_ids"`
+	HasPassword   bool   `json:"has_password"`
+	ExpiresAt     string `json:"expires_at,omitempty"`
+	MaxDownloads  *int   `json:"max_downloads,omitempty"`
+	DownloadCount int    `json:"download_count"`
+	Status        string `json:"status"` // active, expired, revoked, limit_reached
+	RevokedAt     string `json:"revoked_at,omitempty"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// CreateShareLinkResponseDTO for a new share link.
+// The token cannot be retrieved again.
+type CreateShareLinkResponseDTO struct {
+	ShareLinkDTO
+	Token string `json:"token"`
+	URL   string `json:"url"` // Path of the public endpoint, e.g. /s/{token}
+}
+
+// ListShareLinksResponseDTO for listing share links
+type ListShareLinksResponseDTO struct {
+	ShareLinks []ShareLinkDTO `json:"share_links"`
+	TotalCount int64          `json:"total_count"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+}
+
+// ShareAccessDTO represents one access to a share link
+type ShareAccessDTO struct {
+	Outcome    string `json:"outcome"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	AccessedAt string `json:"accessed_at"`
+}
+
+// ListShareAccessesResponseDTO for the access log of a share link, most recent first
+type ListShareAccessesResponseDTO struct {
+	Accesses   []ShareAccessDTO `json:"accesses"`
+	TotalCount int64            `json:"total_count"`
+	Page       int              `json:"page"`
+	PageSize   int              `json:"page_size"`
+}
+
+// SharedFileDTO represents a file resolved through a share link
+type SharedFileDTO struct {
+	ID           uint   `json:"id"`
+	FileName     string `json:"file_name"`
+	FileType     string `json:"file_type"`
+	ContentType  string `json:"content_type"`
+	FileSize     int64  `json:"file_size"`
+	DownloadURL  string `json:"download_url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// ResolveShareLinkResponseDTO for the public share link endpoint
+type ResolveShareLinkResponseDTO struct {
+	Files              []SharedFileDTO `json:"files"`
+	URLExpiresAt       string          `json:"url_expires_at"`
+	ExpiresAt          string          `json:"expires_at,omitempty"`          // Expiry of the link itself
+	RemainingDownloads *int            `json:"remaining_downloads,omitempty"` // Omitted when unlimited
+}
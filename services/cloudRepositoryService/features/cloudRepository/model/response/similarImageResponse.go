@@ -0,0 +1,36 @@
+package response
+
+// SimilarFileDTO is an image with the Hamming distance between its perceptual hash and another image's
+type SimilarFileDTO struct {
+	ID           uint   `json:"id"`
+	FileName     string `json:"file_name"`
+	ContentType  string `json:"content_type"`
+	FileSize     int64  `json:"file_size"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Distance     int    `json:"distance"` // Differing hash bits out of 64; 0 is visually identical
+	CreatedAt    string `json:"created_at"`
+}
+
+// ListSimilarFilesResponseDTO lists the user's images similar to a file, closest first
+type ListSimilarFilesResponseDTO struct {
+	FileID      uint             `json:"file_id"`
+	MaxDistance int              `json:"max_distance"`
+	Files       []SimilarFileDTO `json:"files"`
+}
+
+// SimilarGroupDTO is a cluster of similar images
+type SimilarGroupDTO struct {
+	KeepFileID      uint             `json:"keep_file_id"`     // Suggested file to keep: the largest, then the oldest
+	ReclaimableSize int64            `json:"reclaimable_size"` // Bytes freed by deleting the other files of the group
+	Files           []SimilarFileDTO `json:"files"`            // Kept file first; distances are to the kept file
+}
+
+// SimilarGroupsResponseDTO reports the user's images clustered by similarity, largest savings first
+type SimilarGroupsResponseDTO struct {
+	MaxDistance     int               `json:"max_distance"`
+	Groups          []SimilarGroupDTO `json:"groups"`
+	TotalGroups     int               `json:"total_groups"`
+	ReclaimableSize int64             `json:"reclaimable_size"` // Sum over all groups, including those not returned
+	ScannedFiles    int               `json:"scanned_files"`
+	Truncated       bool              `json:"truncated"` // Only the most recent images were compared
+}
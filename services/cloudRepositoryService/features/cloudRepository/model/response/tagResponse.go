@@ -0,0 +1,20 @@
+package response
+
+// TagSummaryDTO represents a tag with the number of files it is attached to
+type TagSummaryDTO struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	FileCount int64  `json:"file_count"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ListTagsResponseDTO for listing the user's tags
+type ListTagsResponseDTO struct {
+	Tags []TagSummaryDTO `json:"tags"`
+}
+
+// FileTagsResponseDTO for returning the tags of a file after a change
+type FileTagsResponseDTO struct {
+	FileID uint     `json:"file_id"`
+	Tags   []TagDTO `json:"tags"`
+}
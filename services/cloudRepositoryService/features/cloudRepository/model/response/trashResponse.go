@@ -0,0 +1,27 @@
+package response
+
+// TrashFileDTO represents a file in the trash
+type TrashFileDTO struct {
+	ID           uint   `json:"id"`
+	FileName     string `json:"file_name"`
+	FileType     string `json:"file_type"`
+	ContentType  string `json:"content_type"`
+	FileSize     int64  `json:"file_size"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	CreatedAt    string `json:"created_at"`
+	DeletedAt    string `json:"deleted_at"`
+	PurgeAt      string `json:"purge_at"` // When the file is permanently removed
+}
+
+// ListTrashResponseDTO for listing the trash
+type ListTrashResponseDTO struct {
+	Files      []TrashFileDTO `json:"files"`
+	TotalCount int64          `json:"total_count"`
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+}
+
+// EmptyTrashResponseDTO returns how many files were permanently removed
+type EmptyTrashResponseDTO struct {
+	PurgedCount int `json:"purged_count"`
+}
@@ -0,0 +1,11 @@
+package response
+
+// TusUploadDTO describes the state of a tus upload; the handler maps it to tus response headers
+type TusUploadDTO struct {
+	UploadID     uint   `json:"upload_id"`
+	FileID       uint   `json:"file_id"`
+	UploadOffset int64  `json:"upload_offset"`
+	UploadLength int64  `json:"upload_length"`
+	Metadata     string `json:"metadata,omitempty"`
+	Status       string `json:"status"`
+}
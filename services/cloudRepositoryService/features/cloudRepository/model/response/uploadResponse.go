@@ -2,18 +2,29 @@ package response
 
 // UploadResponseDTO returns presigned upload URL
 type UploadResponseDTO struct {
-	FileID           uint   `json:"file_id"`
-	UploadURL        string `json:"upload_url"`
-	S3Key            string `json:"s3_key"`
-	ThumbnailURL     string `json:"thumbnail_upload_url,omitempty"`
-	ThumbnailKey     string `json:"thumbnail_key,omitempty"`
-	ExpiresIn        int    `json:"expires_in"` // seconds
+	FileID          uint              `json:"file_id"`
+	UploadURL       string            `json:"upload_url"`
+	S3Key           string            `json:"s3_key"`
+	ExpiresIn       int               `json:"expires_in"`                 // seconds
+	RequiredHeaders map[string]string `json:"required_headers,omitempty"` // Headers the client must send with the PUT
+	ThumbnailURL    string            `json:"thumbnail_url,omitempty"`    // Videos only: PUT a JPEG poster frame here before completing the upload
+	ThumbnailKey    string            `json:"thumbnail_key,omitempty"`
+	Duplicate       bool              `json:"duplicate"` // The user already has this content; FileID is the existing file and no upload is needed
+}
+
+// CompleteUploadResponseDTO returns the state of a file after upload verification
+type CompleteUploadResponseDTO struct {
+	FileID       uint   `json:"file_id"`
+	UploadStatus string `json:"upload_status"`
+	FileSize     int64  `json:"file_size"`
+	ContentType  string `json:"content_type"`
 }
 
 // BatchUploadResponseDTO returns multiple presigned upload URLs
 type BatchUploadResponseDTO struct {
-	Results      []UploadResponseDTO `json:"results"`
-	TotalCount   int                 `json:"total_count"`
-	SuccessCount int                 `json:"success_count"`
-	FailedCount  int                 `json:"failed_count"`
+	Results        []UploadResponseDTO `json:"results"`
+	TotalCount     int                 `json:"total_count"`
+	SuccessCount   int                 `json:"success_count"`
+	FailedCount    int                 `json:"failed_count"`
+	DuplicateCount int                 `json:"duplicate_count"`
 }
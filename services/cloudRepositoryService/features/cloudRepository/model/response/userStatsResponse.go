@@ -2,7 +2,8 @@ package response
 
 // StorageInfoDTO represents storage usage information
 type StorageInfoDTO struct {
-	Used       int64   `json:"used"`       // Bytes
+	Used       int64   `json:"used"`       // Bytes, including the trash
+	Trash      int64   `json:"trash"`      // Bytes held by files in the trash
 	Total      int64   `json:"total"`      // Bytes
 	Percentage float64 `json:"percentage"`
 }
@@ -51,6 +51,7 @@ func (r *ActivityHistoryCloudRepositoryRepository) GetMonthlyUsedTags(ctx contex
 			AND cf.created_at >= ?
 			AND cf.created_at < ?
 			AND cf.deleted_at IS NULL
+			AND cf.upload_status = ?
 		UNION
 		SELECT DISTINCT
 			DATE(al.created_at) as date,
@@ -71,7 +72,7 @@ func (r *ActivityHistoryCloudRepositoryRepository) GetMonthlyUsedTags(ctx contex
 
 	var results []TagResult
 	err := r.db.WithContext(ctx).Raw(query,
-		userID, startDate, endDate,
+		userID, startDate, endDate, entity.UploadStatusCommitted,
 		userID, entity.ActivityTypeTagAdd, startDate, endDate).
 		Scan(&results).Error
 
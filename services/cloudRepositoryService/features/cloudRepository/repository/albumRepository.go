@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AlbumRepository struct {
+	db *gorm.DB
+}
+
+func NewAlbumRepository(db *gorm.DB) _interface.IAlbumRepository {
+	return &AlbumRepository{
+		db: db,
+	}
+}
+
+// CreateAlbum creates a new album record
+func (r *AlbumRepository) CreateAlbum(ctx context.Context, album *entity.Album) error {
+	return r.db.WithContext(ctx).Create(album).Error
+}
+
+// GetUserAlbums retrieves every album of a user, ordered by name
+func (r *AlbumRepository) GetUserAlbums(ctx context.Context, userID uint) ([]entity.Album, error) {
+	var albums []entity.Album
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC, id ASC").
+		Find(&albums).Error
+
+	return albums, err
+}
+
+// UpdateAlbum updates the given columns of an album
+func (r *AlbumRepository) UpdateAlbum(ctx context.Context, userID, albumID uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.Album{}).
+		Where("id = ? AND user_id = ?", albumID, userID).
+		Updates(updates).Error
+}
+
+// DeleteAlbums deletes albums and their file memberships; the files themselves are kept
+func (r *AlbumRepository) DeleteAlbums(ctx context.Context, userID uint, albumIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("album_id IN ?", albumIDs).Delete(&entity.AlbumFile{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id IN ? AND user_id = ?", albumIDs, userID).Delete(&entity.Album{}).Error
+	})
+}
+
+// CountAlbumFiles counts the committed files outside the trash in each album
+func (r *AlbumRepository) CountAlbumFiles(ctx context.Context, albumIDs []uint) (map[uint]int64, error) {
+	counts := make(map[uint]int64, len(albumIDs))
+	if len(albumIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		AlbumID uint
+		Count   int64
+	}
+	err := r.db.WithContext(ctx).
+		Table("album_files").
+		Select("album_files.album_id, COUNT(*) AS count").
+		Joins("JOIN cloud_files ON cloud_files.id = album_files.file_id").
+		Where("album_files.album_id IN ? AND cloud_files.deleted_at IS NULL AND cloud_files.upload_status = ?", albumIDs, entity.UploadStatusCommitted).
+		Group("album_files.album_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.AlbumID] = row.Count
+	}
+	return counts, nil
+}
+
+// GetLiveFiles retrieves the user's committed files outside the trash among fileIDs
+func (r *AlbumRepository) GetLiveFiles(ctx context.Context, userID uint, fileIDs []uint) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND user_id = ? AND deleted_at IS NULL AND upload_status = ?", fileIDs, userID, entity.UploadStatusCommitted).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GetAlbumFileIDs retrieves the IDs of every file in an album in manual order, including files in the trash
+func (r *AlbumRepository) GetAlbumFileIDs(ctx context.Context, albumID uint) ([]uint, error) {
+	var fileIDs []uint
+	err := r.db.WithContext(ctx).
+		Model(&entity.AlbumFile{}).
+		Where("album_id = ?", albumID).
+		Order("position ASC, file_id ASC").
+		Pluck("file_id", &fileIDs).Error
+
+	return fileIDs, err
+}
+
+// HasAlbumFile checks if a file is in an album
+func (r *AlbumRepository) HasAlbumFile(ctx context.Context, albumID, fileID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.AlbumFile{}).
+		Where("album_id = ? AND file_id = ?", albumID, fileID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// AddAlbumFiles appends files to the end of an album, skipping files already in it.
+// The first file added to an album without a cover becomes its cover.
+// Returns the number of added files.
+func (r *AlbumRepository) AddAlbumFiles(ctx context.Context, albumID uint, fileIDs []uint) (int, error) {
+	added := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the album so concurrent additions do not get the same positions
+		var album entity.Album
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "cover_file_id").
+			Where("id = ?", albumID).
+			First(&album).Error; err != nil {
+			return sharedErrors.NotFound("album not found")
+		}
+
+		var existing []uint
+		if err := tx.Model(&entity.AlbumFile{}).
+			Where("album_id = ? AND file_id IN ?", albumID, fileIDs).
+			Pluck("file_id", &existing).Error; err != nil {
+			return err
+		}
+		var maxPosition int
+		if err := tx.Model(&entity.AlbumFile{}).
+			Where("album_id = ?", albumID).
+			Select("COALESCE(MAX(position), -1)").
+			Scan(&maxPosition).Error; err != nil {
+			return err
+		}
+
+		skip := make(map[uint]bool, len(existing)+len(fileIDs))
+		for _, id := range existing {
+			skip[id] = true
+		}
+		var rows []entity.AlbumFile
+		for _, id := range fileIDs {
+			if skip[id] {
+				continue
+			}
+			skip[id] = true
+			maxPosition++
+			rows = append(rows, entity.AlbumFile{AlbumID: albumID, FileID: id, Position: maxPosition})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		added = len(rows)
+
+		if album.CoverFileID == nil {
+			return tx.Model(&entity.Album{}).Where("id = ?", albumID).Update("cover_file_id", rows[0].FileID).Error
+		}
+		return nil
+	})
+
+	return added, err
+}
+
+// RemoveAlbumFile removes a file from an album.
+// If the file was the cover, the first remaining file becomes the cover.
+func (r *AlbumRepository) RemoveAlbumFile(ctx context.Context, albumID, fileID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("album_id = ? AND file_id = ?", albumID, fileID).Delete(&entity.AlbumFile{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sharedErrors.NotFound("file not found in album")
+		}
+
+		return tx.Exec(
+			"UPDATE albums SET cover_file_id = (SELECT file_id FROM album_files WHERE album_id = ? ORDER BY position ASC, file_id ASC LIMIT 1) WHERE id = ? AND cover_file_id = ?",
+			albumID, albumID, fileID,
+		).Error
+	})
+}
+
+// SetAlbumFilePositions stores fileIDs as the manual order of an album
+func (r *AlbumRepository) SetAlbumFilePositions(ctx context.Context, albumID uint, fileIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for position, fileID := range fileIDs {
+			if err := tx.Model(&entity.AlbumFile{}).
+				Where("album_id = ? AND file_id = ?", albumID, fileID).
+				Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ArchiveRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewArchiveRepository(db *gorm.DB, bucket string) _interface.IArchiveRepository {
+	return &ArchiveRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetLiveFiles retrieves the committed files outside the trash among the given IDs
+func (r *ArchiveRepository) GetLiveFiles(ctx context.Context, fileIDs []uint) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND deleted_at IS NULL AND upload_status = ?", fileIDs, entity.UploadStatusCommitted).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GetTaggedFileIDs retrieves up to limit IDs of the user's live files carrying the tag, oldest first
+func (r *ArchiveRepository) GetTaggedFileIDs(ctx context.Context, userID uint, tag string, limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).
+		Model(&entity.CloudFile{}).
+		Where("user_id = ? AND deleted_at IS NULL AND upload_status = ?", userID, entity.UploadStatusCommitted).
+		Where("id IN (SELECT cloud_file_id FROM file_tags JOIN tags ON tags.id = file_tags.tag_id WHERE tags.user_id = ? AND tags.name = ?)", userID, tag).
+		Order("created_at ASC, id ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+
+	return ids, err
+}
+
+// OpenObject opens an object for streaming
+func (r *ArchiveRepository) OpenObject(ctx context.Context, s3Key string) (io.ReadCloser, error) {
+	return sharedAws.OpenObject(ctx, r.bucket, s3Key)
+}
+
+// CreateExportJob creates a pending export
+func (r *ArchiveRepository) CreateExportJob(ctx context.Context, job *entity.ExportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetExportJob retrieves an export of the user
+func (r *ArchiveRepository) GetExportJob(ctx context.Context, userID, jobID uint) (*entity.ExportJob, error) {
+	var job entity.ExportJob
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", jobID, userID).First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetUserExportJobs retrieves a page of the user's exports, newest first
+func (r *ArchiveRepository) GetUserExportJobs(ctx context.Context, userID uint, offset, limit int) ([]entity.ExportJob, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.ExportJob{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []entity.ExportJob
+	err := query.
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&jobs).Error
+
+	return jobs, total, err
+}
+
+// ClaimExportJob marks the oldest pending export as running and returns it.
+// Exports left running since before staleBefore (e.g. by a restarted server) are claimed again.
+// Returns nil when there is nothing to do.
+func (r *ArchiveRepository) ClaimExportJob(ctx context.Context, staleBefore time.Time) (*entity.ExportJob, error) {
+	var job entity.ExportJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets several servers claim different exports at the same time
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND started_at < ?)", entity.ExportStatusPending, entity.ExportStatusRunning, staleBefore).
+			Order("created_at ASC, id ASC").
+			First(&job).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		job.Status = entity.ExportStatusRunning
+		job.StartedAt = &now
+		return tx.Model(&entity.ExportJob{}).
+			Where("id = ?", job.ID).
+			Updates(map[string]interface{}{"status": job.Status, "started_at": now}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CompleteExportJob records the archive of a running export
+func (r *ArchiveRepository) CompleteExportJob(ctx context.Context, jobID uint, s3Key string, archiveSize int64, expiresAt time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.ExportJob{}).
+		Where("id = ? AND status = ?", jobID, entity.ExportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       entity.ExportStatusCompleted,
+			"s3_key":       s3Key,
+			"archive_size": archiveSize,
+			"completed_at": time.Now(),
+			"expires_at":   expiresAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.Conflict(fmt.Sprintf("export conflict: export %d is no longer running", jobID))
+	}
+	return nil
+}
+
+// FailExportJob marks a running export as failed.
+// Failed exports expire like completed ones so their records are cleaned up.
+func (r *ArchiveRepository) FailExportJob(ctx context.Context, jobID uint, reason string) error {
+	if len(reason) > 500 {
+		reason = reason[:500]
+	}
+	now := time.Now()
+	return r.db.WithContext(ctx).
+		Model(&entity.ExportJob{}).
+		Where("id = ? AND status = ?", jobID, entity.ExportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":       entity.ExportStatusFailed,
+			"error":        reason,
+			"completed_at": now,
+			"expires_at":   now.Add(24 * time.Hour),
+		}).Error
+}
+
+// GetExpiredExportJobs retrieves up to limit finished exports that expired before now
+func (r *ArchiveRepository) GetExpiredExportJobs(ctx context.Context, now time.Time, limit int) ([]entity.ExportJob, error) {
+	var jobs []entity.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("status IN ? AND expires_at < ?", []entity.ExportStatus{entity.ExportStatusCompleted, entity.ExportStatusFailed}, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&jobs).Error
+
+	return jobs, err
+}
+
+// DeleteExportJob removes an export record
+func (r *ArchiveRepository) DeleteExportJob(ctx context.Context, jobID uint) error {
+	return r.db.WithContext(ctx).Delete(&entity.ExportJob{}, jobID).Error
+}
+
+// CreateMultipartUpload starts an S3 multipart upload and returns its upload ID
+func (r *ArchiveRepository) CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error) {
+	return sharedAws.CreateMultipartUpload(ctx, r.bucket, s3Key, contentType)
+}
+
+// UploadPart uploads one part of a multipart upload and returns its ETag
+func (r *ArchiveRepository) UploadPart(ctx context.Context, s3Key, s3UploadID string, partNumber int32, body []byte) (string, error) {
+	return sharedAws.UploadPart(ctx, r.bucket, s3Key, s3UploadID, partNumber, body)
+}
+
+// CompleteMultipartUpload assembles the parts into the final S3 object
+func (r *ArchiveRepository) CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error {
+	return sharedAws.CompleteMultipartUpload(ctx, r.bucket, s3Key, s3UploadID, parts)
+}
+
+// AbortMultipartUpload aborts an S3 multipart upload
+func (r *ArchiveRepository) AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error {
+	return sharedAws.AbortMultipartUpload(ctx, r.bucket, s3Key, s3UploadID)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *ArchiveRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
+
+// GeneratePresignedDownloadURLWithFilename generates a presigned URL that downloads the object as filename
+func (r *ArchiveRepository) GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURLWithFilename(ctx, r.bucket, s3Key, filename, expiration)
+}
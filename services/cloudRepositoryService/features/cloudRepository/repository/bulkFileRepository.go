@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+type BulkFileRepository struct {
+	db *gorm.DB
+}
+
+func NewBulkFileRepository(db *gorm.DB) _interface.IBulkFileRepository {
+	return &BulkFileRepository{
+		db: db,
+	}
+}
+
+// GetFileIDsByFilter retrieves up to limit IDs of the user's files matching a file listing filter, newest first
+func (r *BulkFileRepository) GetFileIDsByFilter(ctx context.Context, userID uint, filter request.ListFilesRequestDTO, limit int) ([]uint, error) {
+	query := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Where("user_id = ? AND deleted_at IS NULL AND upload_status = ?", userID, entity.UploadStatusCommitted)
+	query = applyFileFilters(query, filter)
+
+	var ids []uint
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Pluck("id", &ids).Error
+
+	return ids, err
+}
+
+// GetLiveFiles retrieves the committed files outside the trash among the given IDs
+func (r *BulkFileRepository) GetLiveFiles(ctx context.Context, fileIDs []uint) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND deleted_at IS NULL AND upload_status = ?", fileIDs, entity.UploadStatusCommitted).
+		Find(&files).Error
+
+	return files, err
+}
+
+// TrashFiles moves the user's files to the trash in one transaction
+func (r *BulkFileRepository) TrashFiles(ctx context.Context, userID uint, fileIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		for _, fileID := range fileIDs {
+			result := tx.Model(&entity.CloudFile{}).
+				Where("id = ? AND user_id = ? AND deleted_at IS NULL", fileID, userID).
+				Update("deleted_at", now)
+
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return sharedErrors.NotFound(fmt.Sprintf("file %d not found or already deleted", fileID))
+			}
+			if err := trashFileStorage(tx, fileID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// AddTags attaches tags to files in one transaction, creating missing tags.
+// Tags belong to the file owner, so files of different owners get different tags with the same name.
+func (r *BulkFileRepository) AddTags(ctx context.Context, files []entity.CloudFile, names []string) error {
+	fileIDsByOwner := make(map[uint][]uint)
+	for _, file := range files {
+		fileIDsByOwner[file.UserID] = append(fileIDsByOwner[file.UserID], file.ID)
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for ownerID, fileIDs := range fileIDsByOwner {
+			for _, name := range names {
+				tag := entity.Tag{
+					UserID: ownerID,
+					Name:   name,
+				}
+				if err := tx.Where("user_id = ? AND name = ?", ownerID, name).FirstOrCreate(&tag).Error; err != nil {
+					return fmt.Errorf("failed to process tag %s: %w", name, err)
+				}
+				if err := tx.Exec("INSERT IGNORE INTO file_tags (cloud_file_id, tag_id) SELECT id, ? FROM cloud_files WHERE id IN ?", tag.ID, fileIDs).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveTags detaches the owner's tags with the given names from files; the tags themselves are kept
+func (r *BulkFileRepository) RemoveTags(ctx context.Context, fileIDs []uint, names []string) error {
+	return r.db.WithContext(ctx).Exec(
+		"DELETE file_tags FROM file_tags "+
+			"JOIN tags ON tags.id = file_tags.tag_id "+
+			"JOIN cloud_files ON cloud_files.id = file_tags.cloud_file_id AND cloud_files.user_id = tags.user_id "+
+			"WHERE file_tags.cloud_file_id IN ? AND tags.name IN ?",
+		fileIDs, names).Error
+}
+
+// AddFavorites adds files to the user's favorites; files already favorited are skipped
+func (r *BulkFileRepository) AddFavorites(ctx context.Context, userID uint, fileIDs []uint) error {
+	return r.db.WithContext(ctx).Exec(
+		"INSERT IGNORE INTO favorites (user_id, file_id, favorited_at) SELECT ?, id, ? FROM cloud_files WHERE id IN ?",
+		userID, time.Now(), fileIDs).Error
+}
+
+// RemoveFavorites removes files from the user's favorites
+func (r *BulkFileRepository) RemoveFavorites(ctx context.Context, userID uint, fileIDs []uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND file_id IN ?", userID, fileIDs).
+		Delete(&entity.Favorite{}).Error
+}
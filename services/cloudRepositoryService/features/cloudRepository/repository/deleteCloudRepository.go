@@ -2,12 +2,12 @@ package repository
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 	"gorm.io/gorm"
 )
 
@@ -28,33 +28,61 @@ func (r *DeleteCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Ke
 	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
 }
 
-// SoftDeleteFile soft deletes a file
+// SoftDeleteFile moves a file to the trash by setting deleted_at.
+// Its objects stay in S3 and its size keeps counting against the quota, now also as trash usage.
 func (r *DeleteCloudRepositoryRepository) SoftDeleteFile(ctx context.Context, id uint, userID uint) error {
-	now := time.Now()
-	result := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
-		Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).
-		Update("deleted_at", now)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file entity.CloudFile
+		if err := tx.Select("id", "upload_status").
+			Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).
+			First(&file).Error; err != nil {
+			return sharedErrors.NotFound("file not found or already deleted")
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("file not found or already deleted")
-	}
-	return nil
+		now := time.Now()
+		result := tx.Model(&entity.CloudFile{}).
+			Where("id = ? AND deleted_at IS NULL", id).
+			Update("deleted_at", now)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sharedErrors.NotFound("file not found or already deleted")
+		}
+		if file.UploadStatus != entity.UploadStatusFailed {
+			return trashFileStorage(tx, id)
+		}
+		return nil
+	})
 }
 
-// GetFileByID retrieves a file by ID
+// GetFileByID retrieves a file by ID with its generated thumbnails
 func (r *DeleteCloudRepositoryRepository) GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error) {
 	var file entity.CloudFile
-	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&file).Error
+	err := r.db.WithContext(ctx).Preload("Thumbnails").Where("id = ? AND deleted_at IS NULL", id).First(&file).Error
 	if err != nil {
 		return nil, err
 	}
 	return &file, nil
 }
 
-// HardDeleteFile permanently deletes a file from database
+// HardDeleteFile permanently deletes a file from database, releasing the storage it still held
 func (r *DeleteCloudRepositoryRepository) HardDeleteFile(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Unscoped().Delete(&entity.CloudFile{}, id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file entity.CloudFile
+		if err := tx.Unscoped().Select("id", "upload_status", "deleted_at").First(&file, id).Error; err != nil {
+			return err
+		}
+		if file.UploadStatus != entity.UploadStatusFailed {
+			release := releaseFileStorage
+			if file.DeletedAt != nil {
+				release = purgeFileStorage
+			}
+			if err := release(tx, id); err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Delete(&entity.CloudFile{}, id).Error
+	})
 }
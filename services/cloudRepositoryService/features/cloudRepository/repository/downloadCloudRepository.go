@@ -32,12 +32,22 @@ func (r *DownloadCloudRepositoryRepository) GeneratePresignedDownloadURLWithFile
 	return sharedAws.GeneratePresignedDownloadURLWithFilename(ctx, r.bucket, s3Key, filename, expiration)
 }
 
-// GetFileByID retrieves a file by ID
+// GetFileByID retrieves a committed file by ID
 func (r *DownloadCloudRepositoryRepository) GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error) {
 	var file entity.CloudFile
-	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&file).Error
+	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL AND upload_status = ?", id, entity.UploadStatusCommitted).First(&file).Error
 	if err != nil {
 		return nil, err
 	}
 	return &file, nil
 }
+
+// GetThumbnail retrieves a generated thumbnail of a file at the given size
+func (r *DownloadCloudRepositoryRepository) GetThumbnail(ctx context.Context, fileID uint, size int) (*entity.FileThumbnail, error) {
+	var thumbnail entity.FileThumbnail
+	err := r.db.WithContext(ctx).Where("file_id = ? AND size = ?", fileID, size).First(&thumbnail).Error
+	if err != nil {
+		return nil, err
+	}
+	return &thumbnail, nil
+}
@@ -41,8 +41,9 @@ func (r *FavoriteRepository) RemoveFavorite(ctx context.Context, userID, fileID
 		Delete(&entity.Favorite{}).Error
 }
 
-// GetFavoritesByUserID retrieves all favorited files for a user with filtering and pagination
-func (r *FavoriteRepository) GetFavoritesByUserID(ctx context.Context, userID uint, filter request.ListFavoritesRequestDTO) ([]entity.CloudFile, int64, error) {
+// GetFavoritesByUserID retrieves a page of a user's favorited files with filtering, by offset or after a cursor.
+// Returns the cursor of the next page when there is one; the total is 0 with SkipTotal.
+func (r *FavoriteRepository) GetFavoritesByUserID(ctx context.Context, userID uint, filter request.ListFavoritesRequestDTO) ([]entity.CloudFile, int64, string, error) {
 	var files []entity.CloudFile
 	var total int64
 
@@ -50,8 +51,12 @@ func (r *FavoriteRepository) GetFavoritesByUserID(ctx context.Context, userID ui
 	query := r.db.WithContext(ctx).
 		Model(&entity.CloudFile{}).
 		Preload("Tags"). // Eager load tags
+		Preload("Exif").
+		Preload("Palette", orderPalette).
 		Joins("INNER JOIN favorites ON cloud_files.id = favorites.file_id").
-		Where("favorites.user_id = ? AND cloud_files.deleted_at IS NULL", userID)
+		Where("favorites.user_id = ? AND cloud_files.deleted_at IS NULL AND cloud_files.upload_status = ?", userID, entity.UploadStatusCommitted).
+		// Favorites of shared files disappear once the grant is revoked
+		Where("(cloud_files.user_id = favorites.user_id OR cloud_files.id IN (SELECT file_id FROM file_grants WHERE grantee_id = favorites.user_id))")
 
 	// Apply filename search filter
 	if filter.Q != "" {
@@ -71,25 +76,20 @@ func (r *FavoriteRepository) GetFavoritesByUserID(ctx context.Context, userID ui
 	}
 
 	// Get total count before pagination
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if !filter.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, "", err
+		}
 	}
 
 	// Apply sorting
-	sortField := "favorites.favorited_at" // Default sort by when favorited
-	if filter.Sort == "fileName" {
-		sortField = "cloud_files.file_name"
-	} else if filter.Sort == "uploadDate" {
-		sortField = "cloud_files.created_at"
-	}
-
-	sortOrder := "DESC"
-	if filter.Order == "asc" {
-		sortOrder = "ASC"
+	order := favoriteKeysetOrder(filter)
+	query, err := order.apply(query, "cloud_files.id", filter.Cursor)
+	if err != nil {
+		return nil, 0, "", err
 	}
-	query = query.Order(sortField + " " + sortOrder)
 
-	// Apply pagination
+	// Apply pagination; a cursor replaces the page number
 	page := filter.Page
 	if page < 1 {
 		page = 1
@@ -101,14 +101,52 @@ func (r *FavoriteRepository) GetFavoritesByUserID(ctx context.Context, userID ui
 	if pageSize > 100 {
 		pageSize = 100
 	}
+	if filter.Cursor == "" {
+		query = query.Offset((page - 1) * pageSize)
+	}
 
-	offset := (page - 1) * pageSize
-	err := query.Limit(pageSize).Offset(offset).Find(&files).Error
-	if err != nil {
-		return nil, 0, err
+	// One extra row tells whether there is a next page
+	if err := query.Limit(pageSize + 1).Find(&files).Error; err != nil {
+		return nil, 0, "", err
+	}
+
+	nextCursor := ""
+	if len(files) > pageSize {
+		files = files[:pageSize]
+		last := files[pageSize-1]
+		value := fileSortValue(order, &last)
+		if order.Column == "favorites.favorited_at" {
+			var favorite entity.Favorite
+			if err := r.db.WithContext(ctx).Where("user_id = ? AND file_id = ?", userID, last.ID).First(&favorite).Error; err != nil {
+				return nil, 0, "", err
+			}
+			value = favorite.FavoritedAt
+		}
+		nextCursor = encodeCursor(order, value, last.ID)
+	}
+
+	return files, total, nextCursor, nil
+}
+
+// favoriteKeysetOrder returns the order of a favorites listing; every favorites order supports cursors
+func favoriteKeysetOrder(filter request.ListFavoritesRequestDTO) keysetOrder {
+	order := keysetOrder{Name: "favoritedAt", Column: "favorites.favorited_at", Kind: keysetTime} // Default sort by when favorited
+	switch filter.Sort {
+	case "uploadDate":
+		order = keysetOrder{Name: "uploadDate", Column: "cloud_files.created_at", Kind: keysetTime}
+	case "fileName":
+		order = keysetOrder{Name: "fileName", Column: "cloud_files.file_name", Kind: keysetString}
+	case "fileSize":
+		order = keysetOrder{Name: "fileSize", Column: "cloud_files.file_size", Kind: keysetInt}
 	}
 
-	return files, total, nil
+	order.Desc = filter.Order != "asc"
+	if order.Desc {
+		order.Name += ":desc"
+	} else {
+		order.Name += ":asc"
+	}
+	return order
 }
 
 // CheckIsFavorited checks if a file is favorited by a user
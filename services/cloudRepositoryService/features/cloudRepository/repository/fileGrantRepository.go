@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	"github.com/JokerTrickster/joker_backend/shared/db/mysql"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FileGrantRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewFileGrantRepository(db *gorm.DB, bucket string) _interface.IFileGrantRepository {
+	return &FileGrantRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetGrant retrieves the grant of a user on a file
+func (r *FileGrantRepository) GetGrant(ctx context.Context, fileID, granteeID uint) (*entity.FileGrant, error) {
+	var grant entity.FileGrant
+	err := r.db.WithContext(ctx).
+		Where("file_id = ? AND grantee_id = ?", fileID, granteeID).
+		First(&grant).Error
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+// GetFileGrants retrieves every grant on a file, oldest first
+func (r *FileGrantRepository) GetFileGrants(ctx context.Context, fileID uint) ([]entity.FileGrant, error) {
+	var grants []entity.FileGrant
+	err := r.db.WithContext(ctx).
+		Where("file_id = ?", fileID).
+		Order("created_at ASC, id ASC").
+		Find(&grants).Error
+
+	return grants, err
+}
+
+// UpsertGrant creates a grant or changes the permission of an existing one
+func (r *FileGrantRepository) UpsertGrant(ctx context.Context, grant *entity.FileGrant) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "file_id"}, {Name: "grantee_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"permission", "updated_at"}),
+		}).
+		Create(grant).Error
+	if err != nil {
+		return err
+	}
+
+	// Reload to return the original created_at of an updated grant
+	return r.db.WithContext(ctx).
+		Where("file_id = ? AND grantee_id = ?", grant.FileID, grant.GranteeID).
+		First(grant).Error
+}
+
+// DeleteGrant removes the grant of a user on a file
+func (r *FileGrantRepository) DeleteGrant(ctx context.Context, fileID, granteeID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("file_id = ? AND grantee_id = ?", fileID, granteeID).
+		Delete(&entity.FileGrant{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.NotFound("grant not found")
+	}
+	return nil
+}
+
+// GetSharedWithUser retrieves a page of grants to the user on committed files outside the trash, newest first.
+// Only grants with one of the given permissions are returned.
+func (r *FileGrantRepository) GetSharedWithUser(ctx context.Context, granteeID uint, permissions []entity.FilePermission, offset, limit int) ([]entity.FileGrant, int64, error) {
+	query := r.db.WithContext(ctx).
+		Model(&entity.FileGrant{}).
+		Joins("JOIN cloud_files ON cloud_files.id = file_grants.file_id").
+		Where("file_grants.grantee_id = ? AND file_grants.permission IN ?", granteeID, permissions).
+		Where("cloud_files.deleted_at IS NULL AND cloud_files.upload_status = ?", entity.UploadStatusCommitted)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var grants []entity.FileGrant
+	err := query.
+		Preload("File").
+		Preload("File.Tags").
+		Preload("File.Exif").
+		Preload("File.Palette", orderPalette).
+		Order("file_grants.created_at DESC, file_grants.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&grants).Error
+
+	return grants, total, err
+}
+
+// UserExists checks if a registered user exists
+func (r *FileGrantRepository) UserExists(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&mysql.User{}).
+		Where("id = ?", userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *FileGrantRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FileMetadataRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewFileMetadataRepository(db *gorm.DB, bucket string) _interface.IFileMetadataRepository {
+	return &FileMetadataRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetFileByID retrieves a committed file outside the trash with its tags and EXIF metadata
+func (r *FileMetadataRepository) GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Preload("Tags").
+		Preload("Exif").
+		Preload("Palette", orderPalette).
+		Where("id = ? AND deleted_at IS NULL AND upload_status = ?", fileID, entity.UploadStatusCommitted).
+		First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// UpdateFile locks a file, lets apply change it and saves its name, description and custom fields.
+// An error from apply aborts without changes. updated_at is stored with second precision, so it is moved forward
+// by at least a second to give every saved change a new ETag.
+func (r *FileMetadataRepository) UpdateFile(ctx context.Context, fileID uint, apply func(file *entity.CloudFile) error) (*entity.CloudFile, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var file entity.CloudFile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL AND upload_status = ?", fileID, entity.UploadStatusCommitted).
+			First(&file).Error; err != nil {
+			return sharedErrors.NotFound("file not found")
+		}
+
+		if err := apply(&file); err != nil {
+			return err
+		}
+		file.UpdatedAt = nextUpdatedAt(file.UpdatedAt, time.Now())
+
+		return tx.Model(&file).
+			Select("file_name", "description", "custom_fields", "updated_at").
+			UpdateColumns(&file).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetFileByID(ctx, fileID)
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *FileMetadataRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
+
+// nextUpdatedAt returns now truncated to whole seconds, or one second after previous if that is not later
+func nextUpdatedAt(previous, now time.Time) time.Time {
+	next := now.Truncate(time.Second)
+	if floor := previous.Truncate(time.Second); !next.After(floor) {
+		next = floor.Add(time.Second)
+	}
+	return next
+}
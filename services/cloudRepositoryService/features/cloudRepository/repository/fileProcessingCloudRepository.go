@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FileProcessingCloudRepositoryRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewFileProcessingCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.IFileProcessingCloudRepositoryRepository {
+	return &FileProcessingCloudRepositoryRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetFileByID retrieves a committed file by ID
+func (r *FileProcessingCloudRepositoryRepository) GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL AND upload_status = ?", id, entity.UploadStatusCommitted).First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// HeadObject retrieves object metadata from S3
+func (r *FileProcessingCloudRepositoryRepository) HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error) {
+	return sharedAws.HeadObject(ctx, r.bucket, s3Key)
+}
+
+// GetObject downloads an object from S3 into memory
+func (r *FileProcessingCloudRepositoryRepository) GetObject(ctx context.Context, s3Key string) ([]byte, error) {
+	return sharedAws.GetObject(ctx, r.bucket, s3Key)
+}
+
+// GetObjectRange downloads part of an object from S3
+func (r *FileProcessingCloudRepositoryRepository) GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error) {
+	return sharedAws.GetObjectRange(ctx, r.bucket, s3Key, offset, length)
+}
+
+// PutObject uploads a generated object to S3
+func (r *FileProcessingCloudRepositoryRepository) PutObject(ctx context.Context, s3Key, contentType string, body []byte) error {
+	return sharedAws.PutObject(ctx, r.bucket, s3Key, contentType, body)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *FileProcessingCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
+
+// SaveThumbnails upserts the generated thumbnails and points the file's ThumbnailKey at the smallest one
+func (r *FileProcessingCloudRepositoryRepository) SaveThumbnails(ctx context.Context, fileID uint, thumbnails []entity.FileThumbnail) error {
+	if len(thumbnails) == 0 {
+		return fmt.Errorf("no thumbnails to save")
+	}
+
+	smallest := thumbnails[0]
+	for _, thumbnail := range thumbnails[1:] {
+		if thumbnail.Size < smallest.Size {
+			smallest = thumbnail
+		}
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "file_id"}, {Name: "size"}},
+			DoUpdates: clause.AssignmentColumns([]string{"s3_key", "width", "height", "updated_at"}),
+		}).Create(&thumbnails).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.CloudFile{}).
+			Where("id = ?", fileID).
+			Updates(map[string]interface{}{
+				"thumbnail_key":    smallest.S3Key,
+				"thumbnail_status": entity.ThumbnailStatusReady,
+			}).Error
+	})
+}
+
+// UpdateThumbnailStatus sets the thumbnail generation status of a file
+func (r *FileProcessingCloudRepositoryRepository) UpdateThumbnailStatus(ctx context.Context, fileID uint, status entity.ThumbnailStatus) error {
+	return r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Where("id = ?", fileID).
+		Update("thumbnail_status", status).Error
+}
+
+// SaveVideoMetadata stores the extracted duration, resolution, codec and rotation and marks the metadata ready
+func (r *FileProcessingCloudRepositoryRepository) SaveVideoMetadata(ctx context.Context, file *entity.CloudFile) error {
+	file.MetadataStatus = entity.MetadataStatusReady
+	return r.db.WithContext(ctx).Model(file).
+		Select("duration", "width", "height", "video_codec", "rotation", "metadata_status").
+		Updates(file).Error
+}
+
+// SaveExif upserts the EXIF metadata of a file and marks its metadata ready
+func (r *FileProcessingCloudRepositoryRepository) SaveExif(ctx context.Context, exif *entity.FileExif) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "file_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"taken_at", "camera_make", "camera_model", "lens_model", "exposure_time", "f_number",
+				"iso", "focal_length", "orientation", "latitude", "longitude", "altitude", "geohash", "updated_at",
+			}),
+		}).Create(exif).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.CloudFile{}).
+			Where("id = ?", exif.FileID).
+			Update("metadata_status", entity.MetadataStatusReady).Error
+	})
+}
+
+// UpdateMetadataStatus sets the metadata extraction status of a file
+func (r *FileProcessingCloudRepositoryRepository) UpdateMetadataStatus(ctx context.Context, fileID uint, status entity.MetadataStatus) error {
+	return r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Where("id = ?", fileID).
+		Update("metadata_status", status).Error
+}
+
+// SaveImageAnalysis stores the analysis results of an image, replacing its palette, and marks its analysis ready
+func (r *FileProcessingCloudRepositoryRepository) SaveImageAnalysis(ctx context.Context, file *entity.CloudFile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("file_id = ?", file.ID).Delete(&entity.FileColor{}).Error; err != nil {
+			return err
+		}
+		if len(file.Palette) > 0 {
+			if err := tx.Create(&file.Palette).Error; err != nil {
+				return err
+			}
+		}
+
+		file.AnalysisStatus = entity.AnalysisStatusReady
+		return tx.Model(&entity.CloudFile{}).
+			Where("id = ?", file.ID).
+			Updates(map[string]interface{}{
+				"perceptual_hash": file.PerceptualHash,
+				"sharpness_score": file.SharpnessScore,
+				"exposure_score":  file.ExposureScore,
+				"analysis_status": file.AnalysisStatus,
+			}).Error
+	})
+}
+
+// UpdateAnalysisStatus sets the image analysis status of a file
+func (r *FileProcessingCloudRepositoryRepository) UpdateAnalysisStatus(ctx context.Context, fileID uint, status entity.AnalysisStatus) error {
+	return r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Where("id = ?", fileID).
+		Update("analysis_status", status).Error
+}
+
+// GetFilesPendingProcessing retrieves committed files created before the given time that still need thumbnails, metadata or analysis
+func (r *FileProcessingCloudRepositoryRepository) GetFilesPendingProcessing(ctx context.Context, createdBefore time.Time, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("(thumbnail_status = ? OR metadata_status = ? OR analysis_status = ?) AND upload_status = ? AND created_at < ? AND deleted_at IS NULL",
+			entity.ThumbnailStatusPending, entity.MetadataStatusPending, entity.AnalysisStatusPending, entity.UploadStatusCommitted, createdBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Keyword search uses the FULLTEXT indexes built with the ngram parser (ftx_cloud_files_search and ftx_tags_name),
+// which index every run of ngram_token_size characters, so Korean and other text without spaces between words matches
+// as well as English.
+const (
+	// fileSearchMatch matches the file name and description
+	fileSearchMatch = "MATCH(file_name, description) AGAINST(? IN BOOLEAN MODE)"
+	// tagSearchMatch matches a tag name
+	tagSearchMatch = "MATCH(tags.name) AGAINST(? IN BOOLEAN MODE)"
+)
+
+// fullTextQuery builds a boolean mode query that requires every term of the keyword.
+// Terms end with * so those shorter than ngram_token_size match as prefixes; longer terms are phrase searches of their
+// ngrams, which also match inside words. Returns an empty string when the keyword has no terms.
+func fullTextQuery(keyword string) string {
+	terms := request.SearchTerms(keyword)
+	for i, term := range terms {
+		terms[i] = "+" + term + "*"
+	}
+	return strings.Join(terms, " ")
+}
+
+// orderByRelevance ranks files by how well their name and description match the keyword plus their best matching tag
+func orderByRelevance(query *gorm.DB, keyword string) *gorm.DB {
+	q := fullTextQuery(keyword)
+	if q == "" {
+		return query.Order("created_at DESC, id DESC")
+	}
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:  fileSearchMatch + " + COALESCE((SELECT MAX(" + tagSearchMatch + ") FROM file_tags JOIN tags ON tags.id = file_tags.tag_id WHERE file_tags.cloud_file_id = cloud_files.id), 0) DESC, id DESC",
+		Vars: []interface{}{q, q},
+	}})
+}
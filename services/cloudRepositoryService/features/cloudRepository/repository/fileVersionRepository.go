@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FileVersionRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewFileVersionRepository(db *gorm.DB, bucket string) _interface.IFileVersionRepository {
+	return &FileVersionRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetFileByID retrieves a committed file outside the trash
+func (r *FileVersionRepository) GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL AND upload_status = ?", fileID, entity.UploadStatusCommitted).
+		First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// GetPendingVersion retrieves a version of the file that is still being uploaded
+func (r *FileVersionRepository) GetPendingVersion(ctx context.Context, fileID, versionID uint) (*entity.FileVersion, error) {
+	var version entity.FileVersion
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND file_id = ? AND status = ?", versionID, fileID, entity.VersionStatusPending).
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetArchivedVersion retrieves a previous version of the file by its number
+func (r *FileVersionRepository) GetArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.FileVersion, error) {
+	var version entity.FileVersion
+	err := r.db.WithContext(ctx).
+		Where("file_id = ? AND version = ? AND status = ?", fileID, number, entity.VersionStatusArchived).
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetArchivedVersions retrieves every previous version of the file, newest first
+func (r *FileVersionRepository) GetArchivedVersions(ctx context.Context, fileID uint) ([]entity.FileVersion, error) {
+	var versions []entity.FileVersion
+	err := r.db.WithContext(ctx).
+		Where("file_id = ? AND status = ?", fileID, entity.VersionStatusArchived).
+		Order("version DESC").
+		Find(&versions).Error
+
+	return versions, err
+}
+
+// CreatePendingVersion reserves the version's size against the file owner's quota and saves the pending version.
+// The reservation and the record are written in one transaction so a failed insert never leaks quota.
+func (r *FileVersionRepository) CreatePendingVersion(ctx context.Context, version *entity.FileVersion) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reserveStorage(tx, version.UserID, version.FileSize); err != nil {
+			return err
+		}
+		return tx.Create(version).Error
+	})
+}
+
+// FailPendingVersion marks a pending version as failed and releases its reserved storage
+func (r *FileVersionRepository) FailPendingVersion(ctx context.Context, versionID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.FileVersion{}).
+			Where("id = ? AND status = ?", versionID, entity.VersionStatusPending).
+			Update("status", entity.VersionStatusFailed)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sharedErrors.Conflict("version conflict: version is no longer pending")
+		}
+		return releaseVersionStorage(tx, versionID)
+	})
+}
+
+// CompletePendingVersion makes a pending version the current content of the file under the next version number.
+// The replaced content is archived in the version's row under its own number.
+func (r *FileVersionRepository) CompletePendingVersion(ctx context.Context, fileID, versionID uint) (*entity.CloudFile, error) {
+	return r.swapCurrentVersion(ctx, fileID, func(tx *gorm.DB, file *entity.CloudFile) (*entity.FileVersion, int, error) {
+		var version entity.FileVersion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND file_id = ? AND status = ?", versionID, fileID, entity.VersionStatusPending).
+			First(&version).Error; err != nil {
+			return nil, 0, sharedErrors.Conflict("version conflict: upload has already completed, failed or expired")
+		}
+
+		var latest int
+		if err := tx.Model(&entity.FileVersion{}).
+			Where("file_id = ? AND status = ?", fileID, entity.VersionStatusArchived).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error; err != nil {
+			return nil, 0, err
+		}
+		return &version, max(file.Version, latest) + 1, nil
+	})
+}
+
+// RestoreArchivedVersion makes a previous version the current content of the file again; it keeps its number.
+// The replaced content is archived in the version's row under its own number.
+func (r *FileVersionRepository) RestoreArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.CloudFile, error) {
+	return r.swapCurrentVersion(ctx, fileID, func(tx *gorm.DB, file *entity.CloudFile) (*entity.FileVersion, int, error) {
+		var version entity.FileVersion
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("file_id = ? AND version = ? AND status = ?", fileID, number, entity.VersionStatusArchived).
+			First(&version).Error; err != nil {
+			return nil, 0, sharedErrors.NotFound("version not found")
+		}
+		return &version, number, nil
+	})
+}
+
+// swapCurrentVersion swaps the content of the version chosen by pick with the current content of the file.
+// Storage does not change; thumbnails and metadata are reset so the processor regenerates them for the new content.
+func (r *FileVersionRepository) swapCurrentVersion(ctx context.Context, fileID uint, pick func(tx *gorm.DB, file *entity.CloudFile) (*entity.FileVersion, int, error)) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the file first so concurrent completions, restores and deletions of its versions are serialized
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL AND upload_status = ?", fileID, entity.UploadStatusCommitted).
+			First(&file).Error; err != nil {
+			return sharedErrors.NotFound("file not found")
+		}
+
+		version, number, err := pick(tx, &file)
+		if err != nil {
+			return err
+		}
+
+		replacedCreatedAt := file.CreatedAt
+		if file.VersionCreatedAt != nil {
+			replacedCreatedAt = *file.VersionCreatedAt
+		}
+		if err := tx.Model(&entity.FileVersion{}).
+			Where("id = ?", version.ID).
+			Updates(map[string]interface{}{
+				"version":      file.Version,
+				"s3_key":       file.S3Key,
+				"file_size":    file.FileSize,
+				"content_type": file.ContentType,
+				"sha256":       file.SHA256,
+				"status":       entity.VersionStatusArchived,
+				"created_at":   replacedCreatedAt,
+				"archived_at":  time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+
+		// Thumbnails, video metadata, EXIF and image analysis describe the replaced content
+		if err := tx.Where("file_id = ?", fileID).Delete(&entity.FileThumbnail{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("file_id = ?", fileID).Delete(&entity.FileExif{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("file_id = ?", fileID).Delete(&entity.FileColor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entity.CloudFile{}).
+			Where("id = ?", fileID).
+			Updates(map[string]interface{}{
+				"version":            number,
+				"version_created_at": version.CreatedAt,
+				"s3_key":             version.S3Key,
+				"file_size":          version.FileSize,
+				"content_type":       version.ContentType,
+				"sha256":             version.SHA256,
+				"thumbnail_key":      "",
+				"thumbnail_status":   entity.ThumbnailStatusPending,
+				"metadata_status":    entity.MetadataStatusPending,
+				"analysis_status":    entity.AnalysisStatusPending,
+				"perceptual_hash":    nil,
+				"sharpness_score":    nil,
+				"exposure_score":     nil,
+				"duration":           nil,
+				"width":              nil,
+				"height":             nil,
+				"video_codec":        "",
+				"rotation":           0,
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", fileID).First(&file).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// DeleteArchivedVersion permanently removes a previous version of the file and releases its storage.
+// It returns the removed version; the caller deletes its S3 object afterwards.
+func (r *FileVersionRepository) DeleteArchivedVersion(ctx context.Context, fileID uint, number int) (*entity.FileVersion, error) {
+	var version entity.FileVersion
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the file like swapCurrentVersion so the number cannot change hands while the row is deleted
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("id = ?", fileID).
+			First(&entity.CloudFile{}).Error; err != nil {
+			return sharedErrors.NotFound("file not found")
+		}
+		if err := tx.Where("file_id = ? AND version = ? AND status = ?", fileID, number, entity.VersionStatusArchived).
+			First(&version).Error; err != nil {
+			return sharedErrors.NotFound("version not found")
+		}
+
+		if err := releaseVersionStorage(tx, version.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&entity.FileVersion{}, version.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// GetStalePendingVersions retrieves pending versions created before the given time
+func (r *FileVersionRepository) GetStalePendingVersions(ctx context.Context, createdBefore time.Time, limit int) ([]entity.FileVersion, error) {
+	var versions []entity.FileVersion
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND created_at < ?", entity.VersionStatusPending, createdBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&versions).Error
+
+	return versions, err
+}
+
+// GetPrunableVersions retrieves archived versions beyond the newest keep versions of their file
+// or archived before the given time. fileID limits the search to one file; 0 searches every file.
+func (r *FileVersionRepository) GetPrunableVersions(ctx context.Context, fileID uint, keep int, archivedBefore time.Time, limit int) ([]entity.FileVersion, error) {
+	ranked := r.db.Model(&entity.FileVersion{}).
+		Select("file_versions.*, ROW_NUMBER() OVER (PARTITION BY file_id ORDER BY version DESC) AS version_rank").
+		Where("status = ?", entity.VersionStatusArchived)
+	if fileID != 0 {
+		ranked = ranked.Where("file_id = ?", fileID)
+	}
+
+	var versions []entity.FileVersion
+	err := r.db.WithContext(ctx).
+		Table("(?) AS ranked", ranked).
+		Where("version_rank > ? OR archived_at < ?", keep, archivedBefore).
+		Order("id ASC").
+		Limit(limit).
+		Find(&versions).Error
+
+	return versions, err
+}
+
+// HeadObject retrieves object metadata from S3
+func (r *FileVersionRepository) HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error) {
+	return sharedAws.HeadObject(ctx, r.bucket, s3Key)
+}
+
+// GetObjectRange downloads part of an object from S3
+func (r *FileVersionRepository) GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error) {
+	return sharedAws.GetObjectRange(ctx, r.bucket, s3Key, offset, length)
+}
+
+// GeneratePresignedUploadURL generates a presigned URL for uploading
+func (r *FileVersionRepository) GeneratePresignedUploadURL(ctx context.Context, s3Key, contentType string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedUploadURL(ctx, r.bucket, s3Key, contentType, expiration)
+}
+
+// GeneratePresignedUploadURLWithChecksum generates a presigned URL that S3 rejects unless the body matches the checksum
+func (r *FileVersionRepository) GeneratePresignedUploadURLWithChecksum(ctx context.Context, s3Key, contentType, checksumSHA256 string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedUploadURLWithChecksum(ctx, r.bucket, s3Key, contentType, checksumSHA256, expiration)
+}
+
+// GeneratePresignedDownloadURLWithFilename generates a presigned URL for downloading with Content-Disposition header
+func (r *FileVersionRepository) GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURLWithFilename(ctx, r.bucket, s3Key, filename, expiration)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *FileVersionRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
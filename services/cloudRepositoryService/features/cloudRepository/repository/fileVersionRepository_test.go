@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"gorm.io/gorm"
+)
+
+// createTestVersion saves a pending version of the file through the version repository, reserving its storage
+func createTestVersion(t *testing.T, db *gorm.DB, file *entity.CloudFile, size int64) *entity.FileVersion {
+	t.Helper()
+	version := &entity.FileVersion{
+		FileID:      file.ID,
+		UserID:      file.UserID,
+		S3Key:       fmt.Sprintf("users/%d/versions/%d.jpg", file.UserID, time.Now().UnixNano()),
+		FileSize:    size,
+		ContentType: "image/jpeg",
+		Status:      entity.VersionStatusPending,
+	}
+	if err := NewFileVersionRepository(db, "").CreatePendingVersion(context.Background(), version); err != nil {
+		t.Fatalf("failed to create version: %v", err)
+	}
+	return version
+}
+
+func TestPurgeFileReleasesVersions(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, db, 1000)
+	versionRepo := NewFileVersionRepository(db, "")
+
+	createTestFile(t, db, userID, 50)
+	file := createTestFile(t, db, userID, 100)
+
+	// The completed version becomes the current content; the replaced 100 bytes are archived
+	completed := createTestVersion(t, db, file, 30)
+	if _, err := versionRepo.CompletePendingVersion(ctx, file.ID, completed.ID); err != nil {
+		t.Fatalf("CompletePendingVersion() error = %v", err)
+	}
+	createTestVersion(t, db, file, 70)
+	failed := createTestVersion(t, db, file, 20)
+	if err := versionRepo.FailPendingVersion(ctx, failed.ID); err != nil {
+		t.Fatalf("FailPendingVersion() error = %v", err)
+	}
+	assertStorage(t, db, userID, 250, 0)
+
+	if err := NewDeleteCloudRepositoryRepository(db, "").SoftDeleteFile(ctx, file.ID, userID); err != nil {
+		t.Fatalf("SoftDeleteFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 250, 30)
+
+	if err := NewTrashCloudRepositoryRepository(db, "").PurgeFile(ctx, file); err != nil {
+		t.Fatalf("PurgeFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 50, 0)
+
+	var remaining int64
+	db.Model(&entity.FileVersion{}).Where("file_id = ?", file.ID).Count(&remaining)
+	if remaining != 0 {
+		t.Errorf("remaining versions = %d, want 0", remaining)
+	}
+}
+
+func TestDeleteArchivedVersionReleasesStorage(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, db, 1000)
+	versionRepo := NewFileVersionRepository(db, "")
+
+	file := createTestFile(t, db, userID, 100)
+	version := createTestVersion(t, db, file, 40)
+	current, err := versionRepo.CompletePendingVersion(ctx, file.ID, version.ID)
+	if err != nil {
+		t.Fatalf("CompletePendingVersion() error = %v", err)
+	}
+	assertStorage(t, db, userID, 140, 0)
+
+	if _, err := versionRepo.DeleteArchivedVersion(ctx, file.ID, current.Version-1); err != nil {
+		t.Fatalf("DeleteArchivedVersion() error = %v", err)
+	}
+	assertStorage(t, db, userID, 40, 0)
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+// keysetKind is the type of the value a keyset order sorts by
+type keysetKind int
+
+const (
+	keysetTime keysetKind = iota
+	keysetString
+	keysetInt
+)
+
+// keysetOrder is a sort order that supports cursor pagination.
+// Rows are ordered by Column and then by ID in the same direction, so every row has a unique position.
+type keysetOrder struct {
+	Name   string // Stored in the cursor so it is never used with another order
+	Column string
+	Kind   keysetKind
+	Desc   bool
+}
+
+// keysetCursor is the position of the last row of a page: its sort value and ID
+type keysetCursor struct {
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// encodeCursor returns the opaque cursor of the row with the given sort value and ID
+func encodeCursor(order keysetOrder, value interface{}, id uint) string {
+	cursor := keysetCursor{Order: order.Name, ID: id}
+	switch v := value.(type) {
+	case time.Time:
+		cursor.Value = v.Format(time.RFC3339Nano)
+	case int64:
+		cursor.Value = strconv.FormatInt(v, 10)
+	default:
+		cursor.Value = fmt.Sprint(v)
+	}
+
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor parses a cursor created by encodeCursor for the same order.
+// Returns the sort value converted to the type of the column.
+func decodeCursor(order keysetOrder, raw string) (interface{}, uint, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, 0, sharedErrors.BadRequest("invalid cursor")
+	}
+	var cursor keysetCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, 0, sharedErrors.BadRequest("invalid cursor")
+	}
+	if cursor.Order != order.Name {
+		return nil, 0, sharedErrors.BadRequest("invalid cursor: it was created for another sort order")
+	}
+
+	switch order.Kind {
+	case keysetTime:
+		value, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, 0, sharedErrors.BadRequest("invalid cursor")
+		}
+		return value, cursor.ID, nil
+	case keysetInt:
+		value, err := strconv.ParseInt(cursor.Value, 10, 64)
+		if err != nil {
+			return nil, 0, sharedErrors.BadRequest("invalid cursor")
+		}
+		return value, cursor.ID, nil
+	default:
+		return cursor.Value, cursor.ID, nil
+	}
+}
+
+// apply orders the query and, when a cursor is given, keeps only the rows after it
+func (o keysetOrder) apply(query *gorm.DB, idColumn, cursor string) (*gorm.DB, error) {
+	direction, op := "ASC", ">"
+	if o.Desc {
+		direction, op = "DESC", "<"
+	}
+
+	if cursor != "" {
+		value, id, err := decodeCursor(o, cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", o.Column, op, o.Column, idColumn, op), value, value, id)
+	}
+	return query.Order(o.Column + " " + direction + ", " + idColumn + " " + direction), nil
+}
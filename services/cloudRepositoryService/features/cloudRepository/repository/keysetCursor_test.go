@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeysetCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC)
+	tests := []struct {
+		name  string
+		order keysetOrder
+		value interface{}
+	}{
+		{name: "time", order: keysetOrder{Name: "latest", Column: "created_at", Kind: keysetTime, Desc: true}, value: createdAt},
+		{name: "string", order: keysetOrder{Name: "name", Column: "file_name", Kind: keysetString}, value: "제주 여행.jpg"},
+		{name: "int", order: keysetOrder{Name: "size", Column: "file_size", Kind: keysetInt, Desc: true}, value: int64(1 << 40)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, id, err := decodeCursor(tt.order, encodeCursor(tt.order, tt.value, 42))
+			if err != nil {
+				t.Fatalf("decodeCursor() error = %v", err)
+			}
+			if id != 42 {
+				t.Errorf("id = %d, want 42", id)
+			}
+			if got, ok := value.(time.Time); ok {
+				if !got.Equal(createdAt) {
+					t.Errorf("value = %v, want %v", got, createdAt)
+				}
+			} else if value != tt.value {
+				t.Errorf("value = %v, want %v", value, tt.value)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsInvalid(t *testing.T) {
+	latest := keysetOrder{Name: "latest", Column: "created_at", Kind: keysetTime, Desc: true}
+	name := keysetOrder{Name: "name", Column: "file_name", Kind: keysetString}
+
+	for _, raw := range []string{"not base64!", "bm90IGpzb24", encodeCursor(name, "a.jpg", 1)} {
+		if _, _, err := decodeCursor(latest, raw); err == nil {
+			t.Errorf("decodeCursor(%q) error = nil, want an error", raw)
+		}
+	}
+}
@@ -8,9 +8,21 @@ import (
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// resolutionShortSides maps resolution labels to the minimum short side in pixels,
+// so portrait videos match the same label as landscape ones
+var resolutionShortSides = map[string]int{
+	"480p":  480,
+	"720p":  720,
+	"1080p": 1080,
+	"1440p": 1440,
+	"2160p": 2160,
+}
+
 type ListCloudRepositoryRepository struct {
 	db     *gorm.DB
 	bucket string
@@ -23,23 +35,137 @@ func NewListCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.ILi
 	}
 }
 
-// GetFilesByUserID retrieves all files for a user with filtering and pagination
-func (r *ListCloudRepositoryRepository) GetFilesByUserID(ctx context.Context, userID uint, filter request.ListFilesRequestDTO) ([]entity.CloudFile, int64, error) {
+// fileKeysetOrders are the file sort orders that support cursor pagination
+var fileKeysetOrders = map[string]keysetOrder{
+	"latest": {Name: "latest", Column: "created_at", Kind: keysetTime, Desc: true},
+	"oldest": {Name: "oldest", Column: "created_at", Kind: keysetTime},
+	"name":   {Name: "name", Column: "file_name", Kind: keysetString},
+	"size":   {Name: "size", Column: "file_size", Kind: keysetInt, Desc: true},
+}
+
+// GetFilesByUserID retrieves a page of a user's files with filtering, by offset or after a cursor.
+// Returns the cursor of the next page when there is one and the order supports cursors; the total is 0 with SkipTotal.
+func (r *ListCloudRepositoryRepository) GetFilesByUserID(ctx context.Context, userID uint, filter request.ListFilesRequestDTO) ([]entity.CloudFile, int64, string, error) {
 	var files []entity.CloudFile
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
 		Preload("Tags"). // Eager load tags
-		Where("user_id = ? AND deleted_at IS NULL", userID)
+		Preload("Exif").
+		Preload("Palette", orderPalette).
+		Where("user_id = ? AND deleted_at IS NULL AND upload_status = ?", userID, entity.UploadStatusCommitted)
+
+	query = applyFileFilters(query, filter)
+
+	// Get total count
+	if !filter.SkipTotal {
+		if err := query.Count(&total).Error; err != nil {
+			return nil, 0, "", err
+		}
+	}
+
+	// Apply sorting
+	order, keyset := fileKeysetOrder(filter)
+	if keyset {
+		var err error
+		if query, err = order.apply(query, "id", filter.Cursor); err != nil {
+			return nil, 0, "", err
+		}
+	} else if filter.Cursor != "" {
+		return nil, 0, "", sharedErrors.BadRequest("invalid cursor: this sort order does not support cursor pagination")
+	} else {
+		query = orderFiles(query, filter)
+	}
+
+	// Apply pagination; a cursor replaces the page number
+	page := filter.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := filter.PageSize
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if filter.Cursor == "" {
+		query = query.Offset((page - 1) * pageSize)
+	}
+
+	// One extra row tells whether there is a next page
+	if err := query.Limit(pageSize + 1).Find(&files).Error; err != nil {
+		return nil, 0, "", err
+	}
+
+	nextCursor := ""
+	if len(files) > pageSize {
+		files = files[:pageSize]
+		if keyset {
+			last := files[pageSize-1]
+			nextCursor = encodeCursor(order, fileSortValue(order, &last), last.ID)
+		}
+	}
+
+	return files, total, nextCursor, nil
+}
+
+// fileKeysetOrder returns the keyset order of a file listing.
+// Without a sort, listings by keyword or album use relevance or the album order, which have no keyset.
+func fileKeysetOrder(filter request.ListFilesRequestDTO) (keysetOrder, bool) {
+	sort := filter.Sort
+	if sort == "" {
+		if fullTextQuery(filter.Keyword) != "" || filter.AlbumID != 0 {
+			return keysetOrder{}, false
+		}
+		sort = "latest"
+	}
+	order, ok := fileKeysetOrders[sort]
+	return order, ok
+}
 
-	// Apply filters
+// orderFiles applies the sort orders of a file listing that have no keyset
+func orderFiles(query *gorm.DB, filter request.ListFilesRequestDTO) *gorm.DB {
+	switch filter.Sort {
+	case "duration":
+		return query.Order("duration DESC, id DESC")
+	case "resolution":
+		return query.Order("width * height DESC, id DESC")
+	case "taken":
+		// Files without a capture time fall back to their upload time
+		return query.Order("COALESCE((SELECT taken_at FROM file_exif WHERE file_exif.file_id = cloud_files.id), cloud_files.created_at) DESC, id DESC")
+	case "relevance":
+		return orderByRelevance(query, filter.Keyword)
+	default: // "" with a keyword or an album
+		if filter.AlbumID != 0 {
+			// Manual album order; Order only accepts an expression wrapped in clause.OrderBy
+			return query.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "(SELECT position FROM album_files WHERE album_files.album_id = ? AND album_files.file_id = cloud_files.id) ASC, id ASC",
+				Vars: []interface{}{filter.AlbumID},
+			}})
+		}
+		return orderByRelevance(query, filter.Keyword)
+	}
+}
+
+// fileSortValue returns the value a file is sorted by in a keyset order
+func fileSortValue(order keysetOrder, file *entity.CloudFile) interface{} {
+	switch order.Column {
+	case "file_name", "cloud_files.file_name":
+		return file.FileName
+	case "file_size", "cloud_files.file_size":
+		return file.FileSize
+	default:
+		return file.CreatedAt
+	}
+}
+
+// applyFileFilters applies the filters of a file listing, everything except sorting and pagination
+func applyFileFilters(query *gorm.DB, filter request.ListFilesRequestDTO) *gorm.DB {
 	if filter.FileType != "" {
 		query = query.Where("file_type = ?", filter.FileType)
 	}
 
-	// Keyword search (filename OR tag name)
-	if filter.Keyword != "" {
-		query = query.Where("file_name LIKE ? OR id IN (SELECT cloud_file_id FROM file_tags JOIN tags ON tags.id = file_tags.tag_id WHERE tags.name LIKE ?)", "%"+filter.Keyword+"%", "%"+filter.Keyword+"%")
+	// Keyword search (file name, description OR tag name)
+	if q := fullTextQuery(filter.Keyword); q != "" {
+		query = query.Where(fileSearchMatch+" OR id IN (SELECT cloud_file_id FROM file_tags JOIN tags ON tags.id = file_tags.tag_id WHERE "+tagSearchMatch+")", q, q)
 	}
 
 	// Tag filtering (files must have ALL specified tags)
@@ -56,40 +182,54 @@ func (r *ListCloudRepositoryRepository) GetFilesByUserID(ctx context.Context, us
 		query = query.Where("created_at <= ?", filter.EndDate+" 23:59:59")
 	}
 
-	// Get total count
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	// Capture date filters (images with EXIF only)
+	if filter.TakenStartDate != "" {
+		query = query.Where("id IN (SELECT file_id FROM file_exif WHERE taken_at >= ?)", filter.TakenStartDate+" 00:00:00")
+	}
+	if filter.TakenEndDate != "" {
+		query = query.Where("id IN (SELECT file_id FROM file_exif WHERE taken_at <= ?)", filter.TakenEndDate+" 23:59:59")
 	}
 
-	// Apply sorting
-	switch filter.Sort {
-	case "oldest":
-		query = query.Order("created_at ASC")
-	case "name":
-		query = query.Order("file_name ASC")
-	case "size":
-		query = query.Order("file_size DESC")
-	default: // "latest" or empty
-		query = query.Order("created_at DESC")
+	// Video metadata filters
+	if filter.MinDuration > 0 {
+		query = query.Where("duration >= ?", filter.MinDuration)
+	}
+	if filter.MaxDuration > 0 {
+		query = query.Where("duration <= ?", filter.MaxDuration)
+	}
+	if shortSide, ok := resolutionShortSides[filter.MinResolution]; ok {
+		query = query.Where("LEAST(width, height) >= ?", shortSide)
 	}
 
-	// Apply pagination
-	page := filter.Page
-	if page < 1 {
-		page = 1
+	// Image quality filter (analyzed images only)
+	if filter.Quality == "low" {
+		query = query.Where("(sharpness_score < ? OR exposure_score < ?)", entity.LowSharpnessScore, entity.LowExposureScore)
 	}
-	pageSize := filter.PageSize
-	if pageSize < 1 {
-		pageSize = 20
+
+	// Color filter: images with a palette color of some weight within the distance in Lab space
+	if filter.Color != "" {
+		if rgb, err := request.ParseHexColor(filter.Color); err == nil {
+			lab := entity.LabFromRGB(rgb.R, rgb.G, rgb.B)
+			distance := filter.ColorDistance
+			if distance == 0 {
+				distance = entity.DefaultColorDistance
+			}
+			query = query.Where("id IN (SELECT file_id FROM file_colors WHERE weight >= ? AND POW(lab_l - ?, 2) + POW(lab_a - ?, 2) + POW(lab_b - ?, 2) <= ?)",
+				entity.MinColorMatchWeight, lab.L, lab.A, lab.B, distance*distance)
+		}
 	}
 
-	offset := (page - 1) * pageSize
-	err := query.Limit(pageSize).Offset(offset).Find(&files).Error
-	if err != nil {
-		return nil, 0, err
+	// Album filter
+	if filter.AlbumID != 0 {
+		query = query.Where("id IN (SELECT file_id FROM album_files WHERE album_id = ?)", filter.AlbumID)
 	}
 
-	return files, total, nil
+	return query
+}
+
+// orderPalette preloads the palette of a file largest share first
+func orderPalette(db *gorm.DB) *gorm.DB {
+	return db.Order("weight DESC")
 }
 
 // GeneratePresignedDownloadURL generates a presigned URL for downloading
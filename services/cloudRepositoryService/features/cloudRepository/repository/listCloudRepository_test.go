@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// dryRunDB returns a MySQL connection that only builds statements, for checking the generated SQL
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{DSN: "root@tcp(localhost:3307)/test_db", SkipInitializeWithVersion: true}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatalf("failed to open dry run database: %v", err)
+	}
+	return db
+}
+
+func TestOrderFiles(t *testing.T) {
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name      string
+		filter    request.ListFilesRequestDTO
+		wantOrder string
+	}{
+		{
+			name:      "duration",
+			filter:    request.ListFilesRequestDTO{Sort: "duration"},
+			wantOrder: "ORDER BY duration DESC, id DESC",
+		},
+		{
+			name:      "relevance",
+			filter:    request.ListFilesRequestDTO{Sort: "relevance", Keyword: "제주"},
+			wantOrder: "ORDER BY MATCH(file_name, description) AGAINST('+제주*' IN BOOLEAN MODE) + COALESCE((SELECT MAX(MATCH(tags.name) AGAINST('+제주*' IN BOOLEAN MODE)) FROM file_tags JOIN tags ON tags.id = file_tags.tag_id WHERE file_tags.cloud_file_id = cloud_files.id), 0) DESC, id DESC",
+		},
+		{
+			name:      "keyword without sort",
+			filter:    request.ListFilesRequestDTO{Keyword: "beach"},
+			wantOrder: "ORDER BY MATCH(file_name, description) AGAINST('+beach*' IN BOOLEAN MODE)",
+		},
+		{
+			name:      "relevance without terms",
+			filter:    request.ListFilesRequestDTO{Sort: "relevance"},
+			wantOrder: "ORDER BY created_at DESC, id DESC",
+		},
+		{
+			name:      "album order",
+			filter:    request.ListFilesRequestDTO{AlbumID: 7},
+			wantOrder: "ORDER BY (SELECT position FROM album_files WHERE album_files.album_id = 7 AND album_files.file_id = cloud_files.id) ASC, id ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+				return orderFiles(tx.Model(&entity.CloudFile{}), tt.filter).Find(&[]entity.CloudFile{})
+			})
+			if !strings.Contains(sql, tt.wantOrder) {
+				t.Errorf("orderFiles() SQL = %s, want %s", sql, tt.wantOrder)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	"gorm.io/gorm"
+)
+
+type MapRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewMapRepository(db *gorm.DB, bucket string) _interface.IMapRepository {
+	return &MapRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetClusters aggregates the user's geotagged files in the area by geohash prefix of the given length,
+// returning up to limit cells with the most files first
+func (r *MapRepository) GetClusters(ctx context.Context, userID uint, area entity.MapArea, precision, limit int) ([]entity.MapCluster, error) {
+	// GROUP BY resolves names against the tables before the select list, so the cell needs its own alias
+	var rows []struct {
+		Cell             string
+		Count            int
+		Latitude         float64
+		Longitude        float64
+		MinLatitude      float64
+		MinLongitude     float64
+		MaxLatitude      float64
+		MaxLongitude     float64
+		RepresentativeID *uint
+	}
+	err := r.areaQuery(ctx, userID, area).
+		Select(`LEFT(file_exif.geohash, ?) AS cell, COUNT(*) AS count,
+			AVG(file_exif.latitude) AS latitude, AVG(file_exif.longitude) AS longitude,
+			MIN(file_exif.latitude) AS min_latitude, MIN(file_exif.longitude) AS min_longitude,
+			MAX(file_exif.latitude) AS max_latitude, MAX(file_exif.longitude) AS max_longitude,
+			MAX(CASE WHEN cloud_files.thumbnail_key <> '' THEN cloud_files.id END) AS representative_id`, precision).
+		Group("cell").
+		Order("count DESC, cell ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var representativeIDs []uint
+	for _, row := range rows {
+		if row.RepresentativeID != nil {
+			representativeIDs = append(representativeIDs, *row.RepresentativeID)
+		}
+	}
+	thumbnailKeys := make(map[uint]string, len(representativeIDs))
+	if len(representativeIDs) > 0 {
+		var files []entity.CloudFile
+		if err := r.db.WithContext(ctx).
+			Select("id", "thumbnail_key").
+			Where("id IN ?", representativeIDs).
+			Find(&files).Error; err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			thumbnailKeys[file.ID] = file.ThumbnailKey
+		}
+	}
+
+	clusters := make([]entity.MapCluster, len(rows))
+	for i, row := range rows {
+		clusters[i] = entity.MapCluster{
+			Geohash:          row.Cell,
+			Count:            row.Count,
+			Latitude:         row.Latitude,
+			Longitude:        row.Longitude,
+			MinLatitude:      row.MinLatitude,
+			MinLongitude:     row.MinLongitude,
+			MaxLatitude:      row.MaxLatitude,
+			MaxLongitude:     row.MaxLongitude,
+			RepresentativeID: row.RepresentativeID,
+		}
+		if row.RepresentativeID != nil {
+			clusters[i].ThumbnailKey = thumbnailKeys[*row.RepresentativeID]
+		}
+	}
+	return clusters, nil
+}
+
+// GetFiles retrieves up to limit of the user's geotagged files in the area with their EXIF metadata,
+// most recently taken first
+func (r *MapRepository) GetFiles(ctx context.Context, userID uint, area entity.MapArea, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.areaQuery(ctx, userID, area).
+		Select("cloud_files.*").
+		Preload("Exif").
+		Order("file_exif.taken_at DESC, cloud_files.id DESC").
+		Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// areaQuery selects the user's committed files outside the trash whose EXIF location lies in the area
+func (r *MapRepository) areaQuery(ctx context.Context, userID uint, area entity.MapArea) *gorm.DB {
+	query := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Joins("JOIN file_exif ON file_exif.file_id = cloud_files.id").
+		Where("cloud_files.user_id = ? AND cloud_files.deleted_at IS NULL AND cloud_files.upload_status = ? AND file_exif.geohash <> ''",
+			userID, entity.UploadStatusCommitted)
+
+	if area.Geohash != "" {
+		return query.Where("file_exif.geohash LIKE ?", area.Geohash+"%")
+	}
+
+	query = query.Where("file_exif.latitude BETWEEN ? AND ?", area.MinLatitude, area.MaxLatitude)
+	if area.MinLongitude <= area.MaxLongitude {
+		return query.Where("file_exif.longitude BETWEEN ? AND ?", area.MinLongitude, area.MaxLongitude)
+	}
+	// The box crosses the antimeridian
+	return query.Where("(file_exif.longitude >= ? OR file_exif.longitude <= ?)", area.MinLongitude, area.MaxLongitude)
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *MapRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
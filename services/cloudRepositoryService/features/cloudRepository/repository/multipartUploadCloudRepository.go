@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MultipartUploadCloudRepositoryRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewMultipartUploadCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.IMultipartUploadCloudRepositoryRepository {
+	return &MultipartUploadCloudRepositoryRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// CreateMultipartUpload starts an S3 multipart upload
+func (r *MultipartUploadCloudRepositoryRepository) CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error) {
+	return sharedAws.CreateMultipartUpload(ctx, r.bucket, s3Key, contentType)
+}
+
+// GeneratePresignedUploadPartURL generates a presigned URL for uploading a single part
+func (r *MultipartUploadCloudRepositoryRepository) GeneratePresignedUploadPartURL(ctx context.Context, s3Key, s3UploadID string, partNumber int32, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedUploadPartURL(ctx, r.bucket, s3Key, s3UploadID, partNumber, expiration)
+}
+
+// ListUploadedParts lists the parts S3 has received so far
+func (r *MultipartUploadCloudRepositoryRepository) ListUploadedParts(ctx context.Context, s3Key, s3UploadID string) ([]sharedAws.UploadedPart, error) {
+	return sharedAws.ListUploadedParts(ctx, r.bucket, s3Key, s3UploadID)
+}
+
+// CompleteMultipartUpload assembles the parts into the final S3 object
+func (r *MultipartUploadCloudRepositoryRepository) CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error {
+	return sharedAws.CompleteMultipartUpload(ctx, r.bucket, s3Key, s3UploadID, parts)
+}
+
+// AbortMultipartUpload aborts an S3 multipart upload
+func (r *MultipartUploadCloudRepositoryRepository) AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error {
+	return sharedAws.AbortMultipartUpload(ctx, r.bucket, s3Key, s3UploadID)
+}
+
+// CreateUpload saves a multipart upload session
+func (r *MultipartUploadCloudRepositoryRepository) CreateUpload(ctx context.Context, upload *entity.MultipartUpload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+// GetUploadByID retrieves a multipart upload session with its recorded parts
+func (r *MultipartUploadCloudRepositoryRepository) GetUploadByID(ctx context.Context, id uint) (*entity.MultipartUpload, error) {
+	var upload entity.MultipartUpload
+	err := r.db.WithContext(ctx).
+		Preload("Parts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("part_number ASC")
+		}).
+		Where("id = ?", id).
+		First(&upload).Error
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// SaveParts upserts uploaded parts (a re-uploaded part replaces the previous ETag and size)
+func (r *MultipartUploadCloudRepositoryRepository) SaveParts(ctx context.Context, parts []entity.MultipartUploadPart) error {
+	if len(parts) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "multipart_upload_id"}, {Name: "part_number"}},
+			DoUpdates: clause.AssignmentColumns([]string{"etag", "size", "updated_at"}),
+		}).
+		Create(&parts).Error
+}
+
+// UpdateUploadStatus moves a multipart upload from one status to another
+func (r *MultipartUploadCloudRepositoryRepository) UpdateUploadStatus(ctx context.Context, id uint, from, to entity.MultipartStatus) error {
+	result := r.db.WithContext(ctx).Model(&entity.MultipartUpload{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.Conflict(fmt.Sprintf("upload conflict: multipart upload is not %s", from))
+	}
+	return nil
+}
+
+// GetExpiredUploads retrieves in-progress multipart uploads past their expiry time
+func (r *MultipartUploadCloudRepositoryRepository) GetExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.MultipartUpload, error) {
+	var uploads []entity.MultipartUpload
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", entity.MultipartStatusInProgress, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+
+	return uploads, err
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	"gorm.io/gorm"
+)
+
+type ReconcileCloudRepositoryRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewReconcileCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.IReconcileCloudRepositoryRepository {
+	return &ReconcileCloudRepositoryRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// ListObjects lists one page of the objects under prefix in S3
+func (r *ReconcileCloudRepositoryRepository) ListObjects(ctx context.Context, prefix, delimiter, continuationToken string) (*sharedAws.ObjectPage, error) {
+	return sharedAws.ListObjects(ctx, r.bucket, prefix, delimiter, continuationToken)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *ReconcileCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
+
+// GetUserIDsWithFiles retrieves the IDs of all users that have at least one file record
+func (r *ReconcileCloudRepositoryRepository) GetUserIDsWithFiles(ctx context.Context) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.WithContext(ctx).
+		Model(&entity.CloudFile{}).
+		Distinct("user_id").
+		Order("user_id ASC").
+		Pluck("user_id", &userIDs).Error
+
+	return userIDs, err
+}
+
+// GetUserFiles retrieves every file record of a user, including failed and deleted ones, with their thumbnails and versions
+func (r *ReconcileCloudRepositoryRepository) GetUserFiles(ctx context.Context, userID uint) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Preload("Thumbnails").
+		Preload("Versions").
+		Where("user_id = ?", userID).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GetInProgressTusPartKeys retrieves the S3 keys of incomplete tus parts the user is still uploading
+func (r *ReconcileCloudRepositoryRepository) GetInProgressTusPartKeys(ctx context.Context, userID uint) ([]string, error) {
+	var uploads []entity.TusUpload
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND incomplete_part_size > 0", userID, entity.MultipartStatusInProgress).
+		Find(&uploads).Error
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(uploads))
+	for i := range uploads {
+		keys[i] = uploads[i].IncompletePartKey()
+	}
+	return keys, nil
+}
+
+// FailCommittedFile marks a committed file whose object is missing or corrupt as failed and releases its storage
+func (r *ReconcileCloudRepositoryRepository) FailCommittedFile(ctx context.Context, fileID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.CloudFile{}).
+			Where("id = ? AND upload_status = ? AND deleted_at IS NULL", fileID, entity.UploadStatusCommitted).
+			Update("upload_status", entity.UploadStatusFailed)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("file is not %s", entity.UploadStatusCommitted)
+		}
+		return releaseFileStorage(tx, fileID)
+	})
+}
+
+// ResetThumbnails drops the thumbnail records of a file and marks its thumbnails pending so the backfill job regenerates them
+func (r *ReconcileCloudRepositoryRepository) ResetThumbnails(ctx context.Context, fileID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("file_id = ?", fileID).Delete(&entity.FileThumbnail{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entity.CloudFile{}).
+			Where("id = ?", fileID).
+			Updates(map[string]interface{}{
+				"thumbnail_key":    "",
+				"thumbnail_status": entity.ThumbnailStatusPending,
+			}).Error
+	})
+}
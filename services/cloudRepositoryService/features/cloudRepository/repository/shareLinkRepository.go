@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+type ShareLinkRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewShareLinkRepository(db *gorm.DB, bucket string) _interface.IShareLinkRepository {
+	return &ShareLinkRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// CreateShareLink creates a share link for the given files
+func (r *ShareLinkRepository) CreateShareLink(ctx context.Context, link *entity.ShareLink, fileIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Files").Create(link).Error; err != nil {
+			return err
+		}
+
+		link.Files = make([]entity.ShareLinkFile, len(fileIDs))
+		for i, fileID := range fileIDs {
+			link.Files[i] = entity.ShareLinkFile{ShareLinkID: link.ID, FileID: fileID}
+		}
+		return tx.Create(&link.Files).Error
+	})
+}
+
+// GetShareLink retrieves a share link of the user
+func (r *ShareLinkRepository) GetShareLink(ctx context.Context, userID, linkID uint) (*entity.ShareLink, error) {
+	var link entity.ShareLink
+	err := r.db.WithContext(ctx).
+		Preload("Files").
+		Where("id = ? AND user_id = ?", linkID, userID).
+		First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetShareLinkByTokenHash retrieves a share link by the SHA-256 of its token
+func (r *ShareLinkRepository) GetShareLinkByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLink, error) {
+	var link entity.ShareLink
+	err := r.db.WithContext(ctx).
+		Preload("Files").
+		Where("token_hash = ?", tokenHash).
+		First(&link).Error
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetUserShareLinks retrieves a page of the user's share links, newest first
+func (r *ShareLinkRepository) GetUserShareLinks(ctx context.Context, userID uint, offset, limit int) ([]entity.ShareLink, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.ShareLink{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var links []entity.ShareLink
+	err := query.
+		Preload("Files").
+		Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&links).Error
+
+	return links, total, err
+}
+
+// RevokeShareLink revokes a share link of the user. Revoking a revoked link is a no-op.
+func (r *ShareLinkRepository) RevokeShareLink(ctx context.Context, userID, linkID uint) error {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&entity.ShareLink{}).
+		Where("id = ? AND user_id = ?", linkID, userID).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return sharedErrors.NotFound("share link not found")
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&entity.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", linkID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// ConsumeDownload counts one download of a share link.
+// The update is conditional so concurrent requests cannot exceed max_downloads or use an expired or revoked link.
+// Returns false if the link can no longer be used.
+func (r *ShareLinkRepository) ConsumeDownload(ctx context.Context, linkID uint, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&entity.ShareLink{}).
+		Where("id = ? AND revoked_at IS NULL", linkID).
+		Where("expires_at IS NULL OR expires_at > ?", now).
+		Where("max_downloads IS NULL OR download_count < max_downloads").
+		Update("download_count", gorm.Expr("download_count + 1"))
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// LogAccess records an access to a share link
+func (r *ShareLinkRepository) LogAccess(ctx context.Context, access *entity.ShareLinkAccess) error {
+	return r.db.WithContext(ctx).Create(access).Error
+}
+
+// GetAccesses retrieves a page of the access log of a share link, most recent first
+func (r *ShareLinkRepository) GetAccesses(ctx context.Context, linkID uint, offset, limit int) ([]entity.ShareLinkAccess, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.ShareLinkAccess{}).Where("share_link_id = ?", linkID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var accesses []entity.ShareLinkAccess
+	err := query.
+		Order("accessed_at DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&accesses).Error
+
+	return accesses, total, err
+}
+
+// GetLiveFiles retrieves the user's committed files outside the trash among fileIDs
+func (r *ShareLinkRepository) GetLiveFiles(ctx context.Context, userID uint, fileIDs []uint) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id IN ? AND user_id = ? AND deleted_at IS NULL AND upload_status = ?", fileIDs, userID, entity.UploadStatusCommitted).
+		Order("id ASC").
+		Find(&files).Error
+
+	return files, err
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *ShareLinkRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
+
+// GeneratePresignedDownloadURLWithFilename generates a presigned URL for downloading with Content-Disposition header
+func (r *ShareLinkRepository) GeneratePresignedDownloadURLWithFilename(ctx context.Context, s3Key, filename string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURLWithFilename(ctx, r.bucket, s3Key, filename, expiration)
+}
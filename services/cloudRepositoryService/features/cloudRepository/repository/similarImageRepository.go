@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	"gorm.io/gorm"
+)
+
+type SimilarImageRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewSimilarImageRepository(db *gorm.DB, bucket string) _interface.ISimilarImageRepository {
+	return &SimilarImageRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetFileByID retrieves a committed file outside the trash
+func (r *SimilarImageRepository) GetFileByID(ctx context.Context, fileID uint) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("id = ? AND deleted_at IS NULL AND upload_status = ?", fileID, entity.UploadStatusCommitted).
+		First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// GetSimilarImages retrieves up to limit of the user's analyzed images within maxDistance bits of hash, closest first.
+// The distance is computed by MySQL with BIT_COUNT over the XOR of the hashes.
+func (r *SimilarImageRepository) GetSimilarImages(ctx context.Context, userID, excludeFileID uint, hash uint64, maxDistance, limit int) ([]entity.SimilarImage, error) {
+	var matches []struct {
+		ID       uint
+		Distance int
+	}
+	err := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Select("id, BIT_COUNT(perceptual_hash ^ ?) AS distance", hash).
+		Where("user_id = ? AND id <> ? AND perceptual_hash IS NOT NULL AND deleted_at IS NULL AND upload_status = ?",
+			userID, excludeFileID, entity.UploadStatusCommitted).
+		Where("BIT_COUNT(perceptual_hash ^ ?) <= ?", hash, maxDistance).
+		Order("distance ASC, id ASC").
+		Limit(limit).
+		Scan(&matches).Error
+	if err != nil || len(matches) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uint, len(matches))
+	for i, match := range matches {
+		ids[i] = match.ID
+	}
+	var files []entity.CloudFile
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	filesByID := make(map[uint]entity.CloudFile, len(files))
+	for _, file := range files {
+		filesByID[file.ID] = file
+	}
+
+	similar := make([]entity.SimilarImage, 0, len(matches))
+	for _, match := range matches {
+		if file, ok := filesByID[match.ID]; ok {
+			similar = append(similar, entity.SimilarImage{File: file, Distance: match.Distance})
+		}
+	}
+	return similar, nil
+}
+
+// GetAnalyzedImages retrieves up to limit of the user's images that have a perceptual hash, most recent first.
+// Only the columns needed to compare and list them are loaded.
+func (r *SimilarImageRepository) GetAnalyzedImages(ctx context.Context, userID uint, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Select("id", "user_id", "file_name", "content_type", "file_size", "thumbnail_key", "perceptual_hash", "created_at").
+		Where("user_id = ? AND perceptual_hash IS NOT NULL AND deleted_at IS NULL AND upload_status = ?", userID, entity.UploadStatusCommitted).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *SimilarImageRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/shared/db/mysql"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+// A file holds its size in users.storage_used from the moment its upload is requested until it fails or is purged.
+// While it is in the trash its size is also counted in users.trash_used.
+// File versions hold their size in storage_used the same way, from the upload request until they fail, are pruned
+// or their file is purged; trash_used only counts the current content.
+// The helpers below must run in the same transaction as the status change they account for.
+
+// reserveStorage atomically adds size bytes to the user's storage_used unless it would exceed storage_limit
+func reserveStorage(tx *gorm.DB, userID uint, size int64) error {
+	result := tx.Model(&mysql.User{}).
+		Where("id = ? AND storage_used + ? <= storage_limit", userID, size).
+		Update("storage_used", gorm.Expr("storage_used + ?", size))
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to reserve storage: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.QuotaExceeded(fmt.Sprintf("storage quota exceeded: %d bytes requested", size))
+	}
+	return nil
+}
+
+// releaseFileStorage returns a file's size to its owner's quota.
+// Callers must make sure the file held a reservation, i.e. it was pending or committed.
+func releaseFileStorage(tx *gorm.DB, fileID uint) error {
+	return updateFileStorage(tx, fileID, "users.storage_used = GREATEST(users.storage_used - cloud_files.file_size, 0)")
+}
+
+// trashFileStorage counts a file moved to the trash in its owner's trash_used
+func trashFileStorage(tx *gorm.DB, fileID uint) error {
+	return updateFileStorage(tx, fileID, "users.trash_used = users.trash_used + cloud_files.file_size")
+}
+
+// restoreFileStorage removes a file restored from the trash from its owner's trash_used
+func restoreFileStorage(tx *gorm.DB, fileID uint) error {
+	return updateFileStorage(tx, fileID, "users.trash_used = GREATEST(users.trash_used - cloud_files.file_size, 0)")
+}
+
+// purgeFileStorage releases a trashed file from both storage_used and trash_used
+func purgeFileStorage(tx *gorm.DB, fileID uint) error {
+	return updateFileStorage(tx, fileID,
+		"users.storage_used = GREATEST(users.storage_used - cloud_files.file_size, 0), "+
+			"users.trash_used = GREATEST(users.trash_used - cloud_files.file_size, 0)")
+}
+
+// releaseVersionStorage returns a pending or archived version's size to its owner's quota
+func releaseVersionStorage(tx *gorm.DB, versionID uint) error {
+	err := tx.Exec("UPDATE users JOIN file_versions ON file_versions.user_id = users.id "+
+		"SET users.storage_used = GREATEST(users.storage_used - file_versions.file_size, 0) WHERE file_versions.id = ?", versionID).Error
+	if err != nil {
+		return fmt.Errorf("failed to update storage usage: %w", err)
+	}
+	return nil
+}
+
+// releaseFileVersionsStorage returns the size of every pending and archived version of a file to its owner's quota
+func releaseFileVersionsStorage(tx *gorm.DB, fileID uint) error {
+	return updateFileStorage(tx, fileID, fmt.Sprintf(
+		"users.storage_used = GREATEST(users.storage_used - (SELECT COALESCE(SUM(file_versions.file_size), 0) FROM file_versions "+
+			"WHERE file_versions.file_id = cloud_files.id AND file_versions.status <> '%s'), 0)", entity.VersionStatusFailed))
+}
+
+func updateFileStorage(tx *gorm.DB, fileID uint, assignments string) error {
+	err := tx.Exec("UPDATE users JOIN cloud_files ON cloud_files.user_id = users.id SET "+assignments+" WHERE cloud_files.id = ?", fileID).Error
+	if err != nil {
+		return fmt.Errorf("failed to update storage usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedMysql "github.com/JokerTrickster/joker_backend/shared/db/mysql"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+// setupTestDB connects to the local test database and migrates the file tables like the service does on startup.
+// The users table comes from the migrations. The tests are skipped when the database is not running.
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := "root:rootpassword@tcp(localhost:3307)/test_db?charset=utf8mb4&parseTime=True&loc=Local"
+	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	if err != nil {
+		t.Skipf("test database not available: %v", err)
+	}
+	if err := db.AutoMigrate(&entity.CloudFile{}, &entity.Tag{}, &entity.FileThumbnail{}, &entity.FileExif{}, &entity.FileVersion{}, &entity.FileColor{}); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+	return db
+}
+
+// createTestUser creates a user with the given storage limit; its files and versions are removed after the test
+func createTestUser(t *testing.T, db *gorm.DB, storageLimit int64) uint {
+	t.Helper()
+	email := fmt.Sprintf("storage-%d@example.com", time.Now().UnixNano())
+	if err := db.Exec("INSERT INTO users (name, email, storage_limit) VALUES (?, ?, ?)", "Storage Test", email, storageLimit).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	var user sharedMysql.User
+	if err := db.Raw("SELECT id FROM users WHERE email = ?", email).Scan(&user).Error; err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM file_tags WHERE cloud_file_id IN (SELECT id FROM cloud_files WHERE user_id = ?)", user.ID)
+		db.Exec("DELETE FROM tags WHERE user_id = ?", user.ID)
+		db.Exec("DELETE FROM file_versions WHERE user_id = ?", user.ID)
+		db.Exec("DELETE FROM cloud_files WHERE user_id = ?", user.ID)
+		db.Exec("DELETE FROM users WHERE id = ?", user.ID)
+	})
+	return user.ID
+}
+
+// createTestFile saves a committed file of the given size through the upload repository, reserving its storage
+func createTestFile(t *testing.T, db *gorm.DB, userID uint, size int64) *entity.CloudFile {
+	t.Helper()
+	file := &entity.CloudFile{
+		UserID:       userID,
+		FileName:     "photo.jpg",
+		S3Key:        fmt.Sprintf("users/%d/test/%d.jpg", userID, time.Now().UnixNano()),
+		FileType:     entity.FileTypeImage,
+		ContentType:  "image/jpeg",
+		FileSize:     size,
+		UploadStatus: entity.UploadStatusCommitted,
+	}
+	if err := NewUploadCloudRepositoryRepository(db, "").CreateFile(context.Background(), file); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	return file
+}
+
+// assertStorage checks the user's storage_used and trash_used
+func assertStorage(t *testing.T, db *gorm.DB, userID uint, wantUsed, wantTrash int64) {
+	t.Helper()
+	var user sharedMysql.User
+	if err := db.First(&user, userID).Error; err != nil {
+		t.Fatalf("failed to get user: %v", err)
+	}
+	if user.StorageUsed != wantUsed || user.TrashUsed != wantTrash {
+		t.Errorf("storage_used = %d, trash_used = %d, want %d and %d", user.StorageUsed, user.TrashUsed, wantUsed, wantTrash)
+	}
+}
+
+func TestReserveStorage(t *testing.T) {
+	db := setupTestDB(t)
+
+	tests := []struct {
+		name     string
+		size     int64
+		wantErr  bool
+		wantUsed int64
+	}{
+		{name: "within limit", size: 600, wantUsed: 600},
+		{name: "up to limit", size: 1000, wantUsed: 1000},
+		{name: "beyond limit", size: 1001, wantErr: true, wantUsed: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := createTestUser(t, db, 1000)
+
+			err := reserveStorage(db, userID, tt.size)
+			if tt.wantErr {
+				var appErr *sharedErrors.AppError
+				if !errors.As(err, &appErr) || appErr.Code != sharedErrors.ErrCodeQuotaExceeded {
+					t.Fatalf("reserveStorage() error = %v, want quota exceeded", err)
+				}
+			} else if err != nil {
+				t.Fatalf("reserveStorage() error = %v", err)
+			}
+			assertStorage(t, db, userID, tt.wantUsed, 0)
+		})
+	}
+}
+
+func TestCreateFileBeyondQuota(t *testing.T) {
+	db := setupTestDB(t)
+	userID := createTestUser(t, db, 1000)
+	createTestFile(t, db, userID, 700)
+
+	file := &entity.CloudFile{
+		UserID:       userID,
+		FileName:     "video.mp4",
+		S3Key:        fmt.Sprintf("users/%d/test/%d.mp4", userID, time.Now().UnixNano()),
+		FileType:     entity.FileTypeVideo,
+		ContentType:  "video/mp4",
+		FileSize:     301,
+		UploadStatus: entity.UploadStatusPending,
+		Tags:         []entity.Tag{{UserID: userID, Name: "여행"}},
+	}
+	err := NewUploadCloudRepositoryRepository(db, "").CreateFile(context.Background(), file)
+
+	var appErr *sharedErrors.AppError
+	if !errors.As(err, &appErr) || appErr.Code != sharedErrors.ErrCodeQuotaExceeded {
+		t.Fatalf("CreateFile() error = %v, want quota exceeded", err)
+	}
+	var count int64
+	db.Model(&entity.CloudFile{}).Where("user_id = ?", userID).Count(&count)
+	if count != 1 {
+		t.Errorf("file count = %d, want 1", count)
+	}
+	var tagCount int64
+	db.Model(&entity.Tag{}).Where("user_id = ?", userID).Count(&tagCount)
+	if tagCount != 0 {
+		t.Errorf("tag count = %d, want 0", tagCount)
+	}
+	assertStorage(t, db, userID, 700, 0)
+}
+
+func TestCreateFileWithTags(t *testing.T) {
+	db := setupTestDB(t)
+	userID := createTestUser(t, db, 1000)
+	repo := NewUploadCloudRepositoryRepository(db, "")
+
+	first := &entity.CloudFile{
+		UserID:       userID,
+		FileName:     "beach.jpg",
+		S3Key:        fmt.Sprintf("users/%d/test/%d.jpg", userID, time.Now().UnixNano()),
+		FileType:     entity.FileTypeImage,
+		ContentType:  "image/jpeg",
+		FileSize:     100,
+		UploadStatus: entity.UploadStatusPending,
+		Tags:         []entity.Tag{{UserID: userID, Name: "beach"}},
+	}
+	if err := repo.CreateFile(context.Background(), first); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	second := *first
+	second.ID = 0
+	second.S3Key += ".copy"
+	second.Tags = []entity.Tag{{UserID: userID, Name: "beach"}}
+	if err := repo.CreateFile(context.Background(), &second); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+
+	if first.Tags[0].ID == 0 || second.Tags[0].ID != first.Tags[0].ID {
+		t.Errorf("tag IDs = %d and %d, want the same existing tag", first.Tags[0].ID, second.Tags[0].ID)
+	}
+	var links int64
+	db.Table("file_tags").Where("tag_id = ?", first.Tags[0].ID).Count(&links)
+	if links != 2 {
+		t.Errorf("tagged files = %d, want 2", links)
+	}
+}
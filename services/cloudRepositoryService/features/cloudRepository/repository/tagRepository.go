@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+type TagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) _interface.ITagRepository {
+	return &TagRepository{
+		db: db,
+	}
+}
+
+// liveTaggedFiles joins the committed files outside the trash that carry a tag
+const liveTaggedFiles = "LEFT JOIN file_tags ON file_tags.tag_id = tags.id " +
+	"LEFT JOIN cloud_files ON cloud_files.id = file_tags.cloud_file_id AND cloud_files.deleted_at IS NULL AND cloud_files.upload_status = ?"
+
+// GetUserTags retrieves all tags of a user by name, with the number of live files each one is attached to
+func (r *TagRepository) GetUserTags(ctx context.Context, userID uint) ([]entity.TagFileCount, error) {
+	var tags []entity.TagFileCount
+	err := r.db.WithContext(ctx).
+		Model(&entity.Tag{}).
+		Select("tags.id, tags.name, tags.created_at, COUNT(cloud_files.id) AS file_count").
+		Joins(liveTaggedFiles, entity.UploadStatusCommitted).
+		Where("tags.user_id = ?", userID).
+		Group("tags.id, tags.name, tags.created_at").
+		Order("tags.name ASC, tags.id ASC").
+		Scan(&tags).Error
+
+	return tags, err
+}
+
+// GetTagFileCount counts the live files a tag is attached to
+func (r *TagRepository) GetTagFileCount(ctx context.Context, tagID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.Tag{}).
+		Select("COUNT(cloud_files.id)").
+		Joins(liveTaggedFiles, entity.UploadStatusCommitted).
+		Where("tags.id = ?", tagID).
+		Scan(&count).Error
+
+	return count, err
+}
+
+// GetTag retrieves a tag of the user
+func (r *TagRepository) GetTag(ctx context.Context, userID, tagID uint) (*entity.Tag, error) {
+	var tag entity.Tag
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", tagID, userID).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// GetTagByName retrieves a tag of the user by its name
+func (r *TagRepository) GetTagByName(ctx context.Context, userID uint, name string) (*entity.Tag, error) {
+	var tag entity.Tag
+	err := r.db.WithContext(ctx).Where("user_id = ? AND name = ?", userID, name).First(&tag).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// RenameTag changes the name of a tag
+func (r *TagRepository) RenameTag(ctx context.Context, tagID uint, name string) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.Tag{}).
+		Where("id = ?", tagID).
+		Update("name", name).Error
+}
+
+// MergeTags moves every file of the source tag to the target tag and deletes the source tag
+func (r *TagRepository) MergeTags(ctx context.Context, sourceID, targetID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Files that already carry both tags keep a single row
+		if err := tx.Exec("INSERT IGNORE INTO file_tags (cloud_file_id, tag_id) SELECT cloud_file_id, ? FROM file_tags WHERE tag_id = ?", targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM file_tags WHERE tag_id = ?", sourceID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Tag{}, sourceID).Error
+	})
+}
+
+// DeleteTag removes a tag from every file and deletes it
+func (r *TagRepository) DeleteTag(ctx context.Context, tagID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM file_tags WHERE tag_id = ?", tagID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.Tag{}, tagID).Error
+	})
+}
+
+// FindOrCreateTags returns the user's tags with the given names, creating the missing ones
+func (r *TagRepository) FindOrCreateTags(ctx context.Context, userID uint, names []string) ([]entity.Tag, error) {
+	tags := make([]entity.Tag, 0, len(names))
+	for _, name := range names {
+		tag := entity.Tag{
+			UserID: userID,
+			Name:   name,
+		}
+		if err := r.db.WithContext(ctx).Where("user_id = ? AND name = ?", userID, name).FirstOrCreate(&tag).Error; err != nil {
+			return nil, fmt.Errorf("failed to process tag %s: %w", name, err)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// AddFileTags attaches tags to a file; tags it already carries are skipped
+func (r *TagRepository) AddFileTags(ctx context.Context, fileID uint, tagIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, tagID := range tagIDs {
+			if err := tx.Exec("INSERT IGNORE INTO file_tags (cloud_file_id, tag_id) VALUES (?, ?)", fileID, tagID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveFileTag detaches a tag from a file
+func (r *TagRepository) RemoveFileTag(ctx context.Context, fileID, tagID uint) error {
+	result := r.db.WithContext(ctx).Exec("DELETE FROM file_tags WHERE cloud_file_id = ? AND tag_id = ?", fileID, tagID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.NotFound("tag not found on file")
+	}
+	return nil
+}
+
+// GetFileTags retrieves the tags of a file by name
+func (r *TagRepository) GetFileTags(ctx context.Context, fileID uint) ([]entity.Tag, error) {
+	var tags []entity.Tag
+	err := r.db.WithContext(ctx).
+		Joins("JOIN file_tags ON file_tags.tag_id = tags.id").
+		Where("file_tags.cloud_file_id = ?", fileID).
+		Order("tags.name ASC").
+		Find(&tags).Error
+
+	return tags, err
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TrashCloudRepositoryRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewTrashCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.ITrashCloudRepositoryRepository {
+	return &TrashCloudRepositoryRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// GetTrashedFiles retrieves a page of the user's files in the trash, most recently deleted first.
+// Failed uploads that were deleted are not shown; they hold no content to restore.
+func (r *TrashCloudRepositoryRepository) GetTrashedFiles(ctx context.Context, userID uint, offset, limit int) ([]entity.CloudFile, int64, error) {
+	query := r.db.WithContext(ctx).Model(&entity.CloudFile{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL AND upload_status <> ?", userID, entity.UploadStatusFailed)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var files []entity.CloudFile
+	err := query.
+		Order("deleted_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&files).Error
+
+	return files, total, err
+}
+
+// RestoreFile moves a file out of the trash
+func (r *TrashCloudRepositoryRepository) RestoreFile(ctx context.Context, userID, fileID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.CloudFile{}).
+			Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL AND upload_status <> ?", fileID, userID, entity.UploadStatusFailed).
+			Update("deleted_at", nil)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sharedErrors.NotFound("file not found in trash")
+		}
+		return restoreFileStorage(tx, fileID)
+	})
+}
+
+// GetUserTrashedFiles retrieves up to limit files of the user in the trash, including deleted failed uploads
+func (r *TrashCloudRepositoryRepository) GetUserTrashedFiles(ctx context.Context, userID uint, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Preload("Thumbnails").
+		Preload("Versions").
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// GetExpiredTrashedFiles retrieves files of all users that were moved to the trash before the given time
+func (r *TrashCloudRepositoryRepository) GetExpiredTrashedFiles(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Preload("Thumbnails").
+		Preload("Versions").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Find(&files).Error
+
+	return files, err
+}
+
+// PurgeFile permanently removes a trashed file record and everything attached to it, and releases its storage.
+// The caller deletes the S3 objects afterwards, so a file restored concurrently never loses its content.
+func (r *TrashCloudRepositoryRepository) PurgeFile(ctx context.Context, file *entity.CloudFile) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the row so a concurrent restore cannot interleave with the purge
+		var current entity.CloudFile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "upload_status").
+			Where("id = ? AND deleted_at IS NOT NULL", file.ID).
+			First(&current).Error; err != nil {
+			return sharedErrors.NotFound("file not found in trash")
+		}
+
+		if current.UploadStatus != entity.UploadStatusFailed {
+			if err := purgeFileStorage(tx, file.ID); err != nil {
+				return err
+			}
+		}
+		if err := releaseFileVersionsStorage(tx, file.ID); err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM file_tags WHERE cloud_file_id = ?", file.ID).Error; err != nil {
+			return err
+		}
+		// The versions foreign key created by AutoMigrate does not cascade
+		if err := tx.Where("file_id = ?", file.ID).Delete(&entity.FileVersion{}).Error; err != nil {
+			return err
+		}
+		// Thumbnails, EXIF, palettes, favorites, album memberships, grants and upload sessions are removed by foreign key cascades
+		return tx.Delete(&entity.CloudFile{}, file.ID).Error
+	})
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading
+func (r *TrashCloudRepositoryRepository) GeneratePresignedDownloadURL(ctx context.Context, s3Key string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedDownloadURL(ctx, r.bucket, s3Key, expiration)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *TrashCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestTrashRestorePurgeStorage(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, db, 1000)
+	deleteRepo := NewDeleteCloudRepositoryRepository(db, "")
+	trashRepo := NewTrashCloudRepositoryRepository(db, "")
+
+	kept := createTestFile(t, db, userID, 200)
+	file := createTestFile(t, db, userID, 300)
+	assertStorage(t, db, userID, 500, 0)
+
+	if err := deleteRepo.SoftDeleteFile(ctx, file.ID, userID); err != nil {
+		t.Fatalf("SoftDeleteFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 500, 300)
+
+	if err := trashRepo.RestoreFile(ctx, userID, file.ID); err != nil {
+		t.Fatalf("RestoreFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 500, 0)
+
+	if err := deleteRepo.SoftDeleteFile(ctx, file.ID, userID); err != nil {
+		t.Fatalf("SoftDeleteFile() error = %v", err)
+	}
+	if err := trashRepo.PurgeFile(ctx, file); err != nil {
+		t.Fatalf("PurgeFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 200, 0)
+
+	if err := trashRepo.PurgeFile(ctx, kept); err == nil {
+		t.Error("PurgeFile() of a file outside the trash succeeded")
+	}
+	assertStorage(t, db, userID, 200, 0)
+}
+
+func TestPurgeFailedUploadStorage(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+	userID := createTestUser(t, db, 1000)
+	uploadRepo := NewUploadCloudRepositoryRepository(db, "")
+	deleteRepo := NewDeleteCloudRepositoryRepository(db, "")
+
+	createTestFile(t, db, userID, 200)
+	file := createTestFile(t, db, userID, 300)
+	if err := uploadRepo.UpdateUploadStatus(ctx, file.ID, entity.UploadStatusCommitted, entity.UploadStatusFailed); err != nil {
+		t.Fatalf("UpdateUploadStatus() error = %v", err)
+	}
+	assertStorage(t, db, userID, 200, 0)
+
+	// A failed upload no longer holds storage, so neither trashing nor purging it may release any
+	if err := deleteRepo.SoftDeleteFile(ctx, file.ID, userID); err != nil {
+		t.Fatalf("SoftDeleteFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 200, 0)
+
+	if err := NewTrashCloudRepositoryRepository(db, "").PurgeFile(ctx, file); err != nil {
+		t.Fatalf("PurgeFile() error = %v", err)
+	}
+	assertStorage(t, db, userID, 200, 0)
+}
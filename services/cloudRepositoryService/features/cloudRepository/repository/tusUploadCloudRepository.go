@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+type TusUploadCloudRepositoryRepository struct {
+	db     *gorm.DB
+	bucket string
+}
+
+func NewTusUploadCloudRepositoryRepository(db *gorm.DB, bucket string) _interface.ITusUploadCloudRepositoryRepository {
+	return &TusUploadCloudRepositoryRepository{
+		db:     db,
+		bucket: bucket,
+	}
+}
+
+// CreateMultipartUpload starts the S3 multipart upload backing a tus upload
+func (r *TusUploadCloudRepositoryRepository) CreateMultipartUpload(ctx context.Context, s3Key, contentType string) (string, error) {
+	return sharedAws.CreateMultipartUpload(ctx, r.bucket, s3Key, contentType)
+}
+
+// UploadPart uploads a buffered part to S3
+func (r *TusUploadCloudRepositoryRepository) UploadPart(ctx context.Context, s3Key, s3UploadID string, partNumber int32, body []byte) error {
+	_, err := sharedAws.UploadPart(ctx, r.bucket, s3Key, s3UploadID, partNumber, body)
+	return err
+}
+
+// ListUploadedParts lists the parts S3 has received so far
+func (r *TusUploadCloudRepositoryRepository) ListUploadedParts(ctx context.Context, s3Key, s3UploadID string) ([]sharedAws.UploadedPart, error) {
+	return sharedAws.ListUploadedParts(ctx, r.bucket, s3Key, s3UploadID)
+}
+
+// CompleteMultipartUpload assembles the parts into the final S3 object
+func (r *TusUploadCloudRepositoryRepository) CompleteMultipartUpload(ctx context.Context, s3Key, s3UploadID string, parts []sharedAws.UploadedPart) error {
+	return sharedAws.CompleteMultipartUpload(ctx, r.bucket, s3Key, s3UploadID, parts)
+}
+
+// AbortMultipartUpload aborts an S3 multipart upload
+func (r *TusUploadCloudRepositoryRepository) AbortMultipartUpload(ctx context.Context, s3Key, s3UploadID string) error {
+	return sharedAws.AbortMultipartUpload(ctx, r.bucket, s3Key, s3UploadID)
+}
+
+// PutIncompletePart stores bytes that are too small to be uploaded as a part yet
+func (r *TusUploadCloudRepositoryRepository) PutIncompletePart(ctx context.Context, s3Key string, body []byte) error {
+	return sharedAws.PutObject(ctx, r.bucket, s3Key, "application/octet-stream", body)
+}
+
+// GetIncompletePart loads the bytes stored by PutIncompletePart
+func (r *TusUploadCloudRepositoryRepository) GetIncompletePart(ctx context.Context, s3Key string) ([]byte, error) {
+	return sharedAws.GetObject(ctx, r.bucket, s3Key)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *TusUploadCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
+
+// CreateUpload saves a tus upload session
+func (r *TusUploadCloudRepositoryRepository) CreateUpload(ctx context.Context, upload *entity.TusUpload) error {
+	return r.db.WithContext(ctx).Create(upload).Error
+}
+
+// GetUploadByID retrieves a tus upload session
+func (r *TusUploadCloudRepositoryRepository) GetUploadByID(ctx context.Context, id uint) (*entity.TusUpload, error) {
+	var upload entity.TusUpload
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&upload).Error
+	if err != nil {
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// UpdateProgress saves the new offset and part counters only if the stored offset is still fromOffset,
+// so concurrent PATCH requests for the same upload cannot both advance it
+func (r *TusUploadCloudRepositoryRepository) UpdateProgress(ctx context.Context, id uint, fromOffset int64, upload *entity.TusUpload) error {
+	result := r.db.WithContext(ctx).Model(&entity.TusUpload{}).
+		Where("id = ? AND upload_offset = ? AND status = ?", id, fromOffset, entity.MultipartStatusInProgress).
+		Updates(map[string]interface{}{
+			"upload_offset":        upload.UploadOffset,
+			"part_count":           upload.PartCount,
+			"incomplete_part_size": upload.IncompletePartSize,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.Conflict(fmt.Sprintf("upload conflict: upload offset is no longer %d", fromOffset))
+	}
+	return nil
+}
+
+// UpdateUploadStatus moves a tus upload from one status to another
+func (r *TusUploadCloudRepositoryRepository) UpdateUploadStatus(ctx context.Context, id uint, from, to entity.MultipartStatus) error {
+	result := r.db.WithContext(ctx).Model(&entity.TusUpload{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return sharedErrors.Conflict(fmt.Sprintf("upload conflict: tus upload is not %s", from))
+	}
+	return nil
+}
+
+// GetExpiredUploads retrieves in-progress tus uploads past their expiry time
+func (r *TusUploadCloudRepositoryRepository) GetExpiredUploads(ctx context.Context, now time.Time, limit int) ([]entity.TusUpload, error) {
+	var uploads []entity.TusUpload
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", entity.MultipartStatusInProgress, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&uploads).Error
+
+	return uploads, err
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 	"gorm.io/gorm"
 )
 
@@ -27,12 +29,103 @@ func (r *UploadCloudRepositoryRepository) GeneratePresignedUploadURL(ctx context
 	return sharedAws.GeneratePresignedUploadURL(ctx, r.bucket, s3Key, contentType, expiration)
 }
 
-// CreateFile saves file metadata to database
+// GeneratePresignedUploadURLWithChecksum generates a presigned URL that S3 rejects unless the body matches the checksum
+func (r *UploadCloudRepositoryRepository) GeneratePresignedUploadURLWithChecksum(ctx context.Context, s3Key, contentType, checksumSHA256 string, expiration time.Duration) (string, error) {
+	return sharedAws.GeneratePresignedUploadURLWithChecksum(ctx, r.bucket, s3Key, contentType, checksumSHA256, expiration)
+}
+
+// CreateFile reserves the declared file size against the user's storage quota and saves file metadata to database.
+// The reservation, the file's tags and the record are written in one transaction, so a failed insert never leaks quota
+// and an upload over the quota creates no tags.
 func (r *UploadCloudRepositoryRepository) CreateFile(ctx context.Context, file *entity.CloudFile) error {
-	// Create file record (GORM will handle tag associations since they have IDs)
-	if err := r.db.WithContext(ctx).Create(file).Error; err != nil {
-		return err
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := reserveStorage(tx, file.UserID, file.FileSize); err != nil {
+			return err
+		}
+
+		for i := range file.Tags {
+			tag := &file.Tags[i]
+			if err := tx.Where("user_id = ? AND name = ?", tag.UserID, tag.Name).FirstOrCreate(tag).Error; err != nil {
+				return fmt.Errorf("failed to process tag %s: %w", tag.Name, err)
+			}
+		}
+
+		// Create file record (GORM will handle tag associations since they have IDs)
+		return tx.Create(file).Error
+	})
+}
+
+// GetCommittedFileBySHA256 retrieves the user's oldest committed file with the given content hash
+func (r *UploadCloudRepositoryRepository) GetCommittedFileBySHA256(ctx context.Context, userID uint, sha256 string) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND sha256 = ? AND upload_status = ? AND deleted_at IS NULL", userID, sha256, entity.UploadStatusCommitted).
+		Order("id ASC").
+		First(&file).Error
+	if err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// HeadObject retrieves object metadata from S3
+func (r *UploadCloudRepositoryRepository) HeadObject(ctx context.Context, s3Key string) (*sharedAws.ObjectInfo, error) {
+	return sharedAws.HeadObject(ctx, r.bucket, s3Key)
+}
+
+// GetObjectRange downloads part of an object from S3
+func (r *UploadCloudRepositoryRepository) GetObjectRange(ctx context.Context, s3Key string, offset, length int64) ([]byte, error) {
+	return sharedAws.GetObjectRange(ctx, r.bucket, s3Key, offset, length)
+}
+
+// DeleteFromS3 deletes an object from S3
+func (r *UploadCloudRepositoryRepository) DeleteFromS3(ctx context.Context, s3Key string) error {
+	return sharedAws.DeleteObject(ctx, r.bucket, s3Key)
+}
+
+// GetFileByID retrieves a file by ID regardless of its upload status
+func (r *UploadCloudRepositoryRepository) GetFileByID(ctx context.Context, id uint) (*entity.CloudFile, error) {
+	var file entity.CloudFile
+	err := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&file).Error
+	if err != nil {
+		return nil, err
 	}
+	return &file, nil
+}
+
+// UpdateUploadStatus moves a file from one upload status to another.
+// The update is conditional so concurrent completions cannot both succeed.
+// Moving a file to failed releases its storage reservation in the same transaction.
+func (r *UploadCloudRepositoryRepository) UpdateUploadStatus(ctx context.Context, id uint, from, to entity.UploadStatus) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.CloudFile{}).
+			Where("id = ? AND upload_status = ? AND deleted_at IS NULL", id, from).
+			Update("upload_status", to)
+
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return sharedErrors.Conflict(fmt.Sprintf("upload conflict: file is not %s", from))
+		}
+		if to == entity.UploadStatusFailed {
+			return releaseFileStorage(tx, id)
+		}
+		return nil
+	})
+}
+
+// GetStalePendingFiles retrieves pending files created before the given time.
+// Files with an in-progress multipart or tus upload are expired by their own sweepers instead.
+func (r *UploadCloudRepositoryRepository) GetStalePendingFiles(ctx context.Context, createdBefore time.Time, limit int) ([]entity.CloudFile, error) {
+	var files []entity.CloudFile
+	err := r.db.WithContext(ctx).
+		Where("upload_status = ? AND created_at < ? AND deleted_at IS NULL", entity.UploadStatusPending, createdBefore).
+		Where("id NOT IN (SELECT file_id FROM multipart_uploads WHERE status = ?)", entity.MultipartStatusInProgress).
+		Where("id NOT IN (SELECT file_id FROM tus_uploads WHERE status = ?)", entity.MultipartStatusInProgress).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&files).Error
 
-	return nil
+	return files, err
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/shared/db/mysql"
 	"gorm.io/gorm"
 )
 
@@ -19,16 +20,16 @@ func NewUserStatsCloudRepositoryRepository(db *gorm.DB) _interface.IUserStatsClo
 	}
 }
 
-// GetTotalStorageUsed gets the total storage used by a user
-func (r *UserStatsCloudRepositoryRepository) GetTotalStorageUsed(ctx context.Context, userID uint) (int64, error) {
-	var total int64
+// GetStorageUsage gets the storage used, the part of it held by the trash, and the storage limit of a user.
+// storage_used is maintained incrementally by uploads and deletes and includes reservations for pending uploads.
+func (r *UserStatsCloudRepositoryRepository) GetStorageUsage(ctx context.Context, userID uint) (int64, int64, int64, error) {
+	var user mysql.User
 	err := r.db.WithContext(ctx).
-		Model(&entity.CloudFile{}).
-		Where("user_id = ? AND deleted_at IS NULL", userID).
-		Select("COALESCE(SUM(file_size), 0)").
-		Scan(&total).Error
+		Select("id", "storage_used", "trash_used", "storage_limit").
+		Where("id = ?", userID).
+		First(&user).Error
 
-	return total, err
+	return user.StorageUsed, user.TrashUsed, user.StorageLimit, err
 }
 
 // GetMonthlyUploadCount gets the number of uploads in a specific month
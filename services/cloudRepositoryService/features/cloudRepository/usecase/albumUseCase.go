@@ -0,0 +1,454 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+// MaxAlbumDepth limits how deep albums can be nested; top-level albums are at depth 1
+const MaxAlbumDepth = 10
+
+type AlbumUseCase struct {
+	AlbumRepo      _interface.IAlbumRepository
+	ListRepo       _interface.IListCloudRepositoryRepository // For presigned URL generation
+	ContextTimeout time.Duration
+}
+
+func NewAlbumUseCase(
+	albumRepo _interface.IAlbumRepository,
+	listRepo _interface.IListCloudRepositoryRepository,
+	timeout time.Duration,
+) _interface.IAlbumUseCase {
+	return &AlbumUseCase{
+		AlbumRepo:      albumRepo,
+		ListRepo:       listRepo,
+		ContextTimeout: timeout,
+	}
+}
+
+// CreateAlbum creates an album at the top level or inside a parent album
+func (u *AlbumUseCase) CreateAlbum(c context.Context, userID uint, req request.CreateAlbumRequestDTO) (*response.AlbumDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, sharedErrors.BadRequest("invalid album name")
+	}
+
+	album := &entity.Album{UserID: userID, Name: name}
+	if req.ParentID != 0 {
+		albums, err := u.AlbumRepo.GetUserAlbums(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get albums: %w", err)
+		}
+		tree := newAlbumTree(albums)
+		if tree.byID[req.ParentID] == nil {
+			return nil, sharedErrors.NotFound("parent album not found")
+		}
+		if len(tree.path(req.ParentID))+1 > MaxAlbumDepth {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid parent album: albums can be nested at most %d levels", MaxAlbumDepth))
+		}
+		album.ParentID = &req.ParentID
+	}
+
+	if err := u.AlbumRepo.CreateAlbum(ctx, album); err != nil {
+		return nil, fmt.Errorf("failed to create album: %w", err)
+	}
+
+	dto := toAlbumDTO(album, 0, 0, "")
+	return &dto, nil
+}
+
+// ListAlbums lists the albums directly inside a parent album, or the top-level albums
+func (u *AlbumUseCase) ListAlbums(c context.Context, userID uint, req request.ListAlbumsRequestDTO) (*response.ListAlbumsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	albums, err := u.AlbumRepo.GetUserAlbums(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get albums: %w", err)
+	}
+	tree := newAlbumTree(albums)
+	if req.ParentID != 0 && tree.byID[req.ParentID] == nil {
+		return nil, sharedErrors.NotFound("parent album not found")
+	}
+
+	albumDTOs, err := u.toAlbumDTOs(ctx, userID, tree, tree.children[req.ParentID])
+	if err != nil {
+		return nil, err
+	}
+	return &response.ListAlbumsResponseDTO{Albums: albumDTOs}, nil
+}
+
+// GetAlbum retrieves an album with its path from the top level and its sub-albums
+func (u *AlbumUseCase) GetAlbum(c context.Context, userID, albumID uint) (*response.AlbumDetailResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tree, album, err := u.loadAlbum(ctx, userID, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	albumDTOs, err := u.toAlbumDTOs(ctx, userID, tree, append([]*entity.Album{album}, tree.children[albumID]...))
+	if err != nil {
+		return nil, err
+	}
+
+	path := tree.path(albumID)
+	pathDTOs := make([]response.AlbumPathDTO, len(path))
+	for i, a := range path {
+		pathDTOs[i] = response.AlbumPathDTO{ID: a.ID, Name: a.Name}
+	}
+
+	return &response.AlbumDetailResponseDTO{
+		Album:  albumDTOs[0],
+		Path:   pathDTOs,
+		Albums: albumDTOs[1:],
+	}, nil
+}
+
+// UpdateAlbum renames an album, moves it to another parent or changes its cover
+func (u *AlbumUseCase) UpdateAlbum(c context.Context, userID, albumID uint, req request.UpdateAlbumRequestDTO) (*response.AlbumDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tree, _, err := u.loadAlbum(ctx, userID, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, sharedErrors.BadRequest("invalid album name")
+		}
+		updates["name"] = name
+	}
+
+	if req.ParentID != nil {
+		if err := tree.validateMove(albumID, *req.ParentID); err != nil {
+			return nil, err
+		}
+		if *req.ParentID == 0 {
+			updates["parent_id"] = nil
+		} else {
+			updates["parent_id"] = *req.ParentID
+		}
+	}
+
+	if req.CoverFileID != nil {
+		if *req.CoverFileID == 0 {
+			updates["cover_file_id"] = nil
+		} else {
+			inAlbum, err := u.AlbumRepo.HasAlbumFile(ctx, albumID, *req.CoverFileID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check album files: %w", err)
+			}
+			if !inAlbum {
+				return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid cover: file %d is not in the album", *req.CoverFileID))
+			}
+			updates["cover_file_id"] = *req.CoverFileID
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := u.AlbumRepo.UpdateAlbum(ctx, userID, albumID, updates); err != nil {
+			return nil, fmt.Errorf("failed to update album: %w", err)
+		}
+		if tree, _, err = u.loadAlbum(ctx, userID, albumID); err != nil {
+			return nil, err
+		}
+	}
+
+	albumDTOs, err := u.toAlbumDTOs(ctx, userID, tree, []*entity.Album{tree.byID[albumID]})
+	if err != nil {
+		return nil, err
+	}
+	return &albumDTOs[0], nil
+}
+
+// DeleteAlbum deletes an album and all of its sub-albums. Files are kept.
+func (u *AlbumUseCase) DeleteAlbum(c context.Context, userID, albumID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tree, _, err := u.loadAlbum(ctx, userID, albumID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.AlbumRepo.DeleteAlbums(ctx, userID, tree.subtree(albumID)); err != nil {
+		return fmt.Errorf("failed to delete album: %w", err)
+	}
+	return nil
+}
+
+// AddFiles appends the user's files to the end of an album
+func (u *AlbumUseCase) AddFiles(c context.Context, userID, albumID uint, req request.AddAlbumFilesRequestDTO) (*response.AddAlbumFilesResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if _, _, err := u.loadAlbum(ctx, userID, albumID); err != nil {
+		return nil, err
+	}
+
+	fileIDs := uniqueIDs(req.FileIDs)
+	files, err := u.AlbumRepo.GetLiveFiles(ctx, userID, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files: %w", err)
+	}
+	if len(files) != len(fileIDs) {
+		return nil, sharedErrors.NotFound("file not found")
+	}
+
+	added, err := u.AlbumRepo.AddAlbumFiles(ctx, albumID, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add files to album: %w", err)
+	}
+	return &response.AddAlbumFilesResponseDTO{AddedCount: added}, nil
+}
+
+// RemoveFile removes a file from an album. The file itself is kept.
+func (u *AlbumUseCase) RemoveFile(c context.Context, userID, albumID, fileID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if _, _, err := u.loadAlbum(ctx, userID, albumID); err != nil {
+		return err
+	}
+
+	if err := u.AlbumRepo.RemoveAlbumFile(ctx, albumID, fileID); err != nil {
+		return fmt.Errorf("failed to remove file from album: %w", err)
+	}
+	return nil
+}
+
+// ReorderFiles moves the listed files to the front of the album in the given order
+func (u *AlbumUseCase) ReorderFiles(c context.Context, userID, albumID uint, req request.ReorderAlbumFilesRequestDTO) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if _, _, err := u.loadAlbum(ctx, userID, albumID); err != nil {
+		return err
+	}
+
+	current, err := u.AlbumRepo.GetAlbumFileIDs(ctx, albumID)
+	if err != nil {
+		return fmt.Errorf("failed to get album files: %w", err)
+	}
+	ordered, err := reorderFileIDs(current, req.FileIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := u.AlbumRepo.SetAlbumFilePositions(ctx, albumID, ordered); err != nil {
+		return fmt.Errorf("failed to reorder album: %w", err)
+	}
+	return nil
+}
+
+// loadAlbum loads the user's album tree and checks the album belongs to the user
+func (u *AlbumUseCase) loadAlbum(ctx context.Context, userID, albumID uint) (*albumTree, *entity.Album, error) {
+	albums, err := u.AlbumRepo.GetUserAlbums(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get albums: %w", err)
+	}
+	tree := newAlbumTree(albums)
+	album := tree.byID[albumID]
+	if album == nil {
+		return nil, nil, sharedErrors.NotFound("album not found")
+	}
+	return tree, album, nil
+}
+
+// toAlbumDTOs maps albums to DTOs with their file counts and cover thumbnails
+func (u *AlbumUseCase) toAlbumDTOs(ctx context.Context, userID uint, tree *albumTree, albums []*entity.Album) ([]response.AlbumDTO, error) {
+	albumIDs := make([]uint, 0, len(albums))
+	coverIDs := make([]uint, 0, len(albums))
+	for _, album := range albums {
+		albumIDs = append(albumIDs, album.ID)
+		if album.CoverFileID != nil {
+			coverIDs = append(coverIDs, *album.CoverFileID)
+		}
+	}
+
+	counts, err := u.AlbumRepo.CountAlbumFiles(ctx, albumIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count album files: %w", err)
+	}
+
+	// Covers in the trash are not shown
+	thumbnailKeys := make(map[uint]string, len(coverIDs))
+	if len(coverIDs) > 0 {
+		covers, err := u.AlbumRepo.GetLiveFiles(ctx, userID, coverIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album covers: %w", err)
+		}
+		for _, cover := range covers {
+			thumbnailKeys[cover.ID] = cover.ThumbnailKey
+		}
+	}
+
+	albumDTOs := make([]response.AlbumDTO, len(albums))
+	for i, album := range albums {
+		coverURL := ""
+		if album.CoverFileID != nil && thumbnailKeys[*album.CoverFileID] != "" {
+			coverURL, err = u.ListRepo.GeneratePresignedDownloadURL(ctx, thumbnailKeys[*album.CoverFileID], 1*time.Hour)
+			if err != nil {
+				// Log error but don't fail the entire request
+				coverURL = ""
+			}
+		}
+		albumDTOs[i] = toAlbumDTO(album, counts[album.ID], len(tree.children[album.ID]), coverURL)
+	}
+	return albumDTOs, nil
+}
+
+func toAlbumDTO(album *entity.Album, fileCount int64, albumCount int, coverURL string) response.AlbumDTO {
+	return response.AlbumDTO{
+		ID:          album.ID,
+		Name:        album.Name,
+		ParentID:    album.ParentID,
+		CoverFileID: album.CoverFileID,
+		CoverURL:    coverURL,
+		FileCount:   fileCount,
+		AlbumCount:  albumCount,
+		CreatedAt:   album.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   album.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// albumTree indexes a user's albums by ID and by parent. Top-level albums have parent 0.
+type albumTree struct {
+	byID     map[uint]*entity.Album
+	children map[uint][]*entity.Album
+}
+
+func newAlbumTree(albums []entity.Album) *albumTree {
+	tree := &albumTree{
+		byID:     make(map[uint]*entity.Album, len(albums)),
+		children: make(map[uint][]*entity.Album),
+	}
+	for i := range albums {
+		album := &albums[i]
+		tree.byID[album.ID] = album
+		var parentID uint
+		if album.ParentID != nil {
+			parentID = *album.ParentID
+		}
+		tree.children[parentID] = append(tree.children[parentID], album)
+	}
+	return tree
+}
+
+// path returns the albums from the top level down to id, inclusive
+func (t *albumTree) path(id uint) []*entity.Album {
+	var path []*entity.Album
+	for album := t.byID[id]; album != nil && len(path) <= len(t.byID); {
+		path = append([]*entity.Album{album}, path...)
+		if album.ParentID == nil {
+			break
+		}
+		album = t.byID[*album.ParentID]
+	}
+	return path
+}
+
+// subtree returns id and the IDs of all albums nested inside it
+func (t *albumTree) subtree(id uint) []uint {
+	ids := []uint{id}
+	for i := 0; i < len(ids) && i <= len(t.byID); i++ {
+		for _, child := range t.children[ids[i]] {
+			ids = append(ids, child.ID)
+		}
+	}
+	return ids
+}
+
+// height returns the number of levels from id down to its deepest sub-album, inclusive
+func (t *albumTree) height(id uint) int {
+	height := 0
+	level := []uint{id}
+	for len(level) > 0 && height <= len(t.byID) {
+		height++
+		var next []uint
+		for _, albumID := range level {
+			for _, child := range t.children[albumID] {
+				next = append(next, child.ID)
+			}
+		}
+		level = next
+	}
+	return height
+}
+
+// validateMove checks that album id can be moved into parentID (0 = top level)
+func (t *albumTree) validateMove(id, parentID uint) error {
+	if parentID == 0 {
+		return nil
+	}
+	if t.byID[parentID] == nil {
+		return sharedErrors.NotFound("parent album not found")
+	}
+	for _, descendant := range t.subtree(id) {
+		if descendant == parentID {
+			return sharedErrors.BadRequest("invalid parent album: an album cannot be moved into itself or its sub-albums")
+		}
+	}
+	if len(t.path(parentID))+t.height(id) > MaxAlbumDepth {
+		return sharedErrors.BadRequest(fmt.Sprintf("invalid parent album: albums can be nested at most %d levels", MaxAlbumDepth))
+	}
+	return nil
+}
+
+// reorderFileIDs moves requested to the front of current in the given order.
+// The remaining files keep their relative order after them.
+func reorderFileIDs(current, requested []uint) ([]uint, error) {
+	inAlbum := make(map[uint]bool, len(current))
+	for _, id := range current {
+		inAlbum[id] = true
+	}
+
+	seen := make(map[uint]bool, len(requested))
+	ordered := make([]uint, 0, len(current))
+	for _, id := range requested {
+		if !inAlbum[id] {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid order: file %d is not in the album", id))
+		}
+		if seen[id] {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid order: file %d is listed twice", id))
+		}
+		seen[id] = true
+		ordered = append(ordered, id)
+	}
+	for _, id := range current {
+		if !seen[id] {
+			ordered = append(ordered, id)
+		}
+	}
+	return ordered, nil
+}
+
+// uniqueIDs returns ids without duplicates, keeping the first occurrence
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
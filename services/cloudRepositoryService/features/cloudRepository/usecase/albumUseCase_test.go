@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestAlbumTree(t *testing.T) {
+	parent := func(id uint) *uint { return &id }
+	// 1 ─ 2 ─ 3
+	//   └ 4
+	// 5
+	tree := newAlbumTree([]entity.Album{
+		{ID: 1, Name: "Trips"},
+		{ID: 2, Name: "2024", ParentID: parent(1)},
+		{ID: 3, Name: "Jeju", ParentID: parent(2)},
+		{ID: 4, Name: "2025", ParentID: parent(1)},
+		{ID: 5, Name: "Family"},
+	})
+
+	var path []uint
+	for _, album := range tree.path(3) {
+		path = append(path, album.ID)
+	}
+	if !reflect.DeepEqual(path, []uint{1, 2, 3}) {
+		t.Errorf("path(3) = %v, want [1 2 3]", path)
+	}
+	if got := tree.subtree(1); !reflect.DeepEqual(got, []uint{1, 2, 4, 3}) {
+		t.Errorf("subtree(1) = %v, want [1 2 4 3]", got)
+	}
+	if got := tree.height(1); got != 3 {
+		t.Errorf("height(1) = %d, want 3", got)
+	}
+	if got := len(tree.children[0]); got != 2 {
+		t.Errorf("top-level albums = %d, want 2", got)
+	}
+
+	tests := []struct {
+		name     string
+		id       uint
+		parentID uint
+		wantErr  string
+	}{
+		{name: "move to top level", id: 3, parentID: 0},
+		{name: "move to sibling", id: 4, parentID: 2},
+		{name: "move to another tree", id: 1, parentID: 5},
+		{name: "move into itself", id: 1, parentID: 1, wantErr: "invalid parent album"},
+		{name: "move into descendant", id: 1, parentID: 3, wantErr: "invalid parent album"},
+		{name: "missing parent", id: 1, parentID: 99, wantErr: "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tree.validateMove(tt.id, tt.parentID)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateMove() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateMove() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAlbumTreeMaxDepth(t *testing.T) {
+	var albums []entity.Album
+	for id := uint(1); id <= MaxAlbumDepth; id++ {
+		album := entity.Album{ID: id}
+		if id > 1 {
+			parentID := id - 1
+			album.ParentID = &parentID
+		}
+		albums = append(albums, album)
+	}
+	// A two-level album 100 ─ 101
+	parentID := uint(100)
+	albums = append(albums, entity.Album{ID: 100}, entity.Album{ID: 101, ParentID: &parentID})
+	tree := newAlbumTree(albums)
+
+	if err := tree.validateMove(101, MaxAlbumDepth-1); err != nil {
+		t.Errorf("validateMove() to depth %d error = %v", MaxAlbumDepth, err)
+	}
+	if err := tree.validateMove(100, MaxAlbumDepth-1); err == nil {
+		t.Errorf("validateMove() of a two-level album below depth %d succeeded", MaxAlbumDepth-1)
+	}
+}
+
+func TestReorderFileIDs(t *testing.T) {
+	current := []uint{10, 20, 30, 40}
+
+	tests := []struct {
+		name      string
+		requested []uint
+		want      []uint
+		wantErr   string
+	}{
+		{name: "full order", requested: []uint{40, 30, 20, 10}, want: []uint{40, 30, 20, 10}},
+		{name: "partial order keeps the rest", requested: []uint{30, 10}, want: []uint{30, 10, 20, 40}},
+		{name: "file not in album", requested: []uint{50}, wantErr: "not in the album"},
+		{name: "duplicate file", requested: []uint{20, 20}, wantErr: "listed twice"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := reorderFileIDs(current, tt.requested)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("reorderFileIDs() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("reorderFileIDs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("reorderFileIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,473 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// ArchiveStreamMaxFiles is the largest selection streamed directly; larger ones become export jobs
+	ArchiveStreamMaxFiles = 500
+	// ArchiveStreamMaxBytes is the largest total file size streamed directly
+	ArchiveStreamMaxBytes = 2 << 30 // 2 GiB
+	// ArchiveExportMaxFiles limits how many files one archive can contain
+	ArchiveExportMaxFiles = 10000
+	// ArchiveExportRetention is how long a finished export archive can be downloaded
+	ArchiveExportRetention = 7 * 24 * time.Hour
+	// ArchivePartSize is the S3 multipart part size used to upload export archives
+	ArchivePartSize = 16 << 20 // 16 MiB
+	// ExportStaleAfter is how long an export may stay running before another job run takes it over
+	ExportStaleAfter = 2 * time.Hour
+	// ExportTimeout limits how long building one export archive may take
+	ExportTimeout = 90 * time.Minute
+	// ArchiveManifestName is the entry describing the archive, written last
+	ArchiveManifestName = "manifest.json"
+)
+
+type ArchiveUseCase struct {
+	Repo           _interface.IArchiveRepository
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewArchiveUseCase(
+	repo _interface.IArchiveRepository,
+	authorizer _interface.IFileAuthorizer,
+	timeout time.Duration,
+) _interface.IArchiveUseCase {
+	return &ArchiveUseCase{
+		Repo:           repo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// PrepareArchive resolves the selected files the user may download.
+// Small selections return a plan to stream with WriteArchive; large or async ones create an export job instead.
+func (u *ArchiveUseCase) PrepareArchive(c context.Context, userID uint, req request.CreateArchiveRequestDTO) (*entity.ArchivePlan, *response.ExportJobDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if len(req.FileIDs) == 0 && req.Tag == "" {
+		return nil, nil, sharedErrors.BadRequest("invalid request: file_ids or tag is required")
+	}
+	if len(req.FileIDs) > 0 && req.Tag != "" {
+		return nil, nil, sharedErrors.BadRequest("invalid request: use either file_ids or tag, not both")
+	}
+
+	fileIDs := uniqueIDs(req.FileIDs)
+	if req.Tag != "" {
+		ids, err := u.Repo.GetTaggedFileIDs(ctx, userID, req.Tag, ArchiveExportMaxFiles+1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get tagged files: %w", err)
+		}
+		fileIDs = ids
+	}
+	if len(fileIDs) > ArchiveExportMaxFiles {
+		return nil, nil, sharedErrors.BadRequest(fmt.Sprintf("archive too large: maximum %d files allowed", ArchiveExportMaxFiles))
+	}
+
+	plan, err := u.buildPlan(ctx, userID, fileIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(plan.Entries) == 0 {
+		return nil, nil, sharedErrors.NotFound("file not found: none of the selected files can be downloaded")
+	}
+
+	if !req.Async && len(plan.Entries) <= ArchiveStreamMaxFiles && plan.TotalSize <= ArchiveStreamMaxBytes {
+		return plan, nil, nil
+	}
+
+	ids := make([]uint, len(plan.Entries))
+	for i, entry := range plan.Entries {
+		ids[i] = entry.File.ID
+	}
+	encoded, err := json.Marshal(ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode export selection: %w", err)
+	}
+
+	job := &entity.ExportJob{
+		UserID:    userID,
+		Status:    entity.ExportStatusPending,
+		FileIDs:   string(encoded),
+		FileCount: len(ids),
+		TotalSize: plan.TotalSize,
+	}
+	if err := u.Repo.CreateExportJob(ctx, job); err != nil {
+		return nil, nil, fmt.Errorf("failed to create export: %w", err)
+	}
+
+	dto := u.toExportJobDTO(ctx, job)
+	return nil, &dto, nil
+}
+
+// WriteArchive streams a ZIP archive of the plan to w, one object at a time without buffering whole files.
+// Files whose content is missing from storage are left out and listed in the manifest, which is written last.
+func (u *ArchiveUseCase) WriteArchive(ctx context.Context, plan *entity.ArchivePlan, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	manifest := response.ArchiveManifestDTO{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Files:       make([]response.ArchiveManifestFileDTO, 0, len(plan.Entries)),
+	}
+
+	for _, entry := range plan.Entries {
+		file := entry.File
+		body, err := u.Repo.OpenObject(ctx, file.S3Key)
+		if errors.Is(err, sharedAws.ErrObjectNotFound) {
+			plan.Skipped = append(plan.Skipped, entity.ArchiveSkippedFile{FileID: file.ID, Reason: "content missing from storage"})
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to open file %d: %w", file.ID, err)
+		}
+
+		// Photos and videos are already compressed, so entries are stored as is
+		header := &zip.FileHeader{
+			Name:     entry.Name,
+			Method:   zip.Store,
+			Modified: file.CreatedAt,
+		}
+		entryWriter, err := zw.CreateHeader(header)
+		if err == nil {
+			_, err = io.Copy(entryWriter, body)
+		}
+		body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to write file %d to archive: %w", file.ID, err)
+		}
+
+		manifest.Files = append(manifest.Files, response.ArchiveManifestFileDTO{
+			FileID:      file.ID,
+			Path:        entry.Name,
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			FileSize:    file.FileSize,
+			SHA256:      file.SHA256,
+			CreatedAt:   file.CreatedAt.Format(time.RFC3339),
+		})
+		manifest.TotalSize += file.FileSize
+	}
+	manifest.FileCount = len(manifest.Files)
+
+	manifest.Skipped = make([]response.ArchiveSkippedFileDTO, len(plan.Skipped))
+	for i, skipped := range plan.Skipped {
+		manifest.Skipped[i] = response.ArchiveSkippedFileDTO{FileID: skipped.FileID, Reason: skipped.Reason}
+	}
+
+	manifestWriter, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     ArchiveManifestName,
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	encoder := json.NewEncoder(manifestWriter)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return nil
+}
+
+// GetExport returns an export of the user with a download URL once it is completed
+func (u *ArchiveUseCase) GetExport(c context.Context, userID, jobID uint) (*response.ExportJobDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	job, err := u.Repo.GetExportJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, sharedErrors.NotFound("export not found")
+	}
+
+	dto := u.toExportJobDTO(ctx, job)
+	return &dto, nil
+}
+
+// ListExports lists the user's exports, newest first
+func (u *ArchiveUseCase) ListExports(c context.Context, userID uint, req request.ListExportsRequestDTO) (*response.ListExportJobsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	jobs, total, err := u.Repo.GetUserExportJobs(ctx, userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exports: %w", err)
+	}
+
+	exports := make([]response.ExportJobDTO, len(jobs))
+	for i := range jobs {
+		exports[i] = u.toExportJobDTO(ctx, &jobs[i])
+	}
+
+	return &response.ListExportJobsResponseDTO{
+		Exports:    exports,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}, nil
+}
+
+// ProcessExportJobs builds the archives of pending exports one after another.
+// Returns the number of processed exports.
+func (u *ArchiveUseCase) ProcessExportJobs(ctx context.Context) (int, error) {
+	processed := 0
+	for {
+		job, err := u.Repo.ClaimExportJob(ctx, time.Now().Add(-ExportStaleAfter))
+		if err != nil {
+			return processed, fmt.Errorf("failed to claim export: %w", err)
+		}
+		if job == nil {
+			return processed, nil
+		}
+
+		if err := u.runExport(ctx, job); err != nil {
+			fmt.Printf("Warning: export %d failed: %v\n", job.ID, err)
+		}
+		processed++
+	}
+}
+
+// PurgeExpiredExports deletes expired export archives and their records.
+// Returns the number of purged exports.
+func (u *ArchiveUseCase) PurgeExpiredExports(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	jobs, err := u.Repo.GetExpiredExportJobs(ctx, time.Now(), 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired exports: %w", err)
+	}
+
+	purged := 0
+	for _, job := range jobs {
+		if job.S3Key != "" {
+			if err := u.Repo.DeleteFromS3(ctx, job.S3Key); err != nil {
+				// Keep the record so the next run retries
+				fmt.Printf("Warning: failed to delete export archive %d from S3: %v\n", job.ID, err)
+				continue
+			}
+		}
+		if err := u.Repo.DeleteExportJob(ctx, job.ID); err != nil {
+			fmt.Printf("Warning: failed to delete export %d: %v\n", job.ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// runExport writes the archive of a claimed export to S3 as a multipart upload.
+// Access is checked again, so files the user lost access to since the request are left out.
+func (u *ArchiveUseCase) runExport(c context.Context, job *entity.ExportJob) error {
+	ctx, cancel := context.WithTimeout(c, ExportTimeout)
+	defer cancel()
+
+	var fileIDs []uint
+	if err := json.Unmarshal([]byte(job.FileIDs), &fileIDs); err != nil {
+		return u.failExport(ctx, job, fmt.Errorf("invalid export selection: %w", err))
+	}
+
+	plan, err := u.buildPlan(ctx, job.UserID, fileIDs)
+	if err != nil {
+		return u.failExport(ctx, job, err)
+	}
+	if len(plan.Entries) == 0 {
+		return u.failExport(ctx, job, fmt.Errorf("none of the selected files can be downloaded"))
+	}
+
+	s3Key := exportS3Key(job.UserID, job.ID)
+	uploadID, err := u.Repo.CreateMultipartUpload(ctx, s3Key, "application/zip")
+	if err != nil {
+		return u.failExport(ctx, job, err)
+	}
+
+	writer := &archivePartWriter{ctx: ctx, repo: u.Repo, s3Key: s3Key, uploadID: uploadID}
+	err = u.WriteArchive(ctx, plan, writer)
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		if abortErr := u.Repo.AbortMultipartUpload(ctx, s3Key, uploadID); abortErr != nil {
+			fmt.Printf("Warning: failed to abort export upload: %v\n", abortErr)
+		}
+		return u.failExport(ctx, job, err)
+	}
+
+	if err := u.Repo.CompleteExportJob(ctx, job.ID, s3Key, writer.size, time.Now().Add(ArchiveExportRetention)); err != nil {
+		// Another run took the export over; its archive replaces this one
+		return err
+	}
+	return nil
+}
+
+func (u *ArchiveUseCase) failExport(ctx context.Context, job *entity.ExportJob, cause error) error {
+	if err := u.Repo.FailExportJob(ctx, job.ID, cause.Error()); err != nil {
+		fmt.Printf("Warning: failed to mark export %d as failed: %v\n", job.ID, err)
+	}
+	return cause
+}
+
+// buildPlan loads the selected files in order and keeps those the user may download
+func (u *ArchiveUseCase) buildPlan(ctx context.Context, userID uint, fileIDs []uint) (*entity.ArchivePlan, error) {
+	filesByID := make(map[uint]*entity.CloudFile, len(fileIDs))
+	for _, chunk := range chunkIDs(fileIDs, 1000) {
+		files, err := u.Repo.GetLiveFiles(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get files: %w", err)
+		}
+		for i := range files {
+			filesByID[files[i].ID] = &files[i]
+		}
+	}
+
+	plan := &entity.ArchivePlan{}
+	files := make([]entity.CloudFile, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		file, ok := filesByID[fileID]
+		if !ok {
+			plan.Skipped = append(plan.Skipped, entity.ArchiveSkippedFile{FileID: fileID, Reason: "file not found"})
+			continue
+		}
+		if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionDownload); err != nil {
+			plan.Skipped = append(plan.Skipped, entity.ArchiveSkippedFile{FileID: fileID, Reason: err.Error()})
+			continue
+		}
+		files = append(files, *file)
+		plan.TotalSize += file.FileSize
+	}
+
+	names := archiveEntryNames(files)
+	plan.Entries = make([]entity.ArchiveEntry, len(files))
+	for i := range files {
+		plan.Entries[i] = entity.ArchiveEntry{File: files[i], Name: names[i]}
+	}
+	return plan, nil
+}
+
+func (u *ArchiveUseCase) toExportJobDTO(ctx context.Context, job *entity.ExportJob) response.ExportJobDTO {
+	dto := response.ExportJobDTO{
+		ID:          job.ID,
+		Status:      string(job.Status),
+		FileCount:   job.FileCount,
+		TotalSize:   job.TotalSize,
+		ArchiveSize: job.ArchiveSize,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt.Format(time.RFC3339),
+	}
+	if job.CompletedAt != nil {
+		dto.CompletedAt = job.CompletedAt.Format(time.RFC3339)
+	}
+	if job.ExpiresAt != nil {
+		dto.ExpiresAt = job.ExpiresAt.Format(time.RFC3339)
+	}
+
+	if job.Status == entity.ExportStatusCompleted && job.ExpiresAt != nil && time.Now().Before(*job.ExpiresAt) {
+		downloadURL, err := u.Repo.GeneratePresignedDownloadURLWithFilename(ctx, job.S3Key, fmt.Sprintf("export-%d.zip", job.ID), 1*time.Hour)
+		if err != nil {
+			// Log error but don't fail the entire request
+			downloadURL = ""
+		}
+		dto.DownloadURL = downloadURL
+	}
+	return dto
+}
+
+// archiveEntryNames returns a unique entry name for each file, in order.
+// Names are compared case-insensitively, since most unzip tools run on case-insensitive file systems;
+// duplicates get a " (n)" suffix before the extension.
+func archiveEntryNames(files []entity.CloudFile) []string {
+	taken := map[string]bool{strings.ToLower(ArchiveManifestName): true}
+	names := make([]string, len(files))
+	for i, file := range files {
+		// Never let a stored file name create directories or escape the archive root
+		name := path.Base(strings.ReplaceAll(file.FileName, "\\", "/"))
+		if name == "." || name == "/" || name == ".." {
+			name = fmt.Sprintf("file-%d", file.ID)
+		}
+
+		candidate := name
+		ext := path.Ext(name)
+		base := strings.TrimSuffix(name, ext)
+		for n := 1; taken[strings.ToLower(candidate)]; n++ {
+			candidate = fmt.Sprintf("%s (%d)%s", base, n, ext)
+		}
+		taken[strings.ToLower(candidate)] = true
+		names[i] = candidate
+	}
+	return names
+}
+
+// exportS3Key returns where the archive of an export is stored
+func exportS3Key(userID, jobID uint) string {
+	return fmt.Sprintf("users/%d/exports/%d.zip", userID, jobID)
+}
+
+// archivePartWriter uploads everything written to it as the parts of an S3 multipart upload
+type archivePartWriter struct {
+	ctx      context.Context
+	repo     _interface.IArchiveRepository
+	s3Key    string
+	uploadID string
+	buf      []byte
+	parts    []sharedAws.UploadedPart
+	size     int64
+}
+
+func (w *archivePartWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= ArchivePartSize {
+		if err := w.uploadPart(w.buf[:ArchivePartSize]); err != nil {
+			return 0, err
+		}
+		w.buf = append(w.buf[:0], w.buf[ArchivePartSize:]...)
+	}
+	return len(p), nil
+}
+
+// Close uploads the last part, which may be smaller than ArchivePartSize, and completes the upload
+func (w *archivePartWriter) Close() error {
+	if len(w.buf) > 0 || len(w.parts) == 0 {
+		if err := w.uploadPart(w.buf); err != nil {
+			return err
+		}
+		w.buf = nil
+	}
+	return w.repo.CompleteMultipartUpload(w.ctx, w.s3Key, w.uploadID, w.parts)
+}
+
+func (w *archivePartWriter) uploadPart(body []byte) error {
+	partNumber := int32(len(w.parts) + 1)
+	etag, err := w.repo.UploadPart(w.ctx, w.s3Key, w.uploadID, partNumber, body)
+	if err != nil {
+		return err
+	}
+	w.parts = append(w.parts, sharedAws.UploadedPart{PartNumber: partNumber, ETag: etag, Size: int64(len(body))})
+	w.size += int64(len(body))
+	return nil
+}
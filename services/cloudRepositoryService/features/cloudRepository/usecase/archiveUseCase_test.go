@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestArchiveEntryNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "unique names are kept",
+			files: []string{"a.jpg", "b.jpg"},
+			want:  []string{"a.jpg", "b.jpg"},
+		},
+		{
+			name:  "duplicates get a suffix before the extension",
+			files: []string{"a.jpg", "a.jpg", "A.JPG", "a (1).jpg"},
+			want:  []string{"a.jpg", "a (1).jpg", "A (2).JPG", "a (1) (1).jpg"},
+		},
+		{
+			name:  "directories are stripped",
+			files: []string{"../../etc/passwd", `C:\photos\b.png`, "/"},
+			want:  []string{"passwd", "b.png", "file-3"},
+		},
+		{
+			name:  "manifest name is reserved",
+			files: []string{"manifest.json", "noext", "noext"},
+			want:  []string{"manifest (1).json", "noext", "noext (1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := make([]entity.CloudFile, len(tt.files))
+			for i, name := range tt.files {
+				files[i] = entity.CloudFile{ID: uint(i + 1), FileName: name}
+			}
+			if got := archiveEntryNames(files); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("archiveEntryNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 )
 
 type BatchUploadCloudRepositoryUseCase struct {
@@ -22,41 +24,57 @@ func NewBatchUploadCloudRepositoryUseCase(uploadUseCase _interface.IUploadCloudR
 	}
 }
 
-// RequestBatchUploadURL generates presigned upload URLs for multiple files (max 30)
+// RequestBatchUploadURL generates presigned upload URLs for multiple files (max 30).
+// Files that do not fit in the user's storage quota are counted as failed.
 func (u *BatchUploadCloudRepositoryUseCase) RequestBatchUploadURL(c context.Context, userID uint, req *request.BatchUploadRequestDTO) (*response.BatchUploadResponseDTO, error) {
 	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
 	defer cancel()
 	maxCount := 30
 	if len(req.Files) == 0 {
-		return nil, fmt.Errorf("no files provided")
+		return nil, sharedErrors.BadRequest("no files provided")
 	}
 
 	if len(req.Files) > maxCount {
-		return nil, fmt.Errorf("maximum 30 files allowed, got %d", len(req.Files))
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("maximum 30 files allowed, got %d", len(req.Files)))
 	}
 
 	results := make([]response.UploadResponseDTO, 0, len(req.Files))
 	successCount := 0
 	failedCount := 0
+	duplicateCount := 0
+	var quotaErr error
 
-	// Process each file
+	// Process each file; every file reserves its own size so smaller files can still fit after a quota error
 	for _, fileReq := range req.Files {
 		uploadResp, err := u.UploadUseCase.RequestUploadURL(ctx, userID, &fileReq)
 		if err != nil {
 			// Log error but continue processing other files
 			fmt.Printf("Failed to process file %s: %v\n", fileReq.FileName, err)
 			failedCount++
+			var appErr *sharedErrors.AppError
+			if errors.As(err, &appErr) && appErr.Code == sharedErrors.ErrCodeQuotaExceeded {
+				quotaErr = err
+			}
 			continue
 		}
 
 		results = append(results, *uploadResp)
 		successCount++
+		if uploadResp.Duplicate {
+			duplicateCount++
+		}
+	}
+
+	// Nothing fit in the remaining quota - report it instead of an empty result
+	if successCount == 0 && quotaErr != nil {
+		return nil, quotaErr
 	}
 
 	return &response.BatchUploadResponseDTO{
-		Results:      results,
-		TotalCount:   len(req.Files),
-		SuccessCount: successCount,
-		FailedCount:  failedCount,
+		Results:        results,
+		TotalCount:     len(req.Files),
+		SuccessCount:   successCount,
+		FailedCount:    failedCount,
+		DuplicateCount: duplicateCount,
 	}, nil
 }
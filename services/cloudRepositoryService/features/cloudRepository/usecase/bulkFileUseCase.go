@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// BulkMaxFiles limits how many files one bulk operation can change
+	BulkMaxFiles = 1000
+	// BulkChunkSize is how many files are changed per transaction
+	BulkChunkSize = 100
+)
+
+type BulkFileUseCase struct {
+	BulkRepo       _interface.IBulkFileRepository
+	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewBulkFileUseCase(
+	bulkRepo _interface.IBulkFileRepository,
+	statsRepo _interface.IUserStatsCloudRepositoryRepository,
+	authorizer _interface.IFileAuthorizer,
+	timeout time.Duration,
+) _interface.IBulkFileUseCase {
+	return &BulkFileUseCase{
+		BulkRepo:       bulkRepo,
+		StatsRepo:      statsRepo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// Execute applies one operation to the selected files in chunks of BulkChunkSize.
+// Each chunk is changed in one transaction; files the user cannot change are reported per item and skipped.
+func (u *BulkFileUseCase) Execute(c context.Context, userID uint, req request.BulkFileOperationRequestDTO) (*response.BulkFileOperationResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tags := normalizeTagNames(req.Tags)
+	if err := validateBulkRequest(req, tags); err != nil {
+		return nil, err
+	}
+
+	fileIDs := uniqueIDs(req.FileIDs)
+	if req.Filter != nil {
+		ids, err := u.BulkRepo.GetFileIDsByFilter(ctx, userID, *req.Filter, BulkMaxFiles+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve filter: %w", err)
+		}
+		if len(ids) > BulkMaxFiles {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("bulk operation too large: filter matches more than %d files", BulkMaxFiles))
+		}
+		fileIDs = ids
+	}
+
+	required := requiredBulkPermission(req.Operation)
+	results := make([]response.BulkFileResultDTO, 0, len(fileIDs))
+	successCount := 0
+	for _, chunk := range chunkIDs(fileIDs, BulkChunkSize) {
+		failures := u.executeChunk(ctx, userID, req.Operation, required, chunk, tags)
+		for _, fileID := range chunk {
+			if msg, failed := failures[fileID]; failed {
+				results = append(results, response.BulkFileResultDTO{FileID: fileID, Error: msg})
+				continue
+			}
+			results = append(results, response.BulkFileResultDTO{FileID: fileID, Success: true})
+			successCount++
+		}
+	}
+
+	if successCount > 0 {
+		u.logBulkTagActivity(ctx, userID, req.Operation, tags)
+	}
+
+	return &response.BulkFileOperationResponseDTO{
+		Operation:    string(req.Operation),
+		Results:      results,
+		TotalCount:   len(fileIDs),
+		SuccessCount: successCount,
+		FailedCount:  len(fileIDs) - successCount,
+	}, nil
+}
+
+// executeChunk applies the operation to one chunk and returns the error message of every file that was not changed
+func (u *BulkFileUseCase) executeChunk(ctx context.Context, userID uint, operation request.BulkOperation, required entity.FilePermission, chunk []uint, tags []string) map[uint]string {
+	failures := make(map[uint]string)
+
+	// Removing favorites needs no access to the file, like RemoveFavorite
+	if operation == request.BulkOperationUnfavorite {
+		if err := u.BulkRepo.RemoveFavorites(ctx, userID, chunk); err != nil {
+			for _, fileID := range chunk {
+				failures[fileID] = fmt.Sprintf("failed to %s: %v", operation, err)
+			}
+		}
+		return failures
+	}
+
+	files, err := u.BulkRepo.GetLiveFiles(ctx, chunk)
+	if err != nil {
+		for _, fileID := range chunk {
+			failures[fileID] = fmt.Sprintf("failed to get file: %v", err)
+		}
+		return failures
+	}
+	filesByID := make(map[uint]*entity.CloudFile, len(files))
+	for i := range files {
+		filesByID[files[i].ID] = &files[i]
+	}
+
+	allowed := make([]entity.CloudFile, 0, len(chunk))
+	allowedIDs := make([]uint, 0, len(chunk))
+	for _, fileID := range chunk {
+		file, ok := filesByID[fileID]
+		if !ok {
+			failures[fileID] = "file not found"
+			continue
+		}
+		if err := u.Authorizer.Authorize(ctx, userID, file, required); err != nil {
+			failures[fileID] = err.Error()
+			continue
+		}
+		allowed = append(allowed, *file)
+		allowedIDs = append(allowedIDs, fileID)
+	}
+	if len(allowed) == 0 {
+		return failures
+	}
+
+	switch operation {
+	case request.BulkOperationDelete:
+		err = u.BulkRepo.TrashFiles(ctx, userID, allowedIDs)
+	case request.BulkOperationAddTags:
+		err = u.BulkRepo.AddTags(ctx, allowed, tags)
+	case request.BulkOperationRemoveTags:
+		err = u.BulkRepo.RemoveTags(ctx, allowedIDs, tags)
+	case request.BulkOperationFavorite:
+		err = u.BulkRepo.AddFavorites(ctx, userID, allowedIDs)
+	}
+	if err != nil {
+		for _, fileID := range allowedIDs {
+			failures[fileID] = fmt.Sprintf("failed to %s: %v", operation, err)
+		}
+	}
+	return failures
+}
+
+// logBulkTagActivity logs each tag added or removed by a bulk operation once
+func (u *BulkFileUseCase) logBulkTagActivity(ctx context.Context, userID uint, operation request.BulkOperation, tags []string) {
+	if u.StatsRepo == nil {
+		return
+	}
+
+	var activityType entity.ActivityType
+	switch operation {
+	case request.BulkOperationAddTags:
+		activityType = entity.ActivityTypeTagAdd
+	case request.BulkOperationRemoveTags:
+		activityType = entity.ActivityTypeTagDel
+	default:
+		return
+	}
+
+	for _, tag := range tags {
+		activity := &entity.ActivityLog{
+			UserID:       userID,
+			ActivityType: activityType,
+			TagName:      tag,
+		}
+		_ = u.StatsRepo.LogActivity(ctx, activity) // Don't fail on logging error
+	}
+}
+
+// validateBulkRequest checks the file selection and the tags of a bulk request
+func validateBulkRequest(req request.BulkFileOperationRequestDTO, tags []string) error {
+	if len(req.FileIDs) == 0 && req.Filter == nil {
+		return sharedErrors.BadRequest("invalid request: file_ids or filter is required")
+	}
+	if len(req.FileIDs) > 0 && req.Filter != nil {
+		return sharedErrors.BadRequest("invalid request: use either file_ids or filter, not both")
+	}
+	if req.Filter != nil && req.Filter.Color != "" {
+		if _, err := request.ParseHexColor(req.Filter.Color); err != nil {
+			return err
+		}
+	}
+	if len(req.FileIDs) > BulkMaxFiles {
+		return sharedErrors.BadRequest(fmt.Sprintf("bulk operation too large: maximum %d files allowed, got %d", BulkMaxFiles, len(req.FileIDs)))
+	}
+
+	switch req.Operation {
+	case request.BulkOperationAddTags, request.BulkOperationRemoveTags:
+		if len(tags) == 0 {
+			return sharedErrors.BadRequest(fmt.Sprintf("invalid request: tags are required for %s", req.Operation))
+		}
+	case request.BulkOperationDelete, request.BulkOperationFavorite, request.BulkOperationUnfavorite:
+	default:
+		return sharedErrors.BadRequest(fmt.Sprintf("invalid operation: %s", req.Operation))
+	}
+	return nil
+}
+
+// requiredBulkPermission returns the permission a user needs on each file for an operation
+func requiredBulkPermission(operation request.BulkOperation) entity.FilePermission {
+	switch operation {
+	case request.BulkOperationDelete:
+		return entity.FilePermissionOwner
+	case request.BulkOperationAddTags, request.BulkOperationRemoveTags:
+		return entity.FilePermissionEdit
+	default:
+		return entity.FilePermissionView
+	}
+}
+
+// chunkIDs splits IDs into consecutive chunks of at most size IDs
+func chunkIDs(ids []uint, size int) [][]uint {
+	chunks := make([][]uint, 0, (len(ids)+size-1)/size)
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+)
+
+func TestChunkIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		ids  []uint
+		size int
+		want [][]uint
+	}{
+		{"empty", nil, 2, [][]uint{}},
+		{"exact chunks", []uint{1, 2, 3, 4}, 2, [][]uint{{1, 2}, {3, 4}}},
+		{"last chunk shorter", []uint{1, 2, 3, 4, 5}, 2, [][]uint{{1, 2}, {3, 4}, {5}}},
+		{"single chunk", []uint{1, 2}, 100, [][]uint{{1, 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chunkIDs(tt.ids, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunkIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateBulkRequest(t *testing.T) {
+	tooMany := make([]uint, BulkMaxFiles+1)
+
+	tests := []struct {
+		name    string
+		req     request.BulkFileOperationRequestDTO
+		tags    []string
+		wantErr bool
+	}{
+		{"file ids", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationDelete, FileIDs: []uint{1}}, nil, false},
+		{"filter", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationFavorite, Filter: &request.ListFilesRequestDTO{}}, nil, false},
+		{"no selection", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationDelete}, nil, true},
+		{"ids and filter", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationDelete, FileIDs: []uint{1}, Filter: &request.ListFilesRequestDTO{}}, nil, true},
+		{"too many ids", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationDelete, FileIDs: tooMany}, nil, true},
+		{"add tags without tags", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationAddTags, FileIDs: []uint{1}}, nil, true},
+		{"remove tags", request.BulkFileOperationRequestDTO{Operation: request.BulkOperationRemoveTags, FileIDs: []uint{1}}, []string{"beach"}, false},
+		{"unknown operation", request.BulkFileOperationRequestDTO{Operation: "move", FileIDs: []uint{1}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBulkRequest(tt.req, tt.tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateBulkRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
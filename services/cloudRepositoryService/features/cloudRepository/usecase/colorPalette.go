@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sort"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// PaletteSize is the number of dominant colors found per image
+	PaletteSize = 5
+	// paletteSampleSize is the longest edge images are shrunk to before their pixels are clustered
+	paletteSampleSize = 64
+	// paletteIterations bounds the k-means iterations
+	paletteIterations = 20
+)
+
+// rgbPoint is a pixel or cluster center in RGB space
+type rgbPoint [3]float64
+
+func (p rgbPoint) distanceSquared(other rgbPoint) float64 {
+	dr, dg, db := p[0]-other[0], p[1]-other[1], p[2]-other[2]
+	return dr*dr + dg*dg + db*db
+}
+
+// dominantColors clusters the pixels of a downscaled copy of the image into up to k colors with k-means and
+// returns them largest share first. Centers start from the mean color and then the pixel farthest from every
+// chosen center, so the palette is deterministic and includes small but distinct accents.
+func dominantColors(img image.Image, k int) []entity.FileColor {
+	small := imaging.Fit(img, paletteSampleSize, paletteSampleSize, imaging.Box)
+	width, height := small.Bounds().Dx(), small.Bounds().Dy()
+	pixels := make([]rgbPoint, 0, width*height)
+	for y := 0; y < height; y++ {
+		row := small.Pix[y*small.Stride:]
+		for x := 0; x < width; x++ {
+			pixels = append(pixels, rgbPoint{float64(row[x*4]), float64(row[x*4+1]), float64(row[x*4+2])})
+		}
+	}
+	if len(pixels) == 0 {
+		return nil
+	}
+
+	centers := initialCenters(pixels, k)
+	assignments := make([]int, len(pixels))
+	counts := make([]int, len(centers))
+	for iteration := 0; iteration < paletteIterations; iteration++ {
+		changed := iteration == 0
+		for i, pixel := range pixels {
+			nearest := nearestCenter(centers, pixel)
+			if nearest != assignments[i] {
+				assignments[i] = nearest
+				changed = true
+			}
+		}
+
+		sums := make([]rgbPoint, len(centers))
+		for i := range counts {
+			counts[i] = 0
+		}
+		for i, pixel := range pixels {
+			c := assignments[i]
+			counts[c]++
+			for channel := range pixel {
+				sums[c][channel] += pixel[channel]
+			}
+		}
+		for c := range centers {
+			if counts[c] > 0 {
+				for channel := range sums[c] {
+					centers[c][channel] = sums[c][channel] / float64(counts[c])
+				}
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	palette := make([]entity.FileColor, 0, len(centers))
+	for c, center := range centers {
+		if counts[c] == 0 {
+			continue
+		}
+		r, g, b := uint8(math.Round(center[0])), uint8(math.Round(center[1])), uint8(math.Round(center[2]))
+		lab := entity.LabFromRGB(r, g, b)
+		palette = append(palette, entity.FileColor{
+			Hex:    fmt.Sprintf("#%02x%02x%02x", r, g, b),
+			L:      math.Round(lab.L*100) / 100,
+			A:      math.Round(lab.A*100) / 100,
+			B:      math.Round(lab.B*100) / 100,
+			Weight: math.Round(float64(counts[c])/float64(len(pixels))*1000) / 1000,
+		})
+	}
+	sort.SliceStable(palette, func(i, j int) bool {
+		return palette[i].Weight > palette[j].Weight
+	})
+	return palette
+}
+
+// initialCenters picks up to k distinct starting centers: the mean color, then repeatedly the pixel farthest from
+// the chosen centers. Images with fewer distinct colors get fewer centers.
+func initialCenters(pixels []rgbPoint, k int) []rgbPoint {
+	var mean rgbPoint
+	for _, pixel := range pixels {
+		for channel := range pixel {
+			mean[channel] += pixel[channel]
+		}
+	}
+	for channel := range mean {
+		mean[channel] /= float64(len(pixels))
+	}
+
+	centers := []rgbPoint{mean}
+	distances := make([]float64, len(pixels))
+	for i, pixel := range pixels {
+		distances[i] = pixel.distanceSquared(mean)
+	}
+	for len(centers) < k {
+		farthest := 0
+		for i := range distances {
+			if distances[i] > distances[farthest] {
+				farthest = i
+			}
+		}
+		if distances[farthest] == 0 {
+			break
+		}
+
+		center := pixels[farthest]
+		centers = append(centers, center)
+		for i, pixel := range pixels {
+			distances[i] = math.Min(distances[i], pixel.distanceSquared(center))
+		}
+	}
+	return centers
+}
+
+// nearestCenter returns the index of the center closest to a pixel
+func nearestCenter(centers []rgbPoint, pixel rgbPoint) int {
+	nearest := 0
+	best := math.Inf(1)
+	for c, center := range centers {
+		if d := pixel.distanceSquared(center); d < best {
+			nearest, best = c, d
+		}
+	}
+	return nearest
+}
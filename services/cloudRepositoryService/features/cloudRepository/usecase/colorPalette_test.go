@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestDominantColors(t *testing.T) {
+	// 70% red on the left, 30% blue on the right
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 50))
+	for y := 0; y < 50; y++ {
+		for x := 0; x < 100; x++ {
+			c := color.NRGBA{R: 220, G: 30, B: 30, A: 255}
+			if x >= 70 {
+				c = color.NRGBA{R: 20, G: 40, B: 200, A: 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	palette := dominantColors(img, PaletteSize)
+	if len(palette) != 2 {
+		t.Fatalf("got %d colors %+v, want 2", len(palette), palette)
+	}
+	if palette[0].Hex != "#dc1e1e" || math.Abs(palette[0].Weight-0.7) > 0.02 {
+		t.Errorf("first color = %s (%.3f), want #dc1e1e (0.7)", palette[0].Hex, palette[0].Weight)
+	}
+	if palette[1].Hex != "#1428c8" || math.Abs(palette[1].Weight-0.3) > 0.02 {
+		t.Errorf("second color = %s (%.3f), want #1428c8 (0.3)", palette[1].Hex, palette[1].Weight)
+	}
+
+	want := entity.LabFromRGB(220, 30, 30)
+	if got := (entity.LabColor{L: palette[0].L, A: palette[0].A, B: palette[0].B}); got.Distance(want) > 0.01 {
+		t.Errorf("first color Lab = %+v, want %+v", got, want)
+	}
+}
+
+func TestDominantColorsOfSolidImage(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	for i := range img.Pix {
+		img.Pix[i] = 128
+	}
+
+	palette := dominantColors(img, PaletteSize)
+	if len(palette) != 1 || palette[0].Hex != "#808080" || palette[0].Weight != 1 {
+		t.Errorf("dominantColors() = %+v, want a single #808080 with weight 1", palette)
+	}
+}
+
+func TestLabFromRGB(t *testing.T) {
+	tests := []struct {
+		name    string
+		r, g, b uint8
+		want    entity.LabColor
+	}{
+		{name: "white", r: 255, g: 255, b: 255, want: entity.LabColor{L: 100, A: 0, B: 0}},
+		{name: "black", r: 0, g: 0, b: 0, want: entity.LabColor{L: 0, A: 0, B: 0}},
+		{name: "red", r: 255, g: 0, b: 0, want: entity.LabColor{L: 53.24, A: 80.09, B: 67.20}},
+		{name: "blue", r: 0, g: 0, b: 255, want: entity.LabColor{L: 32.30, A: 79.19, B: -107.86}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := entity.LabFromRGB(tt.r, tt.g, tt.b); got.Distance(tt.want) > 0.1 {
+				t.Errorf("LabFromRGB() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+// ContentSniffLength is how many leading bytes of an uploaded object are read to detect its real format
+const ContentSniffLength = 512
+
+// compatibleContentTypes lists the sniffed formats accepted for each declared content type.
+// Containers of the same family are interchangeable: cameras write MP4, MOV and 3GP brands for the same files,
+// and WebM is a Matroska profile.
+var compatibleContentTypes = map[string][]string{
+	"image/jpeg":       {"image/jpeg"},
+	"image/png":        {"image/png"},
+	"image/gif":        {"image/gif"},
+	"image/webp":       {"image/webp"},
+	"video/mp4":        {"video/mp4", "video/quicktime", "video/3gpp"},
+	"video/mov":        {"video/mp4", "video/quicktime", "video/3gpp"},
+	"video/quicktime":  {"video/mp4", "video/quicktime", "video/3gpp"},
+	"video/3gpp":       {"video/mp4", "video/quicktime", "video/3gpp"},
+	"video/webm":       {"video/webm", "video/x-matroska"},
+	"video/x-matroska": {"video/webm", "video/x-matroska"},
+	"video/avi":        {"video/x-msvideo"},
+	"video/x-msvideo":  {"video/x-msvideo"},
+	"video/mpeg":       {"video/mpeg"},
+}
+
+// sniffContentType detects the format of a file from its leading bytes.
+// Returns "" when the signature is not one of the supported image or video formats.
+func sniffContentType(header []byte) string {
+	switch {
+	case bytes.HasPrefix(header, []byte{0xFF, 0xD8, 0xFF}):
+		return "image/jpeg"
+	case bytes.HasPrefix(header, []byte("\x89PNG\r\n\x1a\n")):
+		return "image/png"
+	case bytes.HasPrefix(header, []byte("GIF87a")) || bytes.HasPrefix(header, []byte("GIF89a")):
+		return "image/gif"
+	case isRIFF(header, "WEBP"):
+		return "image/webp"
+	case isRIFF(header, "AVI "):
+		return "video/x-msvideo"
+	case bytes.HasPrefix(header, []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return sniffMatroska(header)
+	case bytes.HasPrefix(header, []byte{0x00, 0x00, 0x01, 0xBA}) || bytes.HasPrefix(header, []byte{0x00, 0x00, 0x01, 0xB3}):
+		return "video/mpeg"
+	}
+	return sniffBMFF(header)
+}
+
+func isRIFF(header []byte, format string) bool {
+	return len(header) >= 12 && string(header[:4]) == "RIFF" && string(header[8:12]) == format
+}
+
+// sniffMatroska tells WebM from other Matroska files by the DocType of the EBML header
+func sniffMatroska(header []byte) string {
+	elements := ebmlChildren(header)
+	if len(elements) == 0 || elements[0].ID != ebmlIDHeader {
+		// Header larger than the sniffed bytes - still an EBML file
+		return "video/x-matroska"
+	}
+	for _, element := range ebmlChildren(elements[0].Payload) {
+		if element.ID == ebmlIDDocType && strings.TrimRight(string(element.Payload), "\x00") == "webm" {
+			return "video/webm"
+		}
+	}
+	return "video/x-matroska"
+}
+
+// sniffBMFF recognises ISO base media files (MP4/MOV/3GP) by their first box.
+// The ftyp major brand distinguishes the variants; QuickTime files from before ftyp start with a movie atom.
+func sniffBMFF(header []byte) string {
+	if len(header) < 8 {
+		return ""
+	}
+	boxSize := binary.BigEndian.Uint32(header)
+	switch string(header[4:8]) {
+	case "ftyp":
+		if boxSize < 16 || len(header) < 12 {
+			return ""
+		}
+		brand := string(header[8:12])
+		switch {
+		case brand == "qt  ":
+			return "video/quicktime"
+		case strings.HasPrefix(brand, "3gp") || strings.HasPrefix(brand, "3g2"):
+			return "video/3gpp"
+		}
+		return "video/mp4"
+	case "moov", "mdat", "wide", "free", "skip", "pnot":
+		if boxSize != 0 && boxSize < 8 {
+			return ""
+		}
+		return "video/quicktime"
+	}
+	return ""
+}
+
+// contentMatchesDeclared reports whether the sniffed format is acceptable for the declared content type
+func contentMatchesDeclared(declared, sniffed string) bool {
+	if sniffed == "" {
+		return false
+	}
+	for _, contentType := range compatibleContentTypes[normalizeContentType(declared)] {
+		if contentType == sniffed {
+			return true
+		}
+	}
+	return false
+}
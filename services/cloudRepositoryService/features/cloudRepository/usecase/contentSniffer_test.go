@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestSniffContentType(t *testing.T) {
+	mkv := append(ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("matroska"))), ebml(ebmlIDSegment, make([]byte, 600))...)
+
+	tests := []struct {
+		name   string
+		header []byte
+		want   string
+	}{
+		{"jpeg", []byte("\xFF\xD8\xFF\xE0\x00\x10JFIF"), "image/jpeg"},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"), "image/png"},
+		{"gif", []byte("GIF89a\x01\x00\x01\x00"), "image/gif"},
+		{"webp", []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"avi", []byte("RIFF\x24\x00\x00\x00AVI LIST"), "video/x-msvideo"},
+		{"mp4", box("ftyp", []byte("isom\x00\x00\x02\x00isomavc1")), "video/mp4"},
+		{"mov", box("ftyp", []byte("qt  \x00\x00\x02\x00qt  ")), "video/quicktime"},
+		{"legacy mov", box("moov", make([]byte, 16)), "video/quicktime"},
+		{"3gp", box("ftyp", []byte("3gp4\x00\x00\x02\x003gp4isom")), "video/3gpp"},
+		{"webm", ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("webm"))), "video/webm"},
+		{"mkv truncated", mkv[:ContentSniffLength], "video/x-matroska"},
+		{"mpeg", []byte{0x00, 0x00, 0x01, 0xBA, 0x44}, "video/mpeg"},
+		{"html", []byte("<!DOCTYPE html><html>"), ""},
+		{"elf", []byte("\x7fELF\x02\x01\x01\x00"), ""},
+		{"empty", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sniffContentType(tt.header); got != tt.want {
+				t.Errorf("sniffContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContentMatchesDeclared(t *testing.T) {
+	tests := []struct {
+		declared string
+		sniffed  string
+		want     bool
+	}{
+		{"image/jpeg", "image/jpeg", true},
+		{"image/jpeg", "image/png", false},
+		{"image/png", "video/mp4", false},
+		{"video/mp4", "video/quicktime", true},
+		{"video/3gpp", "video/mp4", true},
+		{"video/x-matroska", "video/webm", true},
+		{"video/avi", "video/x-msvideo", true},
+		{"video/webm", "video/mp4", false},
+		{"Video/MP4; codecs=avc1", "video/mp4", true},
+		{"image/jpeg", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := contentMatchesDeclared(tt.declared, tt.sniffed); got != tt.want {
+			t.Errorf("contentMatchesDeclared(%q, %q) = %v, want %v", tt.declared, tt.sniffed, got, tt.want)
+		}
+	}
+}
+
+func TestVerifyUploadedContentRejectsDisguisedFile(t *testing.T) {
+	file := &entity.CloudFile{FileType: entity.FileTypeImage, ContentType: "image/png"}
+	if err := verifyUploadedContent(file, bytes.Repeat([]byte("<script>"), 8)); err == nil {
+		t.Error("verifyUploadedContent() error = nil, want verification failure")
+	}
+	if err := verifyUploadedContent(file, []byte("\x89PNG\r\n\x1a\n")); err != nil {
+		t.Errorf("verifyUploadedContent() error = %v", err)
+	}
+}
@@ -5,34 +5,38 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 )
 
 type DeleteCloudRepositoryUseCase struct {
 	Repo           _interface.IDeleteCloudRepositoryRepository
+	Authorizer     _interface.IFileAuthorizer
 	ContextTimeout time.Duration
 }
 
-func NewDeleteCloudRepositoryUseCase(repo _interface.IDeleteCloudRepositoryRepository, timeout time.Duration) _interface.IDeleteCloudRepositoryUseCase {
+func NewDeleteCloudRepositoryUseCase(repo _interface.IDeleteCloudRepositoryRepository, authorizer _interface.IFileAuthorizer, timeout time.Duration) _interface.IDeleteCloudRepositoryUseCase {
 	return &DeleteCloudRepositoryUseCase{
 		Repo:           repo,
+		Authorizer:     authorizer,
 		ContextTimeout: timeout,
 	}
 }
 
-// DeleteFile soft deletes a file and removes it from S3
+// DeleteFile moves a file to the trash.
+// Its objects are kept so it can be restored until the trash purge removes it.
 func (u *DeleteCloudRepositoryUseCase) DeleteFile(c context.Context, userID, fileID uint) error {
 	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
 	defer cancel()
 	// Get file from database
 	file, err := u.Repo.GetFileByID(ctx, fileID)
 	if err != nil {
-		return fmt.Errorf("file not found: %w", err)
+		return lookupError("file", err)
 	}
 
-	// Check if user owns the file
-	if file.UserID != userID {
-		return fmt.Errorf("unauthorized access to file")
+	// Only the owner can delete a file, whatever was granted to others
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionOwner); err != nil {
+		return err
 	}
 
 	// Soft delete from database
@@ -40,12 +44,5 @@ func (u *DeleteCloudRepositoryUseCase) DeleteFile(c context.Context, userID, fil
 		return fmt.Errorf("failed to delete file: %w", err)
 	}
 
-	// Delete from S3 (optional: can be done asynchronously)
-	if err := u.Repo.DeleteFromS3(ctx, file.S3Key); err != nil {
-		// Log error but don't fail the operation
-		// In production, you might want to queue this for retry
-		fmt.Printf("Warning: failed to delete file from S3: %v\n", err)
-	}
-
 	return nil
 }
@@ -2,24 +2,30 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
 )
 
 type DownloadCloudRepositoryUseCase struct {
 	Repo           _interface.IDownloadCloudRepositoryRepository
 	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	Authorizer     _interface.IFileAuthorizer
 	ContextTimeout time.Duration
 }
 
-func NewDownloadCloudRepositoryUseCase(repo _interface.IDownloadCloudRepositoryRepository, statsRepo _interface.IUserStatsCloudRepositoryRepository, timeout time.Duration) _interface.IDownloadCloudRepositoryUseCase {
+func NewDownloadCloudRepositoryUseCase(repo _interface.IDownloadCloudRepositoryRepository, statsRepo _interface.IUserStatsCloudRepositoryRepository, authorizer _interface.IFileAuthorizer, timeout time.Duration) _interface.IDownloadCloudRepositoryUseCase {
 	return &DownloadCloudRepositoryUseCase{
 		Repo:           repo,
 		StatsRepo:      statsRepo,
+		Authorizer:     authorizer,
 		ContextTimeout: timeout,
 	}
 }
@@ -29,12 +35,12 @@ func (u *DownloadCloudRepositoryUseCase) RequestDownloadURL(ctx context.Context,
 	// Get file from database
 	file, err := u.Repo.GetFileByID(ctx, fileID)
 	if err != nil {
-		return nil, fmt.Errorf("file not found: %w", err)
+		return nil, lookupError("file", err)
 	}
 
-	// Check if user owns the file
-	if file.UserID != userID {
-		return nil, fmt.Errorf("unauthorized access to file")
+	// Check if user owns the file or was granted download access
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionDownload); err != nil {
+		return nil, err
 	}
 
 	// Log download activity
@@ -59,3 +65,45 @@ func (u *DownloadCloudRepositoryUseCase) RequestDownloadURL(ctx context.Context,
 		ExpiresIn:   int(time.Hour.Seconds()),
 	}, nil
 }
+
+// RequestThumbnailURL generates a presigned URL for a server-generated thumbnail
+func (u *DownloadCloudRepositoryUseCase) RequestThumbnailURL(c context.Context, userID, fileID uint, req *request.ThumbnailRequestDTO) (*response.ThumbnailResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+
+	// Thumbnails only need view access
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionView); err != nil {
+		return nil, err
+	}
+
+	size := req.Size
+	if size == 0 {
+		size = ThumbnailSizes[0]
+	}
+
+	thumbnail, err := u.Repo.GetThumbnail(ctx, file.ID, size)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, sharedErrors.NotFound(fmt.Sprintf("thumbnail not found (status: %s)", file.ThumbnailStatus))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thumbnail: %w", err)
+	}
+
+	thumbnailURL, err := u.Repo.GeneratePresignedDownloadURL(ctx, thumbnail.S3Key, 1*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate thumbnail URL: %w", err)
+	}
+
+	return &response.ThumbnailResponseDTO{
+		ThumbnailURL: thumbnailURL,
+		Size:         thumbnail.Size,
+		Width:        thumbnail.Width,
+		Height:       thumbnail.Height,
+		ExpiresIn:    int(time.Hour.Seconds()),
+	}, nil
+}
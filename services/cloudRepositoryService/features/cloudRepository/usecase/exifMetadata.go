@@ -0,0 +1,392 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// EXIF/TIFF tags used for metadata extraction
+const (
+	exifTagMake             = 0x010F
+	exifTagModel            = 0x0110
+	exifTagOrientation      = 0x0112
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagGPSIFD           = 0x8825
+	exifTagExposureTime     = 0x829A
+	exifTagFNumber          = 0x829D
+	exifTagISO              = 0x8827
+	exifTagDateTimeOriginal = 0x9003
+	exifTagOffsetOriginal   = 0x9011
+	exifTagFocalLength      = 0x920A
+	exifTagLensMake         = 0xA433
+	exifTagLensModel        = 0xA434
+	gpsTagLatitudeRef       = 0x0001
+	gpsTagLatitude          = 0x0002
+	gpsTagLongitudeRef      = 0x0003
+	gpsTagLongitude         = 0x0004
+	gpsTagAltitudeRef       = 0x0005
+	gpsTagAltitude          = 0x0006
+)
+
+// exifDateTimeLayout is the EXIF date format; the time zone is stored separately (OffsetTimeOriginal)
+const exifDateTimeLayout = "2006:01:02 15:04:05"
+
+// errNoExif is returned when an image carries no EXIF block
+var errNoExif = errors.New("no EXIF data")
+
+// exifTypeSizes maps TIFF field types to their size in bytes
+var exifTypeSizes = map[uint16]uint32{
+	1:  1, // BYTE
+	2:  1, // ASCII
+	3:  2, // SHORT
+	4:  4, // LONG
+	5:  8, // RATIONAL
+	7:  1, // UNDEFINED
+	9:  4, // SLONG
+	10: 8, // SRATIONAL
+}
+
+// exifMetadata is the information extracted from an image's EXIF block
+type exifMetadata struct {
+	TakenAt      *time.Time
+	CameraMake   string
+	CameraModel  string
+	LensModel    string
+	ExposureTime *float64 // seconds
+	FNumber      *float64
+	ISO          *int
+	FocalLength  *float64 // millimeters
+	Orientation  int      // EXIF orientation 1-8, 0 if absent
+	Latitude     *float64
+	Longitude    *float64
+	Altitude     *float64 // meters above sea level
+}
+
+// exifEntry is a single IFD entry with its value bytes resolved
+type exifEntry struct {
+	Type  uint16
+	Count uint32
+	Value []byte
+}
+
+// extractExifMetadata locates the EXIF block of a JPEG, PNG or WebP image and parses it
+func extractExifMetadata(data []byte) (*exifMetadata, error) {
+	var tiff []byte
+	var err error
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8}):
+		tiff, err = findJPEGExif(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		tiff, err = findPNGExif(data)
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		tiff, err = findWebPExif(data)
+	default:
+		return nil, errNoExif
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseExif(tiff)
+}
+
+// findJPEGExif returns the TIFF data of the APP1 Exif segment
+func findJPEGExif(data []byte) ([]byte, error) {
+	for offset := 2; offset+4 <= len(data); {
+		if data[offset] != 0xFF {
+			return nil, fmt.Errorf("invalid JPEG marker at %d", offset)
+		}
+		marker := data[offset+1]
+		switch {
+		case marker == 0xFF: // Fill byte
+			offset++
+			continue
+		case marker == 0xD8 || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01:
+			offset += 2
+			continue
+		case marker == 0xDA || marker == 0xD9: // Start of scan / end of image: no more metadata
+			return nil, errNoExif
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		if length < 2 || offset+2+length > len(data) {
+			return nil, fmt.Errorf("invalid JPEG segment length at %d", offset)
+		}
+		segment := data[offset+4 : offset+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+		offset += 2 + length
+	}
+	return nil, errNoExif
+}
+
+// findPNGExif returns the data of the eXIf chunk
+func findPNGExif(data []byte) ([]byte, error) {
+	for offset := 8; offset+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[offset:]))
+		chunkType := string(data[offset+4 : offset+8])
+		end := offset + 12 + length // Header, data and CRC
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("invalid PNG chunk length at %d", offset)
+		}
+
+		switch chunkType {
+		case "eXIf":
+			return data[offset+8 : offset+8+length], nil
+		case "IEND":
+			return nil, errNoExif
+		}
+		offset = end
+	}
+	return nil, errNoExif
+}
+
+// findWebPExif returns the data of the EXIF chunk of an extended WebP file
+func findWebPExif(data []byte) ([]byte, error) {
+	for offset := 12; offset+8 <= len(data); {
+		chunkType := string(data[offset : offset+4])
+		length := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		if length < 0 || offset+8+length > len(data) {
+			return nil, fmt.Errorf("invalid WebP chunk length at %d", offset)
+		}
+
+		if chunkType == "EXIF" {
+			// Some writers keep the JPEG "Exif\0\0" prefix
+			return bytes.TrimPrefix(data[offset+8:offset+8+length], []byte("Exif\x00\x00")), nil
+		}
+		offset += 8 + length + length%2 // Chunks are padded to an even size
+	}
+	return nil, errNoExif
+}
+
+// parseExif reads IFD0, the Exif sub-IFD and the GPS sub-IFD of a TIFF structure
+func parseExif(tiff []byte) (*exifMetadata, error) {
+	if len(tiff) < 8 {
+		return nil, fmt.Errorf("truncated EXIF header")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("invalid EXIF byte order")
+	}
+	if order.Uint16(tiff[2:4]) != 42 {
+		return nil, fmt.Errorf("invalid EXIF header")
+	}
+
+	ifd0, err := readIFD(tiff, order, order.Uint32(tiff[4:8]))
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &exifMetadata{
+		CameraMake:  exifString(ifd0[exifTagMake]),
+		CameraModel: exifString(ifd0[exifTagModel]),
+	}
+	if orientation, ok := exifUint(ifd0[exifTagOrientation], order); ok && orientation >= 1 && orientation <= 8 {
+		meta.Orientation = int(orientation)
+	}
+
+	takenAt := exifString(ifd0[exifTagDateTime])
+	offsetTime := ""
+	if pointer, ok := exifUint(ifd0[exifTagExifIFD], order); ok {
+		exifIFD, err := readIFD(tiff, order, pointer)
+		if err != nil {
+			return nil, err
+		}
+
+		if original := exifString(exifIFD[exifTagDateTimeOriginal]); original != "" {
+			takenAt = original
+			offsetTime = exifString(exifIFD[exifTagOffsetOriginal])
+		}
+		meta.ExposureTime = exifRational(exifIFD[exifTagExposureTime], order)
+		meta.FNumber = exifRational(exifIFD[exifTagFNumber], order)
+		meta.FocalLength = exifRational(exifIFD[exifTagFocalLength], order)
+		if iso, ok := exifUint(exifIFD[exifTagISO], order); ok && iso > 0 {
+			value := int(iso)
+			meta.ISO = &value
+		}
+
+		meta.LensModel = exifString(exifIFD[exifTagLensModel])
+		if lensMake := exifString(exifIFD[exifTagLensMake]); lensMake != "" && meta.LensModel != "" && !strings.HasPrefix(meta.LensModel, lensMake) {
+			meta.LensModel = lensMake + " " + meta.LensModel
+		}
+	}
+	meta.TakenAt = parseExifDateTime(takenAt, offsetTime)
+
+	if pointer, ok := exifUint(ifd0[exifTagGPSIFD], order); ok {
+		gpsIFD, err := readIFD(tiff, order, pointer)
+		if err != nil {
+			return nil, err
+		}
+		meta.Latitude, meta.Longitude, meta.Altitude = parseGPS(gpsIFD, order)
+	}
+
+	return meta, nil
+}
+
+// readIFD reads the entries of the IFD at offset, resolving values stored outside the entry
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32) (map[uint16]exifEntry, error) {
+	if uint64(offset)+2 > uint64(len(tiff)) {
+		return nil, fmt.Errorf("invalid IFD offset %d", offset)
+	}
+	count := int(order.Uint16(tiff[offset:]))
+	start := int(offset) + 2
+	if start+count*12 > len(tiff) {
+		return nil, fmt.Errorf("truncated IFD at %d", offset)
+	}
+
+	entries := make(map[uint16]exifEntry, count)
+	for i := 0; i < count; i++ {
+		raw := tiff[start+i*12 : start+(i+1)*12]
+		tag := order.Uint16(raw[0:2])
+		fieldType := order.Uint16(raw[2:4])
+		valueCount := order.Uint32(raw[4:8])
+
+		typeSize, ok := exifTypeSizes[fieldType]
+		if !ok {
+			continue
+		}
+		size := uint64(typeSize) * uint64(valueCount)
+
+		var value []byte
+		if size <= 4 {
+			value = raw[8 : 8+size]
+		} else {
+			valueOffset := uint64(order.Uint32(raw[8:12]))
+			if valueOffset+size > uint64(len(tiff)) {
+				continue // Skip a corrupt entry rather than the whole block
+			}
+			value = tiff[valueOffset : valueOffset+size]
+		}
+		entries[tag] = exifEntry{Type: fieldType, Count: valueCount, Value: value}
+	}
+	return entries, nil
+}
+
+func exifString(entry exifEntry) string {
+	if entry.Type != 2 {
+		return ""
+	}
+	value := entry.Value
+	if i := bytes.IndexByte(value, 0); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(string(value))
+}
+
+// exifUint returns the first value of a BYTE, SHORT or LONG entry
+func exifUint(entry exifEntry, order binary.ByteOrder) (uint32, bool) {
+	switch {
+	case entry.Type == 1 && len(entry.Value) >= 1:
+		return uint32(entry.Value[0]), true
+	case entry.Type == 3 && len(entry.Value) >= 2:
+		return uint32(order.Uint16(entry.Value)), true
+	case entry.Type == 4 && len(entry.Value) >= 4:
+		return order.Uint32(entry.Value), true
+	}
+	return 0, false
+}
+
+// exifRationals returns the values of a RATIONAL or SRATIONAL entry
+func exifRationals(entry exifEntry, order binary.ByteOrder) []float64 {
+	if entry.Type != 5 && entry.Type != 10 {
+		return nil
+	}
+
+	values := make([]float64, 0, len(entry.Value)/8)
+	for i := 0; i+8 <= len(entry.Value); i += 8 {
+		numerator := order.Uint32(entry.Value[i:])
+		denominator := order.Uint32(entry.Value[i+4:])
+		if denominator == 0 {
+			return nil
+		}
+		if entry.Type == 10 {
+			values = append(values, float64(int32(numerator))/float64(int32(denominator)))
+		} else {
+			values = append(values, float64(numerator)/float64(denominator))
+		}
+	}
+	return values
+}
+
+func exifRational(entry exifEntry, order binary.ByteOrder) *float64 {
+	values := exifRationals(entry, order)
+	if len(values) == 0 || values[0] <= 0 || math.IsInf(values[0], 0) {
+		return nil
+	}
+	return &values[0]
+}
+
+// parseExifDateTime parses an EXIF date, applying the recorded UTC offset when present.
+// Without an offset the camera's wall clock time is kept as UTC.
+func parseExifDateTime(value, offset string) *time.Time {
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return nil
+	}
+
+	location := time.UTC
+	if offset != "" {
+		if t, err := time.Parse("-07:00", offset); err == nil {
+			location = t.Location()
+		}
+	}
+
+	t, err := time.ParseInLocation(exifDateTimeLayout, value, location)
+	if err != nil {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
+// parseGPS converts the GPS IFD coordinates to signed decimal degrees
+func parseGPS(gps map[uint16]exifEntry, order binary.ByteOrder) (*float64, *float64, *float64) {
+	latitude := gpsCoordinate(gps[gpsTagLatitude], exifString(gps[gpsTagLatitudeRef]), "S", order)
+	longitude := gpsCoordinate(gps[gpsTagLongitude], exifString(gps[gpsTagLongitudeRef]), "W", order)
+	if latitude == nil || longitude == nil || math.Abs(*latitude) > 90 || math.Abs(*longitude) > 180 {
+		return nil, nil, nil
+	}
+
+	var altitude *float64
+	if values := exifRationals(gps[gpsTagAltitude], order); len(values) > 0 {
+		value := values[0]
+		if ref, ok := exifUint(gps[gpsTagAltitudeRef], order); ok && ref == 1 {
+			value = -value // Below sea level
+		}
+		altitude = &value
+	}
+	return latitude, longitude, altitude
+}
+
+func gpsCoordinate(entry exifEntry, ref, negativeRef string, order binary.ByteOrder) *float64 {
+	values := exifRationals(entry, order)
+	if len(values) < 3 {
+		return nil
+	}
+
+	degrees := values[0] + values[1]/60 + values[2]/3600
+	if strings.EqualFold(ref, negativeRef) {
+		degrees = -degrees
+	}
+	return &degrees
+}
+
+// truncateString shortens s to at most max runes so it fits its column
+func truncateString(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
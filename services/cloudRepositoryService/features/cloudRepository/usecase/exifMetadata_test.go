@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type tiffByteOrder interface {
+	binary.ByteOrder
+	binary.AppendByteOrder
+}
+
+type tiffEntry struct {
+	tag   uint16
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+func asciiEntry(tag uint16, value string) tiffEntry {
+	return tiffEntry{tag: tag, typ: 2, count: uint32(len(value) + 1), value: append([]byte(value), 0)}
+}
+
+func shortEntry(order tiffByteOrder, tag, value uint16) tiffEntry {
+	return tiffEntry{tag: tag, typ: 3, count: 1, value: order.AppendUint16(nil, value)}
+}
+
+func longEntry(order tiffByteOrder, tag uint16, value uint32) tiffEntry {
+	return tiffEntry{tag: tag, typ: 4, count: 1, value: order.AppendUint32(nil, value)}
+}
+
+func rationalEntry(order tiffByteOrder, tag uint16, values ...uint32) tiffEntry {
+	var value []byte
+	for i := 0; i < len(values); i += 2 {
+		value = order.AppendUint32(value, values[i])
+		value = order.AppendUint32(value, values[i+1])
+	}
+	return tiffEntry{tag: tag, typ: 5, count: uint32(len(values) / 2), value: value}
+}
+
+// tiffIFD encodes an IFD located at base followed by its out-of-line values
+func tiffIFD(order tiffByteOrder, base int, entries []tiffEntry) []byte {
+	ifd := order.AppendUint16(nil, uint16(len(entries)))
+	dataOffset := base + 2 + 12*len(entries) + 4
+	var data []byte
+	for _, entry := range entries {
+		ifd = order.AppendUint16(ifd, entry.tag)
+		ifd = order.AppendUint16(ifd, entry.typ)
+		ifd = order.AppendUint32(ifd, entry.count)
+		if len(entry.value) <= 4 {
+			ifd = append(ifd, append(entry.value, make([]byte, 4-len(entry.value))...)...)
+			continue
+		}
+		ifd = order.AppendUint32(ifd, uint32(dataOffset+len(data)))
+		data = append(data, entry.value...)
+	}
+	ifd = order.AppendUint32(ifd, 0) // No next IFD
+	return append(ifd, data...)
+}
+
+// buildTIFF lays out the Exif and GPS sub-IFDs first so IFD0 can point at them
+func buildTIFF(order tiffByteOrder, ifd0, exifIFD, gpsIFD []tiffEntry) []byte {
+	tiff := []byte("MM")
+	if order == binary.LittleEndian {
+		tiff = []byte("II")
+	}
+	tiff = order.AppendUint16(tiff, 42)
+	tiff = order.AppendUint32(tiff, 0) // IFD0 offset, patched below
+
+	if exifIFD != nil {
+		ifd0 = append(ifd0, longEntry(order, exifTagExifIFD, uint32(len(tiff))))
+		tiff = append(tiff, tiffIFD(order, len(tiff), exifIFD)...)
+	}
+	if gpsIFD != nil {
+		ifd0 = append(ifd0, longEntry(order, exifTagGPSIFD, uint32(len(tiff))))
+		tiff = append(tiff, tiffIFD(order, len(tiff), gpsIFD)...)
+	}
+
+	order.PutUint32(tiff[4:8], uint32(len(tiff)))
+	return append(tiff, tiffIFD(order, len(tiff), ifd0)...)
+}
+
+func jpegWithExif(tiff []byte) []byte {
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	out := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x04, 0x00, 0x00} // SOI and a short APP0
+	out = append(out, 0xFF, 0xE1)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(segment)+2))
+	out = append(out, segment...)
+	return append(out, 0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9)
+}
+
+func pngWithExif(tiff []byte) []byte {
+	out := []byte("\x89PNG\r\n\x1a\n")
+	for _, chunk := range []struct {
+		typ  string
+		data []byte
+	}{{"IHDR", make([]byte, 13)}, {"eXIf", tiff}, {"IEND", nil}} {
+		out = binary.BigEndian.AppendUint32(out, uint32(len(chunk.data)))
+		out = append(out, chunk.typ...)
+		out = append(out, chunk.data...)
+		out = append(out, 0, 0, 0, 0) // CRC is not checked
+	}
+	return out
+}
+
+func webpWithExif(tiff []byte) []byte {
+	body := []byte("WEBP")
+	for _, chunk := range []struct {
+		typ  string
+		data []byte
+	}{{"VP8X", make([]byte, 10)}, {"VP8 ", make([]byte, 5)}, {"EXIF", append([]byte("Exif\x00\x00"), tiff...)}} {
+		body = append(body, chunk.typ...)
+		body = binary.LittleEndian.AppendUint32(body, uint32(len(chunk.data)))
+		body = append(body, chunk.data...)
+		if len(chunk.data)%2 == 1 {
+			body = append(body, 0)
+		}
+	}
+	out := append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	return append(out, body...)
+}
+
+func floatEquals(got *float64, want float64) bool {
+	return got != nil && math.Abs(*got-want) < 1e-6
+}
+
+func TestExtractExifMetadata(t *testing.T) {
+	var be tiffByteOrder = binary.BigEndian
+	full := buildTIFF(be,
+		[]tiffEntry{asciiEntry(exifTagMake, "Canon"), asciiEntry(exifTagModel, "Canon EOS R5"), shortEntry(be, exifTagOrientation, 6), asciiEntry(exifTagDateTime, "2024:01:01 00:00:00")},
+		[]tiffEntry{
+			rationalEntry(be, exifTagExposureTime, 1, 250),
+			rationalEntry(be, exifTagFNumber, 28, 10),
+			shortEntry(be, exifTagISO, 400),
+			asciiEntry(exifTagDateTimeOriginal, "2015:07:04 18:30:15"),
+			asciiEntry(exifTagOffsetOriginal, "+09:00"),
+			rationalEntry(be, exifTagFocalLength, 50, 1),
+			asciiEntry(exifTagLensMake, "Canon"),
+			asciiEntry(exifTagLensModel, "RF24-70mm F2.8 L IS USM"),
+		},
+		[]tiffEntry{
+			asciiEntry(gpsTagLatitudeRef, "N"),
+			rationalEntry(be, gpsTagLatitude, 37, 1, 33, 1, 3600, 100),
+			asciiEntry(gpsTagLongitudeRef, "E"),
+			rationalEntry(be, gpsTagLongitude, 126, 1, 58, 1, 4800, 100),
+			{tag: gpsTagAltitudeRef, typ: 1, count: 1, value: []byte{0}},
+			rationalEntry(be, gpsTagAltitude, 385, 10),
+		},
+	)
+
+	var le tiffByteOrder = binary.LittleEndian
+	minimal := buildTIFF(le,
+		[]tiffEntry{asciiEntry(exifTagModel, "Pixel 8"), shortEntry(le, exifTagOrientation, 1), asciiEntry(exifTagDateTime, "2020:02:29 12:00:00")},
+		nil,
+		[]tiffEntry{
+			asciiEntry(gpsTagLatitudeRef, "S"),
+			rationalEntry(le, gpsTagLatitude, 33, 1, 52, 1, 0, 1),
+			asciiEntry(gpsTagLongitudeRef, "W"),
+			rationalEntry(le, gpsTagLongitude, 70, 1, 30, 1, 0, 1),
+		},
+	)
+
+	t.Run("jpeg with exif and gps sub-IFDs", func(t *testing.T) {
+		meta, err := extractExifMetadata(jpegWithExif(full))
+		if err != nil {
+			t.Fatalf("extractExifMetadata() error = %v", err)
+		}
+
+		wantTaken := time.Date(2015, 7, 4, 9, 30, 15, 0, time.UTC)
+		if meta.TakenAt == nil || !meta.TakenAt.Equal(wantTaken) {
+			t.Errorf("TakenAt = %v, want %v", meta.TakenAt, wantTaken)
+		}
+		if meta.CameraMake != "Canon" || meta.CameraModel != "Canon EOS R5" || meta.LensModel != "Canon RF24-70mm F2.8 L IS USM" {
+			t.Errorf("camera = %q %q %q", meta.CameraMake, meta.CameraModel, meta.LensModel)
+		}
+		if meta.Orientation != 6 || meta.ISO == nil || *meta.ISO != 400 {
+			t.Errorf("Orientation = %d, ISO = %v", meta.Orientation, meta.ISO)
+		}
+		if !floatEquals(meta.ExposureTime, 0.004) || !floatEquals(meta.FNumber, 2.8) || !floatEquals(meta.FocalLength, 50) {
+			t.Errorf("exposure = %v f/%v %vmm", meta.ExposureTime, meta.FNumber, meta.FocalLength)
+		}
+		if !floatEquals(meta.Latitude, 37.56) || !floatEquals(meta.Longitude, 126.98) || !floatEquals(meta.Altitude, 38.5) {
+			t.Errorf("gps = %v, %v, %v", meta.Latitude, meta.Longitude, meta.Altitude)
+		}
+	})
+
+	for name, image := range map[string][]byte{"png eXIf chunk": pngWithExif(minimal), "webp EXIF chunk": webpWithExif(minimal)} {
+		t.Run(name, func(t *testing.T) {
+			meta, err := extractExifMetadata(image)
+			if err != nil {
+				t.Fatalf("extractExifMetadata() error = %v", err)
+			}
+
+			wantTaken := time.Date(2020, 2, 29, 12, 0, 0, 0, time.UTC)
+			if meta.TakenAt == nil || !meta.TakenAt.Equal(wantTaken) {
+				t.Errorf("TakenAt = %v, want %v", meta.TakenAt, wantTaken)
+			}
+			if meta.CameraModel != "Pixel 8" || meta.Orientation != 1 || meta.ISO != nil {
+				t.Errorf("CameraModel = %q, Orientation = %d, ISO = %v", meta.CameraModel, meta.Orientation, meta.ISO)
+			}
+			if !floatEquals(meta.Latitude, -33.8666666) || !floatEquals(meta.Longitude, -70.5) || meta.Altitude != nil {
+				t.Errorf("gps = %v, %v, %v", meta.Latitude, meta.Longitude, meta.Altitude)
+			}
+		})
+	}
+
+	t.Run("jpeg without exif", func(t *testing.T) {
+		jpeg := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x04, 0x00, 0x00, 0xFF, 0xDA, 0x00, 0x02, 0xFF, 0xD9}
+		if _, err := extractExifMetadata(jpeg); !errors.Is(err, errNoExif) {
+			t.Errorf("extractExifMetadata() error = %v, want %v", err, errNoExif)
+		}
+	})
+
+	t.Run("corrupt exif", func(t *testing.T) {
+		if _, err := extractExifMetadata(jpegWithExif([]byte("MM\x00\x2a\xff\xff\xff\xff"))); err == nil || errors.Is(err, errNoExif) {
+			t.Errorf("extractExifMetadata() error = %v, want a parse error", err)
+		}
+	})
+}
+
+func TestParseExifDateTime(t *testing.T) {
+	tests := []struct {
+		value  string
+		offset string
+		want   *time.Time
+	}{
+		{value: "2019:12:31 23:59:59", offset: "-05:00", want: ptrTime(time.Date(2020, 1, 1, 4, 59, 59, 0, time.UTC))},
+		{value: "2019:12:31 23:59:59", want: ptrTime(time.Date(2019, 12, 31, 23, 59, 59, 0, time.UTC))},
+		{value: "0000:00:00 00:00:00"},
+		{value: "    :  :     :  :  "},
+		{value: ""},
+	}
+
+	for _, tt := range tests {
+		got := parseExifDateTime(tt.value, tt.offset)
+		if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+			t.Errorf("parseExifDateTime(%q, %q) = %v, want %v", tt.value, tt.offset, got, tt.want)
+		}
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestJPEGExifIgnoresImageData(t *testing.T) {
+	// A marker-like byte sequence after SOS must not be mistaken for an APP1 segment
+	jpeg := []byte{0xFF, 0xD8, 0xFF, 0xDA, 0x00, 0x02}
+	jpeg = append(jpeg, bytes.Repeat([]byte{0xFF, 0xE1}, 8)...)
+	if _, err := findJPEGExif(jpeg); !errors.Is(err, errNoExif) {
+		t.Errorf("findJPEGExif() error = %v, want %v", err, errNoExif)
+	}
+}
@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 )
 
 type FavoriteUseCase struct {
 	FavoriteRepo   _interface.IFavoriteRepository
 	FileRepo       _interface.IDownloadCloudRepositoryRepository // For file validation and ownership
 	ListRepo       _interface.IListCloudRepositoryRepository     // For presigned URL generation
+	Authorizer     _interface.IFileAuthorizer
 	ContextTimeout time.Duration
 }
 
@@ -21,17 +24,19 @@ func NewFavoriteUseCase(
 	favoriteRepo _interface.IFavoriteRepository,
 	fileRepo _interface.IDownloadCloudRepositoryRepository,
 	listRepo _interface.IListCloudRepositoryRepository,
+	authorizer _interface.IFileAuthorizer,
 	timeout time.Duration,
 ) _interface.IFavoriteUseCase {
 	return &FavoriteUseCase{
 		FavoriteRepo:   favoriteRepo,
 		FileRepo:       fileRepo,
 		ListRepo:       listRepo,
+		Authorizer:     authorizer,
 		ContextTimeout: timeout,
 	}
 }
 
-// AddFavorite validates file exists and user owns it, then adds to favorites
+// AddFavorite validates file exists and user can view it, then adds to favorites
 func (u *FavoriteUseCase) AddFavorite(c context.Context, userID, fileID uint) (*response.FavoriteResponseDTO, error) {
 	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
 	defer cancel()
@@ -39,12 +44,12 @@ func (u *FavoriteUseCase) AddFavorite(c context.Context, userID, fileID uint) (*
 	// Validate file exists
 	file, err := u.FileRepo.GetFileByID(ctx, fileID)
 	if err != nil {
-		return nil, fmt.Errorf("file not found")
+		return nil, sharedErrors.NotFound("file not found")
 	}
 
-	// Verify ownership
-	if file.UserID != userID {
-		return nil, fmt.Errorf("access denied: you do not own this file")
+	// Verify the user owns the file or was granted access to it
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionView); err != nil {
+		return nil, err
 	}
 
 	// Add favorite (idempotent - won't error if already favorited)
@@ -85,7 +90,7 @@ func (u *FavoriteUseCase) ListFavorites(c context.Context, userID uint, filter r
 	}
 
 	// Get favorited files
-	files, total, err := u.FavoriteRepo.GetFavoritesByUserID(ctx, userID, filter)
+	files, total, nextCursor, err := u.FavoriteRepo.GetFavoritesByUserID(ctx, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list favorites: %w", err)
 	}
@@ -102,11 +107,14 @@ func (u *FavoriteUseCase) ListFavorites(c context.Context, userID uint, filter r
 			}
 		}
 
-		// Generate presigned download URL
-		downloadURL, err := u.ListRepo.GeneratePresignedDownloadURL(ctx, file.S3Key, 1*time.Hour)
-		if err != nil {
-			// Log error but don't fail the entire request
-			downloadURL = ""
+		// Generate presigned download URL unless the file was shared with view permission only
+		downloadURL := ""
+		if u.canDownload(ctx, userID, &files[i]) {
+			downloadURL, err = u.ListRepo.GeneratePresignedDownloadURL(ctx, file.S3Key, 1*time.Hour)
+			if err != nil {
+				// Log error but don't fail the entire request
+				downloadURL = ""
+			}
 		}
 
 		// Generate presigned thumbnail URL if available
@@ -119,13 +127,25 @@ func (u *FavoriteUseCase) ListFavorites(c context.Context, userID uint, filter r
 			}
 		}
 
+		takenAt, exifDTO := toExifDTO(file.Exif)
+
 		fileInfos[i] = response.FileInfoDTO{
 			ID:           file.ID,
 			FileName:     file.FileName,
+			Description:  file.Description,
+			CustomFields: file.CustomFields,
 			FileType:     string(file.FileType),
 			ContentType:  file.ContentType,
 			FileSize:     file.FileSize,
 			Duration:     file.Duration,
+			Width:        file.Width,
+			Height:       file.Height,
+			VideoCodec:   file.VideoCodec,
+			Rotation:     file.Rotation,
+			TakenAt:      takenAt,
+			Exif:         exifDTO,
+			Quality:      toImageQualityDTO(&file),
+			Palette:      toPaletteDTO(file.Palette),
 			Tags:         tagDTOs,
 			DownloadURL:  downloadURL,
 			ThumbnailURL: thumbnailURL,
@@ -134,19 +154,28 @@ func (u *FavoriteUseCase) ListFavorites(c context.Context, userID uint, filter r
 		}
 	}
 
-	// Calculate total pages
-	totalPages := int(total) / filter.Size
-	if int(total)%filter.Size != 0 {
-		totalPages++
+	pagination := response.PaginationMeta{
+		Page:       filter.Page,
+		Size:       filter.Size,
+		NextCursor: nextCursor,
+	}
+	if !filter.SkipTotal {
+		// Calculate total pages
+		totalPages := int(total) / filter.Size
+		if int(total)%filter.Size != 0 {
+			totalPages++
+		}
+		pagination.Total = &total
+		pagination.TotalPages = &totalPages
 	}
 
 	return &response.ListFavoritesResponseDTO{
-		Data: fileInfos,
-		Pagination: response.PaginationMeta{
-			Total:      total,
-			Page:       filter.Page,
-			Size:       filter.Size,
-			TotalPages: totalPages,
-		},
+		Data:       fileInfos,
+		Pagination: pagination,
 	}, nil
 }
+
+// canDownload reports whether a favorited file may be downloaded by the user
+func (u *FavoriteUseCase) canDownload(ctx context.Context, userID uint, file *entity.CloudFile) bool {
+	return u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionDownload) == nil
+}
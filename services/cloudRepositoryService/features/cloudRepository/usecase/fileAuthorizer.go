@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+// FileAuthorizer is the single place that decides what a user may do with a file.
+// The owner has every permission; other users have what the owner granted them.
+type FileAuthorizer struct {
+	GrantRepo _interface.IFileGrantRepository
+}
+
+func NewFileAuthorizer(grantRepo _interface.IFileGrantRepository) _interface.IFileAuthorizer {
+	return &FileAuthorizer{
+		GrantRepo: grantRepo,
+	}
+}
+
+// Permission returns the user's permission on the file, or "" without access
+func (a *FileAuthorizer) Permission(ctx context.Context, userID uint, file *entity.CloudFile) (entity.FilePermission, error) {
+	if file.UserID == userID {
+		return entity.FilePermissionOwner, nil
+	}
+
+	grant, err := a.GrantRepo.GetGrant(ctx, file.ID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check file access: %w", err)
+	}
+	return grant.Permission, nil
+}
+
+// Authorize returns an access denied error unless the user has the required permission on the file
+func (a *FileAuthorizer) Authorize(ctx context.Context, userID uint, file *entity.CloudFile, required entity.FilePermission) error {
+	permission, err := a.Permission(ctx, userID, file)
+	if err != nil {
+		return err
+	}
+	if permission.Allows(required) {
+		return nil
+	}
+	if required == entity.FilePermissionOwner {
+		return sharedErrors.Forbidden("access denied: you do not own this file")
+	}
+	return sharedErrors.Forbidden(fmt.Sprintf("access denied: you do not have %s permission on this file", required))
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+type FileGrantUseCase struct {
+	GrantRepo      _interface.IFileGrantRepository
+	FileRepo       _interface.IDownloadCloudRepositoryRepository // For file validation
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewFileGrantUseCase(
+	grantRepo _interface.IFileGrantRepository,
+	fileRepo _interface.IDownloadCloudRepositoryRepository,
+	authorizer _interface.IFileAuthorizer,
+	timeout time.Duration,
+) _interface.IFileGrantUseCase {
+	return &FileGrantUseCase{
+		GrantRepo:      grantRepo,
+		FileRepo:       fileRepo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// GrantAccess gives another registered user access to a file, or changes the permission they already have
+func (u *FileGrantUseCase) GrantAccess(c context.Context, ownerID, fileID, granteeID uint, req request.GrantFileRequestDTO) (*response.FileGrantDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	permission := entity.FilePermission(req.Permission)
+	if !permission.IsGrantable() {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid permission: %s", req.Permission))
+	}
+	if granteeID == ownerID {
+		return nil, sharedErrors.BadRequest("invalid grantee: cannot grant access to yourself")
+	}
+
+	if err := u.checkOwner(ctx, ownerID, fileID); err != nil {
+		return nil, err
+	}
+
+	exists, err := u.GrantRepo.UserExists(ctx, granteeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user: %w", err)
+	}
+	if !exists {
+		return nil, sharedErrors.NotFound("user not found")
+	}
+
+	grant := &entity.FileGrant{
+		FileID:     fileID,
+		OwnerID:    ownerID,
+		GranteeID:  granteeID,
+		Permission: permission,
+	}
+	if err := u.GrantRepo.UpsertGrant(ctx, grant); err != nil {
+		return nil, fmt.Errorf("failed to grant access: %w", err)
+	}
+
+	dto := toFileGrantDTO(grant)
+	return &dto, nil
+}
+
+// RevokeAccess removes a user's access to a file
+func (u *FileGrantUseCase) RevokeAccess(c context.Context, ownerID, fileID, granteeID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if err := u.checkOwner(ctx, ownerID, fileID); err != nil {
+		return err
+	}
+
+	if err := u.GrantRepo.DeleteGrant(ctx, fileID, granteeID); err != nil {
+		return fmt.Errorf("failed to revoke access: %w", err)
+	}
+	return nil
+}
+
+// ListGrants lists the users that were granted access to a file
+func (u *FileGrantUseCase) ListGrants(c context.Context, ownerID, fileID uint) (*response.ListFileGrantsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if err := u.checkOwner(ctx, ownerID, fileID); err != nil {
+		return nil, err
+	}
+
+	grants, err := u.GrantRepo.GetFileGrants(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grants: %w", err)
+	}
+
+	grantDTOs := make([]response.FileGrantDTO, len(grants))
+	for i := range grants {
+		grantDTOs[i] = toFileGrantDTO(&grants[i])
+	}
+
+	return &response.ListFileGrantsResponseDTO{Grants: grantDTOs}, nil
+}
+
+// ListSharedWithMe lists the files other users granted the user access to, most recently shared first
+func (u *FileGrantUseCase) ListSharedWithMe(c context.Context, userID uint, req request.ListSharedWithMeRequestDTO) (*response.ListSharedWithMeResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	minimum := entity.FilePermission(req.Permission)
+	if minimum == "" {
+		minimum = entity.FilePermissionView
+	}
+	permissions := grantablePermissionsAllowing(minimum)
+	if len(permissions) == 0 {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid permission: %s", req.Permission))
+	}
+
+	grants, total, err := u.GrantRepo.GetSharedWithUser(ctx, userID, permissions, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared files: %w", err)
+	}
+
+	files := make([]response.SharedFileInfoDTO, 0, len(grants))
+	for _, grant := range grants {
+		file := grant.File
+		if file == nil {
+			continue
+		}
+
+		tagDTOs := make([]response.TagDTO, len(file.Tags))
+		for j, tag := range file.Tags {
+			tagDTOs[j] = response.TagDTO{
+				ID:   tag.ID,
+				Name: tag.Name,
+			}
+		}
+
+		// View permission only reveals the thumbnail
+		downloadURL := ""
+		if grant.Permission.Allows(entity.FilePermissionDownload) {
+			downloadURL, err = u.GrantRepo.GeneratePresignedDownloadURL(ctx, file.S3Key, 1*time.Hour)
+			if err != nil {
+				// Log error but don't fail the entire request
+				downloadURL = ""
+			}
+		}
+
+		thumbnailURL := ""
+		if file.ThumbnailKey != "" {
+			thumbnailURL, err = u.GrantRepo.GeneratePresignedDownloadURL(ctx, file.ThumbnailKey, 1*time.Hour)
+			if err != nil {
+				// Log error but don't fail the entire request
+				thumbnailURL = ""
+			}
+		}
+
+		takenAt, exifDTO := toExifDTO(file.Exif)
+
+		files = append(files, response.SharedFileInfoDTO{
+			FileInfoDTO: response.FileInfoDTO{
+				ID:           file.ID,
+				FileName:     file.FileName,
+				Description:  file.Description,
+				CustomFields: file.CustomFields,
+				FileType:     string(file.FileType),
+				ContentType:  file.ContentType,
+				FileSize:     file.FileSize,
+				Duration:     file.Duration,
+				Width:        file.Width,
+				Height:       file.Height,
+				VideoCodec:   file.VideoCodec,
+				Rotation:     file.Rotation,
+				TakenAt:      takenAt,
+				Exif:         exifDTO,
+				Quality:      toImageQualityDTO(file),
+				Palette:      toPaletteDTO(file.Palette),
+				Tags:         tagDTOs,
+				DownloadURL:  downloadURL,
+				ThumbnailURL: thumbnailURL,
+				CreatedAt:    file.CreatedAt.Format(time.RFC3339),
+				UpdatedAt:    file.UpdatedAt.Format(time.RFC3339),
+			},
+			OwnerID:    grant.OwnerID,
+			Permission: string(grant.Permission),
+			SharedAt:   grant.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &response.ListSharedWithMeResponseDTO{
+		Files:      files,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}, nil
+}
+
+// checkOwner verifies the file exists and belongs to the user; only owners manage access to a file
+func (u *FileGrantUseCase) checkOwner(ctx context.Context, ownerID, fileID uint) error {
+	file, err := u.FileRepo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return sharedErrors.NotFound("file not found")
+	}
+	return u.Authorizer.Authorize(ctx, ownerID, file, entity.FilePermissionOwner)
+}
+
+// grantablePermissionsAllowing returns the permissions that can be granted and include the minimum permission
+func grantablePermissionsAllowing(minimum entity.FilePermission) []entity.FilePermission {
+	var permissions []entity.FilePermission
+	for _, permission := range []entity.FilePermission{entity.FilePermissionView, entity.FilePermissionDownload, entity.FilePermissionEdit} {
+		if permission.Allows(minimum) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+func toFileGrantDTO(grant *entity.FileGrant) response.FileGrantDTO {
+	return response.FileGrantDTO{
+		FileID:     grant.FileID,
+		GranteeID:  grant.GranteeID,
+		Permission: string(grant.Permission),
+		CreatedAt:  grant.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  grant.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestFilePermissionAllows(t *testing.T) {
+	tests := []struct {
+		permission entity.FilePermission
+		required   entity.FilePermission
+		want       bool
+	}{
+		{entity.FilePermissionView, entity.FilePermissionView, true},
+		{entity.FilePermissionView, entity.FilePermissionDownload, false},
+		{entity.FilePermissionDownload, entity.FilePermissionView, true},
+		{entity.FilePermissionEdit, entity.FilePermissionDownload, true},
+		{entity.FilePermissionEdit, entity.FilePermissionOwner, false},
+		{entity.FilePermissionOwner, entity.FilePermissionEdit, true},
+		{"", entity.FilePermissionView, false},
+		{entity.FilePermissionOwner, "admin", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.permission.Allows(tt.required); got != tt.want {
+			t.Errorf("%q.Allows(%q) = %v, want %v", tt.permission, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestGrantablePermissionsAllowing(t *testing.T) {
+	tests := []struct {
+		minimum entity.FilePermission
+		want    []entity.FilePermission
+	}{
+		{entity.FilePermissionView, []entity.FilePermission{entity.FilePermissionView, entity.FilePermissionDownload, entity.FilePermissionEdit}},
+		{entity.FilePermissionDownload, []entity.FilePermission{entity.FilePermissionDownload, entity.FilePermissionEdit}},
+		{entity.FilePermissionEdit, []entity.FilePermission{entity.FilePermissionEdit}},
+		{entity.FilePermissionOwner, nil},
+		{"admin", nil},
+	}
+
+	for _, tt := range tests {
+		if got := grantablePermissionsAllowing(tt.minimum); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("grantablePermissionsAllowing(%q) = %v, want %v", tt.minimum, got, tt.want)
+		}
+	}
+}
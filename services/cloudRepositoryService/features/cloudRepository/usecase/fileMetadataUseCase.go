@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// MaxFileNameLength is the longest file name in characters
+	MaxFileNameLength = 255
+	// MaxCustomFields is how many custom fields a file can have
+	MaxCustomFields = 50
+	// MaxCustomFieldKeyLength is the longest custom field key in characters
+	MaxCustomFieldKeyLength = 64
+	// MaxCustomFieldValueLength is the longest custom field value in characters
+	MaxCustomFieldValueLength = 1000
+)
+
+type FileMetadataUseCase struct {
+	Repo           _interface.IFileMetadataRepository
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewFileMetadataUseCase(repo _interface.IFileMetadataRepository, authorizer _interface.IFileAuthorizer, timeout time.Duration) _interface.IFileMetadataUseCase {
+	return &FileMetadataUseCase{
+		Repo:           repo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// GetFile returns a file with the caller's permission and its ETag
+func (u *FileMetadataUseCase) GetFile(c context.Context, userID, fileID uint) (*response.FileDetailDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	permission, err := u.Authorizer.Permission(ctx, userID, file)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.Allows(entity.FilePermissionView) {
+		return nil, sharedErrors.Forbidden(fmt.Sprintf("access denied: you do not have %s permission on this file", entity.FilePermissionView))
+	}
+
+	return u.toFileDetailDTO(ctx, file, permission), nil
+}
+
+// UpdateFile renames a file and sets its description and custom fields.
+// The S3 key never changes; downloads use the new name. With ifMatch the update only applies
+// if the file still has that ETag, so concurrent edits are not lost.
+func (u *FileMetadataUseCase) UpdateFile(c context.Context, userID, fileID uint, ifMatch string, req *request.UpdateFileRequestDTO) (*response.FileDetailDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	var fileName, description string
+	if req.FileName != nil {
+		name, err := validateFileName(*req.FileName)
+		if err != nil {
+			return nil, err
+		}
+		fileName = name
+	}
+	if req.Description != nil {
+		description = strings.TrimSpace(*req.Description)
+	}
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	permission, err := u.Authorizer.Permission(ctx, userID, file)
+	if err != nil {
+		return nil, err
+	}
+	if !permission.Allows(entity.FilePermissionEdit) {
+		return nil, sharedErrors.Forbidden(fmt.Sprintf("access denied: you do not have %s permission on this file", entity.FilePermissionEdit))
+	}
+
+	// The ETag is checked again on the locked row, so an edit between the two reads is still detected
+	updated, err := u.Repo.UpdateFile(ctx, fileID, func(current *entity.CloudFile) error {
+		if ifMatch != "" && !etagMatches(ifMatch, fileETag(current)) {
+			return sharedErrors.PreconditionFailed("precondition failed: the file was changed by someone else, fetch it again before updating")
+		}
+		if req.FileName != nil {
+			current.FileName = fileName
+		}
+		if req.Description != nil {
+			current.Description = description
+		}
+		if req.CustomFields != nil {
+			fields, err := mergeCustomFields(current.CustomFields, req.CustomFields)
+			if err != nil {
+				return err
+			}
+			current.CustomFields = fields
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update file: %w", err)
+	}
+
+	return u.toFileDetailDTO(ctx, updated, permission), nil
+}
+
+func (u *FileMetadataUseCase) toFileDetailDTO(ctx context.Context, file *entity.CloudFile, permission entity.FilePermission) *response.FileDetailDTO {
+	tagDTOs := make([]response.TagDTO, len(file.Tags))
+	for i, tag := range file.Tags {
+		tagDTOs[i] = response.TagDTO{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+
+	// View permission only reveals the thumbnail
+	downloadURL := ""
+	if permission.Allows(entity.FilePermissionDownload) {
+		var err error
+		downloadURL, err = u.Repo.GeneratePresignedDownloadURL(ctx, file.S3Key, 1*time.Hour)
+		if err != nil {
+			// Log error but don't fail the entire request
+			downloadURL = ""
+		}
+	}
+
+	thumbnailURL := ""
+	if file.ThumbnailKey != "" {
+		var err error
+		thumbnailURL, err = u.Repo.GeneratePresignedDownloadURL(ctx, file.ThumbnailKey, 1*time.Hour)
+		if err != nil {
+			// Log error but don't fail the entire request
+			thumbnailURL = ""
+		}
+	}
+
+	takenAt, exifDTO := toExifDTO(file.Exif)
+
+	return &response.FileDetailDTO{
+		FileInfoDTO: response.FileInfoDTO{
+			ID:           file.ID,
+			FileName:     file.FileName,
+			Description:  file.Description,
+			CustomFields: file.CustomFields,
+			FileType:     string(file.FileType),
+			ContentType:  file.ContentType,
+			FileSize:     file.FileSize,
+			Duration:     file.Duration,
+			Width:        file.Width,
+			Height:       file.Height,
+			VideoCodec:   file.VideoCodec,
+			Rotation:     file.Rotation,
+			TakenAt:      takenAt,
+			Exif:         exifDTO,
+			Quality:      toImageQualityDTO(file),
+			Palette:      toPaletteDTO(file.Palette),
+			Tags:         tagDTOs,
+			DownloadURL:  downloadURL,
+			ThumbnailURL: thumbnailURL,
+			CreatedAt:    file.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:    file.UpdatedAt.Format(time.RFC3339),
+		},
+		Version:    file.Version,
+		Permission: string(permission),
+		ETag:       fileETag(file),
+	}
+}
+
+// fileETag derives the strong ETag of a file from its ID and last update time (second precision, as stored)
+func fileETag(file *entity.CloudFile) string {
+	return fmt.Sprintf(`"%d-%d"`, file.ID, file.UpdatedAt.Unix())
+}
+
+// etagMatches reports whether an If-Match header value ("*" or a comma-separated list of ETags) matches etag
+func etagMatches(ifMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// validateFileName trims a new file name and rejects empty names, path separators and control characters.
+// Quotes, semicolons and non-ASCII characters are allowed; download URLs encode them in Content-Disposition.
+func validateFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", sharedErrors.BadRequest("invalid file name: must not be empty")
+	}
+	if utf8.RuneCountInString(name) > MaxFileNameLength {
+		return "", sharedErrors.BadRequest(fmt.Sprintf("invalid file name: longer than %d characters", MaxFileNameLength))
+	}
+	if strings.ContainsAny(name, `/\`) || strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", sharedErrors.BadRequest("invalid file name: must not contain path separators or control characters")
+	}
+	return name, nil
+}
+
+// mergeCustomFields applies changes to a copy of the current custom fields: a value sets a field, nil removes it.
+// Returns nil when no fields remain.
+func mergeCustomFields(current entity.CustomFields, changes map[string]*string) (entity.CustomFields, error) {
+	merged := make(entity.CustomFields, len(current)+len(changes))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range changes {
+		if strings.TrimSpace(key) != key || key == "" || utf8.RuneCountInString(key) > MaxCustomFieldKeyLength {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid custom field key %q: must be 1-%d characters without surrounding spaces", key, MaxCustomFieldKeyLength))
+		}
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		if utf8.RuneCountInString(*value) > MaxCustomFieldValueLength {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid custom field %q: value longer than %d characters", key, MaxCustomFieldValueLength))
+		}
+		merged[key] = *value
+	}
+
+	if len(merged) > MaxCustomFields {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid custom fields: a file can have at most %d", MaxCustomFields))
+	}
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return merged, nil
+}
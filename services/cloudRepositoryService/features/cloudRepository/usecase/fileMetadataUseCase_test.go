@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestFileETag(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	file := &entity.CloudFile{ID: 7, UpdatedAt: updatedAt}
+	etag := fileETag(file)
+
+	if etag != `"7-1714564800"` {
+		t.Errorf("fileETag() = %s", etag)
+	}
+
+	tests := []struct {
+		ifMatch string
+		want    bool
+	}{
+		{ifMatch: etag, want: true},
+		{ifMatch: "*", want: true},
+		{ifMatch: `"7-1", ` + etag, want: true},
+		{ifMatch: `"7-1714564801"`, want: false},
+		{ifMatch: `W/` + etag, want: false}, // If-Match uses strong comparison
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.ifMatch, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.ifMatch, got, tt.want)
+		}
+	}
+}
+
+func TestValidateFileName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{name: "trimmed", input: "  beach.jpg ", want: "beach.jpg"},
+		{name: "korean", input: "제주도 여행.mp4", want: "제주도 여행.mp4"},
+		{name: "quotes and semicolons are escaped in downloads", input: `say "hi"; bye.jpg`, want: `say "hi"; bye.jpg`},
+		{name: "empty", input: "   ", wantErr: true},
+		{name: "path separator", input: "../beach.jpg", wantErr: true},
+		{name: "backslash", input: `a\b.jpg`, wantErr: true},
+		{name: "control character", input: "beach\n.jpg", wantErr: true},
+		{name: "too long", input: strings.Repeat("가", MaxFileNameLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateFileName(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateFileName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("validateFileName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeCustomFields(t *testing.T) {
+	value := func(s string) *string { return &s }
+	current := entity.CustomFields{"camera": "X100V", "trip": "jeju"}
+
+	merged, err := mergeCustomFields(current, map[string]*string{"trip": nil, "rating": value("5")})
+	if err != nil {
+		t.Fatalf("mergeCustomFields() error = %v", err)
+	}
+	if len(merged) != 2 || merged["camera"] != "X100V" || merged["rating"] != "5" {
+		t.Errorf("mergeCustomFields() = %v", merged)
+	}
+	if current["trip"] != "jeju" {
+		t.Error("mergeCustomFields() modified the current fields")
+	}
+
+	if merged, err := mergeCustomFields(current, map[string]*string{"camera": nil, "trip": nil}); err != nil || merged != nil {
+		t.Errorf("removing every field = %v, %v, want nil", merged, err)
+	}
+	if _, err := mergeCustomFields(nil, map[string]*string{" spaced": value("x")}); err == nil {
+		t.Error("expected an error for a key with surrounding spaces")
+	}
+	if _, err := mergeCustomFields(nil, map[string]*string{"long": value(strings.Repeat("a", MaxCustomFieldValueLength+1))}); err == nil {
+		t.Error("expected an error for a value that is too long")
+	}
+
+	tooMany := make(map[string]*string, MaxCustomFields+1)
+	for i := 0; i <= MaxCustomFields; i++ {
+		tooMany[strings.Repeat("k", i+1)] = value("v")
+	}
+	if _, err := mergeCustomFields(nil, tooMany); err == nil {
+		t.Errorf("expected an error for more than %d fields", MaxCustomFields)
+	}
+}
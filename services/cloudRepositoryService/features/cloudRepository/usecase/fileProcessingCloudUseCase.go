@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+const (
+	// MaxThumbnailSourceSize is the largest original downloaded for thumbnail generation
+	MaxThumbnailSourceSize = 50 * 1024 * 1024 // 50MB
+	// MaxPosterSize is the largest video poster frame downloaded for thumbnail generation
+	MaxPosterSize = 10 * 1024 * 1024 // 10MB
+	// MaxConcurrentProcessing limits background processing started after upload commits
+	MaxConcurrentProcessing = 4
+	// ProcessingTimeout bounds processing of a single file
+	ProcessingTimeout = 2 * time.Minute
+	// ProcessingBackfillDelay gives the post-commit processing time to finish before the backfill job picks a file up
+	ProcessingBackfillDelay = 5 * time.Minute
+	// ProcessingBackfillBatchSize limits how many files are processed per backfill run
+	ProcessingBackfillBatchSize = 50
+)
+
+type FileProcessingCloudRepositoryUseCase struct {
+	Repo           _interface.IFileProcessingCloudRepositoryRepository
+	ContextTimeout time.Duration
+	slots          chan struct{}
+}
+
+func NewFileProcessingCloudRepositoryUseCase(repo _interface.IFileProcessingCloudRepositoryRepository, timeout time.Duration) _interface.IFileProcessingCloudRepositoryUseCase {
+	return &FileProcessingCloudRepositoryUseCase{
+		Repo:           repo,
+		ContextTimeout: timeout,
+		slots:          make(chan struct{}, MaxConcurrentProcessing),
+	}
+}
+
+// ProcessFile generates server-side thumbnails, extracts media metadata and analyzes images for a committed file
+func (u *FileProcessingCloudRepositoryUseCase) ProcessFile(c context.Context, fileID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("file not found: %w", err)
+	}
+
+	return u.processFile(ctx, file)
+}
+
+// ProcessFileAsync processes a file in the background without blocking the caller.
+// When all slots are busy the file is left pending for the backfill job.
+func (u *FileProcessingCloudRepositoryUseCase) ProcessFileAsync(fileID uint) {
+	select {
+	case u.slots <- struct{}{}:
+	default:
+		return
+	}
+
+	go func() {
+		defer func() { <-u.slots }()
+
+		ctx, cancel := context.WithTimeout(context.Background(), ProcessingTimeout)
+		defer cancel()
+		if err := u.ProcessFile(ctx, fileID); err != nil {
+			fmt.Printf("Warning: failed to process file %d: %v\n", fileID, err)
+		}
+	}()
+}
+
+// BackfillPendingFiles processes committed files whose thumbnails, metadata or analysis are still pending.
+// Returns the number of processed files.
+func (u *FileProcessingCloudRepositoryUseCase) BackfillPendingFiles(c context.Context) (int, error) {
+	files, err := u.Repo.GetFilesPendingProcessing(c, time.Now().Add(-ProcessingBackfillDelay), ProcessingBackfillBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get files pending processing: %w", err)
+	}
+
+	processed := 0
+	for i := range files {
+		ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+		err := u.processFile(ctx, &files[i])
+		cancel()
+		if err != nil {
+			fmt.Printf("Warning: failed to backfill file %d: %v\n", files[i].ID, err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// processFile runs every processing step that is still pending for the file.
+// Images are downloaded once and shared by thumbnail generation, EXIF extraction and analysis.
+func (u *FileProcessingCloudRepositoryUseCase) processFile(ctx context.Context, file *entity.CloudFile) error {
+	thumbnailPending := file.ThumbnailStatus == entity.ThumbnailStatusPending
+	metadataPending := file.MetadataStatus == entity.MetadataStatusPending
+	analysisPending := file.AnalysisStatus == entity.AnalysisStatusPending
+	if !thumbnailPending && !metadataPending && !analysisPending {
+		return nil
+	}
+
+	var source []byte
+	if file.FileType == entity.FileTypeImage && AllowedImageTypes[file.ContentType] && file.FileSize <= MaxThumbnailSourceSize {
+		data, err := u.Repo.GetObject(ctx, file.S3Key)
+		if err != nil {
+			// Transient - leave the file pending so the backfill job retries
+			return fmt.Errorf("failed to download original: %w", err)
+		}
+		source = data
+	}
+
+	thumbnailSource := source
+	if thumbnailPending && file.FileType == entity.FileTypeVideo {
+		poster, err := u.getPoster(ctx, file)
+		if err != nil {
+			// Transient - leave the file pending so the backfill job retries
+			return err
+		}
+		thumbnailSource = poster
+	}
+
+	var errs []error
+	if thumbnailPending {
+		if err := u.generateThumbnails(ctx, file, thumbnailSource); err != nil {
+			errs = append(errs, fmt.Errorf("thumbnails: %w", err))
+		}
+	}
+	if metadataPending {
+		if err := u.extractMetadata(ctx, file, source); err != nil {
+			errs = append(errs, fmt.Errorf("metadata: %w", err))
+		}
+	}
+	if analysisPending {
+		if err := u.analyzeImage(ctx, file, source); err != nil {
+			errs = append(errs, fmt.Errorf("analysis: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getPoster downloads the poster frame the client uploaded for a video.
+// Returns nil when there is no usable poster.
+func (u *FileProcessingCloudRepositoryUseCase) getPoster(ctx context.Context, file *entity.CloudFile) ([]byte, error) {
+	if file.ThumbnailKey == "" {
+		return nil, nil
+	}
+
+	object, err := u.Repo.HeadObject(ctx, file.ThumbnailKey)
+	if errors.Is(err, sharedAws.ErrObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check poster: %w", err)
+	}
+	if object.Size == 0 || object.Size > MaxPosterSize {
+		fmt.Printf("Warning: ignoring %d byte poster of file %d\n", object.Size, file.ID)
+		return nil, nil
+	}
+
+	poster, err := u.Repo.GetObject(ctx, file.ThumbnailKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download poster: %w", err)
+	}
+	return poster, nil
+}
+
+// analyzeImage computes the perceptual hash, the sharpness and exposure scores and the dominant color palette
+// of the downloaded original and stores them on the file.
+// source is nil when the file cannot be analyzed.
+func (u *FileProcessingCloudRepositoryUseCase) analyzeImage(ctx context.Context, file *entity.CloudFile, source []byte) error {
+	if source == nil {
+		return u.Repo.UpdateAnalysisStatus(ctx, file.ID, entity.AnalysisStatusUnsupported)
+	}
+
+	img, err := decodeImage(source)
+	if err != nil {
+		if statusErr := u.Repo.UpdateAnalysisStatus(ctx, file.ID, entity.AnalysisStatusFailed); statusErr != nil {
+			fmt.Printf("Warning: failed to mark analysis of file %d as failed: %v\n", file.ID, statusErr)
+		}
+		return err
+	}
+
+	hash := differenceHash(img)
+	sharpness, exposure := measureImageQuality(img)
+	sharpness = math.Round(sharpness*100) / 100
+	exposure = math.Round(exposure*1000) / 1000
+	file.PerceptualHash = &hash
+	file.SharpnessScore = &sharpness
+	file.ExposureScore = &exposure
+	file.Palette = dominantColors(img, PaletteSize)
+	for i := range file.Palette {
+		file.Palette[i].FileID = file.ID
+	}
+	if err := u.Repo.SaveImageAnalysis(ctx, file); err != nil {
+		return fmt.Errorf("failed to save image analysis: %w", err)
+	}
+	return nil
+}
+
+// extractMetadata reads video container headers or image EXIF, depending on the file type
+func (u *FileProcessingCloudRepositoryUseCase) extractMetadata(ctx context.Context, file *entity.CloudFile, source []byte) error {
+	switch {
+	case file.FileType == entity.FileTypeVideo:
+		return u.extractVideoMetadata(ctx, file)
+	case source != nil && file.ContentType != "image/gif":
+		return u.extractExif(ctx, file, source)
+	}
+	return u.Repo.UpdateMetadataStatus(ctx, file.ID, entity.MetadataStatusUnsupported)
+}
+
+// extractExif parses the EXIF block of an image and stores it in its own table, with the geohash of its GPS
+// coordinates for the map. Images without EXIF are marked ready with no FileExif row.
+func (u *FileProcessingCloudRepositoryUseCase) extractExif(ctx context.Context, file *entity.CloudFile, source []byte) error {
+	meta, err := extractExifMetadata(source)
+	if errors.Is(err, errNoExif) {
+		return u.Repo.UpdateMetadataStatus(ctx, file.ID, entity.MetadataStatusReady)
+	}
+	if err != nil {
+		if statusErr := u.Repo.UpdateMetadataStatus(ctx, file.ID, entity.MetadataStatusFailed); statusErr != nil {
+			fmt.Printf("Warning: failed to mark metadata of file %d as failed: %v\n", file.ID, statusErr)
+		}
+		return err
+	}
+
+	exif := &entity.FileExif{
+		FileID:       file.ID,
+		TakenAt:      meta.TakenAt,
+		CameraMake:   truncateString(meta.CameraMake, 100),
+		CameraModel:  truncateString(meta.CameraModel, 100),
+		LensModel:    truncateString(meta.LensModel, 150),
+		ExposureTime: meta.ExposureTime,
+		FNumber:      meta.FNumber,
+		ISO:          meta.ISO,
+		FocalLength:  meta.FocalLength,
+		Orientation:  meta.Orientation,
+		Latitude:     meta.Latitude,
+		Longitude:    meta.Longitude,
+		Altitude:     meta.Altitude,
+	}
+	if meta.Latitude != nil && meta.Longitude != nil {
+		exif.Geohash = encodeGeohash(*meta.Latitude, *meta.Longitude, GeohashPrecision)
+	}
+	if err := u.Repo.SaveExif(ctx, exif); err != nil {
+		return fmt.Errorf("failed to save EXIF metadata: %w", err)
+	}
+	return nil
+}
+
+// extractVideoMetadata parses the video container headers with ranged reads and stores duration, resolution, codec and rotation.
+// A duration claimed by the client is kept when the container has none.
+func (u *FileProcessingCloudRepositoryUseCase) extractVideoMetadata(ctx context.Context, file *entity.CloudFile) error {
+	reader := newS3RangeReader(ctx, u.Repo, file.S3Key, file.FileSize)
+	meta, err := extractVideoMetadata(reader, file.FileSize)
+	if err != nil {
+		if reader.err != nil || ctx.Err() != nil {
+			// Transient - leave the file pending so the backfill job retries
+			return err
+		}
+		status := entity.MetadataStatusFailed
+		if errors.Is(err, errUnsupportedContainer) {
+			status = entity.MetadataStatusUnsupported
+		}
+		if statusErr := u.Repo.UpdateMetadataStatus(ctx, file.ID, status); statusErr != nil {
+			fmt.Printf("Warning: failed to mark metadata of file %d as %s: %v\n", file.ID, status, statusErr)
+		}
+		if status == entity.MetadataStatusUnsupported {
+			return nil
+		}
+		return err
+	}
+
+	if meta.Duration > 0 {
+		duration := math.Round(meta.Duration*100) / 100
+		file.Duration = &duration
+	}
+	if meta.Width > 0 && meta.Height > 0 {
+		file.Width = &meta.Width
+		file.Height = &meta.Height
+	}
+	file.VideoCodec = meta.Codec
+	file.Rotation = meta.Rotation
+
+	if err := u.Repo.SaveVideoMetadata(ctx, file); err != nil {
+		return fmt.Errorf("failed to save video metadata: %w", err)
+	}
+	return nil
+}
+
+// generateThumbnails stores every thumbnail size of the downloaded original, or of the poster of a video,
+// and records them on the file. source is nil when the file cannot be thumbnailed.
+func (u *FileProcessingCloudRepositoryUseCase) generateThumbnails(ctx context.Context, file *entity.CloudFile, source []byte) error {
+	if source == nil {
+		return u.Repo.UpdateThumbnailStatus(ctx, file.ID, entity.ThumbnailStatusUnsupported)
+	}
+
+	generated, err := generateThumbnails(source, ThumbnailSizes)
+	if err != nil {
+		if statusErr := u.Repo.UpdateThumbnailStatus(ctx, file.ID, entity.ThumbnailStatusFailed); statusErr != nil {
+			fmt.Printf("Warning: failed to mark thumbnails of file %d as failed: %v\n", file.ID, statusErr)
+		}
+		return err
+	}
+
+	thumbnails := make([]entity.FileThumbnail, len(generated))
+	for i, thumbnail := range generated {
+		key := generateThumbnailKey(file.UserID, file.ID, thumbnail.Size)
+		if err := u.Repo.PutObject(ctx, key, "image/jpeg", thumbnail.Data); err != nil {
+			return fmt.Errorf("failed to upload %dpx thumbnail: %w", thumbnail.Size, err)
+		}
+		thumbnails[i] = entity.FileThumbnail{
+			FileID: file.ID,
+			Size:   thumbnail.Size,
+			S3Key:  key,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		}
+	}
+
+	if err := u.Repo.SaveThumbnails(ctx, file.ID, thumbnails); err != nil {
+		return fmt.Errorf("failed to save thumbnails: %w", err)
+	}
+
+	// Remove the video poster, or a thumbnail uploaded by the client before server-side generation existed
+	if file.ThumbnailKey != "" && file.ThumbnailKey != thumbnails[0].S3Key {
+		if err := u.Repo.DeleteFromS3(ctx, file.ThumbnailKey); err != nil {
+			fmt.Printf("Warning: failed to delete legacy thumbnail of file %d: %v\n", file.ID, err)
+		}
+	}
+	return nil
+}
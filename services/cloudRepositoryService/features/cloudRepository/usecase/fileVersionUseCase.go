@@ -0,0 +1,455 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// DefaultMaxFileVersions is how many previous versions are kept per file when FILE_VERSION_MAX_COUNT is not set
+	DefaultMaxFileVersions = 10
+	// DefaultFileVersionRetention is how long previous versions are kept when FILE_VERSION_RETENTION_DAYS is not set
+	DefaultFileVersionRetention = 90 * 24 * time.Hour
+	// VersionPruneBatchSize limits how many versions are loaded per prune query
+	VersionPruneBatchSize = 100
+)
+
+// MaxFileVersions returns how many previous versions are kept per file, read from FILE_VERSION_MAX_COUNT
+func MaxFileVersions() int {
+	count, err := strconv.Atoi(os.Getenv("FILE_VERSION_MAX_COUNT"))
+	if err != nil || count < 1 {
+		return DefaultMaxFileVersions
+	}
+	return count
+}
+
+// FileVersionRetention returns how long previous versions are kept, read from FILE_VERSION_RETENTION_DAYS
+func FileVersionRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("FILE_VERSION_RETENTION_DAYS"))
+	if err != nil || days < 1 {
+		return DefaultFileVersionRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+type FileVersionUseCase struct {
+	Repo           _interface.IFileVersionRepository
+	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	Processor      _interface.IFileProcessingCloudRepositoryUseCase // Regenerates thumbnails and metadata for the new current content
+	Authorizer     _interface.IFileAuthorizer
+	MaxVersions    int
+	Retention      time.Duration
+	ContextTimeout time.Duration
+}
+
+func NewFileVersionUseCase(
+	repo _interface.IFileVersionRepository,
+	statsRepo _interface.IUserStatsCloudRepositoryRepository,
+	processor _interface.IFileProcessingCloudRepositoryUseCase,
+	authorizer _interface.IFileAuthorizer,
+	maxVersions int,
+	retention time.Duration,
+	timeout time.Duration,
+) _interface.IFileVersionUseCase {
+	return &FileVersionUseCase{
+		Repo:           repo,
+		StatsRepo:      statsRepo,
+		Processor:      processor,
+		Authorizer:     authorizer,
+		MaxVersions:    maxVersions,
+		Retention:      retention,
+		ContextTimeout: timeout,
+	}
+}
+
+// ListVersions lists the current content of a file followed by its previous versions, newest first
+func (u *FileVersionUseCase) ListVersions(c context.Context, userID, fileID uint) (*response.ListFileVersionsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionView); err != nil {
+		return nil, err
+	}
+
+	archived, err := u.Repo.GetArchivedVersions(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions: %w", err)
+	}
+
+	resp := &response.ListFileVersionsResponseDTO{
+		FileID:   fileID,
+		Versions: make([]response.FileVersionDTO, 0, len(archived)+1),
+	}
+	resp.Versions = append(resp.Versions, *currentVersionDTO(file))
+	for _, version := range archived {
+		resp.Versions = append(resp.Versions, toFileVersionDTO(&version))
+		resp.RetainedSize += version.FileSize
+	}
+	return resp, nil
+}
+
+// RequestVersionUpload generates a presigned upload URL for new content of a file and creates a pending version.
+// The version counts against the quota of the file's owner; it becomes current only after CompleteVersionUpload.
+func (u *FileVersionUseCase) RequestVersionUpload(c context.Context, userID, fileID uint, req *request.UploadVersionRequestDTO) (*response.UploadVersionResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if req.FileSize > MaxSingleUploadSize {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("file too large for single upload (max %d bytes)", MaxSingleUploadSize))
+	}
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionEdit); err != nil {
+		return nil, err
+	}
+	if err := validateVersionContentType(file.FileType, req.ContentType); err != nil {
+		return nil, err
+	}
+
+	checksum := strings.ToLower(req.SHA256)
+	version := &entity.FileVersion{
+		FileID:      file.ID,
+		UserID:      file.UserID,
+		S3Key:       generateS3Key(file.UserID, file.FileType, file.FileName),
+		FileSize:    req.FileSize,
+		ContentType: req.ContentType,
+		SHA256:      checksum,
+		Status:      entity.VersionStatusPending,
+	}
+	if err := u.Repo.CreatePendingVersion(ctx, version); err != nil {
+		return nil, fmt.Errorf("failed to create version record: %w", err)
+	}
+
+	resp := &response.UploadVersionResponseDTO{
+		FileID:    file.ID,
+		UploadID:  version.ID,
+		ExpiresIn: int(DefaultUploadExpiration.Seconds()),
+	}
+	if checksum == "" {
+		resp.UploadURL, err = u.Repo.GeneratePresignedUploadURL(ctx, version.S3Key, req.ContentType, DefaultUploadExpiration)
+	} else {
+		// S3 rejects the PUT unless the body matches the declared hash
+		checksumBase64 := sha256HexToBase64(checksum)
+		resp.UploadURL, err = u.Repo.GeneratePresignedUploadURLWithChecksum(ctx, version.S3Key, req.ContentType, checksumBase64, DefaultUploadExpiration)
+		resp.RequiredHeaders = map[string]string{
+			"Content-Type":          req.ContentType,
+			"x-amz-checksum-sha256": checksumBase64,
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
+	}
+
+	return resp, nil
+}
+
+// CompleteVersionUpload verifies the uploaded object of a pending version and makes it the current content.
+// The replaced content is kept as the previous version.
+func (u *FileVersionUseCase) CompleteVersionUpload(c context.Context, userID, fileID, uploadID uint) (*response.FileVersionDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionEdit); err != nil {
+		return nil, err
+	}
+
+	version, err := u.Repo.GetPendingVersion(ctx, fileID, uploadID)
+	if err != nil {
+		return nil, lookupError("version upload", err)
+	}
+
+	object, err := u.Repo.HeadObject(ctx, version.S3Key)
+	if err != nil {
+		if errors.Is(err, sharedAws.ErrObjectNotFound) {
+			return nil, sharedErrors.Conflict("upload conflict: object has not been uploaded yet")
+		}
+		return nil, fmt.Errorf("failed to verify uploaded object: %w", err)
+	}
+
+	// The uploaded content is verified like a new file of the same type
+	declared := &entity.CloudFile{
+		FileType:    file.FileType,
+		FileSize:    version.FileSize,
+		ContentType: version.ContentType,
+		SHA256:      version.SHA256,
+	}
+	if verifyErr := verifyUploadedObject(declared, object); verifyErr != nil {
+		u.failVersion(ctx, version)
+		return nil, verifyErr
+	}
+	header, err := u.Repo.GetObjectRange(ctx, version.S3Key, 0, min(ContentSniffLength, version.FileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+	if verifyErr := verifyUploadedContent(declared, header); verifyErr != nil {
+		u.failVersion(ctx, version)
+		return nil, verifyErr
+	}
+
+	current, err := u.Repo.CompletePendingVersion(ctx, fileID, version.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete version: %w", err)
+	}
+	u.afterSwap(ctx, userID, current, entity.ActivityTypeVersionUpload)
+
+	// Enforce the version count right away; the prune job covers anything missed here
+	if _, err := u.pruneVersions(ctx, current.ID, time.Time{}); err != nil {
+		fmt.Printf("Warning: failed to prune versions of file %d: %v\n", current.ID, err)
+	}
+
+	return currentVersionDTO(current), nil
+}
+
+// DownloadVersion generates a presigned download URL for a version of a file, current or previous
+func (u *FileVersionUseCase) DownloadVersion(c context.Context, userID, fileID uint, number int) (*response.DownloadResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionDownload); err != nil {
+		return nil, err
+	}
+
+	s3Key := file.S3Key
+	if number != file.Version {
+		version, err := u.Repo.GetArchivedVersion(ctx, fileID, number)
+		if err != nil {
+			return nil, lookupError("version", err)
+		}
+		s3Key = version.S3Key
+	}
+
+	fileName := versionFileName(file.FileName, number)
+	downloadURL, err := u.Repo.GeneratePresignedDownloadURLWithFilename(ctx, s3Key, fileName, 1*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate download URL: %w", err)
+	}
+
+	return &response.DownloadResponseDTO{
+		DownloadURL: downloadURL,
+		FileName:    fileName,
+		ExpiresIn:   int(time.Hour.Seconds()),
+	}, nil
+}
+
+// RestoreVersion makes a previous version the current content of a file again.
+// The replaced content is kept as a previous version, so restoring never loses content or changes storage.
+func (u *FileVersionUseCase) RestoreVersion(c context.Context, userID, fileID uint, number int) (*response.FileVersionDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionEdit); err != nil {
+		return nil, err
+	}
+	if number == file.Version {
+		// Already current - restoring is idempotent
+		return currentVersionDTO(file), nil
+	}
+
+	current, err := u.Repo.RestoreArchivedVersion(ctx, fileID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore version: %w", err)
+	}
+	u.afterSwap(ctx, userID, current, entity.ActivityTypeVersionRestore)
+
+	return currentVersionDTO(current), nil
+}
+
+// DeleteVersion permanently removes a previous version of a file and releases its storage.
+// Only the owner can delete versions; the current version is removed by deleting the file.
+func (u *FileVersionUseCase) DeleteVersion(c context.Context, userID, fileID uint, number int) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionOwner); err != nil {
+		return err
+	}
+	if number == file.Version {
+		return sharedErrors.Conflict("version conflict: the current version cannot be deleted")
+	}
+
+	version, err := u.Repo.DeleteArchivedVersion(ctx, fileID, number)
+	if err != nil {
+		return fmt.Errorf("failed to delete version: %w", err)
+	}
+
+	// S3 cleanup is best effort - the reconciliation job deletes objects left behind
+	if err := u.Repo.DeleteFromS3(ctx, version.S3Key); err != nil {
+		fmt.Printf("Warning: failed to delete version from S3: %v\n", err)
+	}
+	return nil
+}
+
+// PruneVersions expires pending versions whose upload URL has expired and removes previous versions beyond
+// MaxVersions per file or older than Retention. Returns the number of versions removed.
+func (u *FileVersionUseCase) PruneVersions(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	createdBefore := time.Now().Add(-(DefaultUploadExpiration + PendingUploadGracePeriod))
+	stale, err := u.Repo.GetStalePendingVersions(ctx, createdBefore, VersionPruneBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stale pending versions: %w", err)
+	}
+
+	removed := 0
+	for i := range stale {
+		if u.failVersion(ctx, &stale[i]) {
+			removed++
+		}
+	}
+
+	pruned, err := u.pruneVersions(ctx, 0, time.Now().Add(-u.Retention))
+	return removed + pruned, err
+}
+
+// pruneVersions removes previous versions beyond MaxVersions or archived before archivedBefore.
+// fileID limits pruning to one file; 0 prunes every file.
+func (u *FileVersionUseCase) pruneVersions(ctx context.Context, fileID uint, archivedBefore time.Time) (int, error) {
+	pruned := 0
+	for {
+		versions, err := u.Repo.GetPrunableVersions(ctx, fileID, u.MaxVersions, archivedBefore, VersionPruneBatchSize)
+		if err != nil {
+			return pruned, fmt.Errorf("failed to get prunable versions: %w", err)
+		}
+
+		failed := 0
+		for _, version := range versions {
+			deleted, err := u.Repo.DeleteArchivedVersion(ctx, version.FileID, version.Version)
+			if err != nil {
+				fmt.Printf("Warning: failed to prune version %d of file %d: %v\n", version.Version, version.FileID, err)
+				failed++
+				continue
+			}
+			if err := u.Repo.DeleteFromS3(ctx, deleted.S3Key); err != nil {
+				fmt.Printf("Warning: failed to delete pruned version from S3: %v\n", err)
+			}
+			pruned++
+		}
+
+		// Stop on a short batch, or when nothing in the batch could be removed so the same versions are not fetched again
+		if len(versions) < VersionPruneBatchSize || failed == len(versions) {
+			return pruned, nil
+		}
+	}
+}
+
+// failVersion marks a pending version as failed, releases its storage and deletes its object from S3.
+// Returns false if the version was no longer pending.
+func (u *FileVersionUseCase) failVersion(ctx context.Context, version *entity.FileVersion) bool {
+	if err := u.Repo.FailPendingVersion(ctx, version.ID); err != nil {
+		return false
+	}
+	version.Status = entity.VersionStatusFailed
+
+	// S3 cleanup is best effort - the row is already marked failed
+	if err := u.Repo.DeleteFromS3(ctx, version.S3Key); err != nil {
+		fmt.Printf("Warning: failed to delete failed version upload from S3: %v\n", err)
+	}
+	return true
+}
+
+// afterSwap logs the change of the current content and regenerates its thumbnails and metadata
+func (u *FileVersionUseCase) afterSwap(ctx context.Context, userID uint, file *entity.CloudFile, activityType entity.ActivityType) {
+	if u.StatsRepo != nil {
+		activity := &entity.ActivityLog{
+			UserID:       userID,
+			FileID:       &file.ID,
+			ActivityType: activityType,
+		}
+		_ = u.StatsRepo.LogActivity(ctx, activity) // Don't fail on logging error
+	}
+
+	// The backfill job retries anything missed here
+	if u.Processor != nil {
+		u.Processor.ProcessFileAsync(file.ID)
+	}
+}
+
+// validateVersionContentType checks that new content keeps the file type (image or video) of the file
+func validateVersionContentType(fileType entity.FileType, contentType string) error {
+	switch fileType {
+	case entity.FileTypeImage:
+		if !AllowedImageTypes[contentType] {
+			return sharedErrors.BadRequest(fmt.Sprintf("invalid image content type: %s", contentType))
+		}
+	case entity.FileTypeVideo:
+		if !AllowedVideoTypes[contentType] {
+			return sharedErrors.BadRequest(fmt.Sprintf("invalid video content type: %s", contentType))
+		}
+	default:
+		return sharedErrors.BadRequest(fmt.Sprintf("invalid file type: %s", fileType))
+	}
+	return nil
+}
+
+// versionFileName names a downloaded version after the file, e.g. "photo (v2).jpg"
+func versionFileName(fileName string, number int) string {
+	ext := filepath.Ext(fileName)
+	return fmt.Sprintf("%s (v%d)%s", strings.TrimSuffix(fileName, ext), number, ext)
+}
+
+// currentVersionDTO describes the current content of a file as a version
+func currentVersionDTO(file *entity.CloudFile) *response.FileVersionDTO {
+	createdAt := file.CreatedAt
+	if file.VersionCreatedAt != nil {
+		createdAt = *file.VersionCreatedAt
+	}
+	return &response.FileVersionDTO{
+		Version:     file.Version,
+		Current:     true,
+		FileSize:    file.FileSize,
+		ContentType: file.ContentType,
+		SHA256:      file.SHA256,
+		CreatedAt:   createdAt.Format(time.RFC3339),
+	}
+}
+
+func toFileVersionDTO(version *entity.FileVersion) response.FileVersionDTO {
+	dto := response.FileVersionDTO{
+		Version:     version.Version,
+		FileSize:    version.FileSize,
+		ContentType: version.ContentType,
+		SHA256:      version.SHA256,
+		CreatedAt:   version.CreatedAt.Format(time.RFC3339),
+	}
+	if version.ArchivedAt != nil {
+		dto.ArchivedAt = version.ArchivedAt.Format(time.RFC3339)
+	}
+	return dto
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestVersionFileName(t *testing.T) {
+	tests := []struct {
+		fileName string
+		number   int
+		want     string
+	}{
+		{fileName: "photo.jpg", number: 2, want: "photo (v2).jpg"},
+		{fileName: "trip.2024.mp4", number: 11, want: "trip.2024 (v11).mp4"},
+		{fileName: "noext", number: 1, want: "noext (v1)"},
+	}
+
+	for _, tt := range tests {
+		if got := versionFileName(tt.fileName, tt.number); got != tt.want {
+			t.Errorf("versionFileName(%q, %d) = %q, want %q", tt.fileName, tt.number, got, tt.want)
+		}
+	}
+}
+
+func TestValidateVersionContentType(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileType    entity.FileType
+		contentType string
+		wantErr     bool
+	}{
+		{name: "image replaced by another image format", fileType: entity.FileTypeImage, contentType: "image/png"},
+		{name: "video replaced by video", fileType: entity.FileTypeVideo, contentType: "video/mp4"},
+		{name: "image replaced by video", fileType: entity.FileTypeImage, contentType: "video/mp4", wantErr: true},
+		{name: "video replaced by image", fileType: entity.FileTypeVideo, contentType: "image/jpeg", wantErr: true},
+		{name: "unsupported type", fileType: entity.FileTypeImage, contentType: "application/pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateVersionContentType(tt.fileType, tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVersionContentType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
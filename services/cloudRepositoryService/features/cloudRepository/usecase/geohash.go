@@ -0,0 +1,65 @@
+package usecase
+
+import "strings"
+
+// geohashAlphabet is the base32 alphabet of geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// GeohashPrecision is the length of the geohash stored for every geotagged image (cells of about 3.7cm)
+const GeohashPrecision = 12
+
+// geohashPrecisionByZoom maps map zoom levels to the geohash length of the clusters, so a 256px tile holds a few
+// cells at every zoom, up to the level where individual files are returned.
+var geohashPrecisionByZoom = [MapFilesMinZoom]int{1, 1, 1, 2, 2, 3, 3, 3, 4, 4, 5, 5, 5, 6, 6, 7, 7, 8}
+
+// encodeGeohash encodes a coordinate as a geohash of the given length by alternately halving the longitude and
+// latitude ranges, five bits per character
+func encodeGeohash(latitude, longitude float64, precision int) string {
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	var hash strings.Builder
+	bit, ch := 0, 0
+	even := true
+	for hash.Len() < precision {
+		if even {
+			mid := (minLng + maxLng) / 2
+			if longitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLng = mid
+			} else {
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if latitude >= mid {
+				ch |= 1 << (4 - bit)
+				minLat = mid
+			} else {
+				maxLat = mid
+			}
+		}
+		even = !even
+
+		if bit < 4 {
+			bit++
+			continue
+		}
+		hash.WriteByte(geohashAlphabet[ch])
+		bit, ch = 0, 0
+	}
+	return hash.String()
+}
+
+// validGeohash reports whether value is a geohash of at most GeohashPrecision characters
+func validGeohash(value string) bool {
+	if value == "" || len(value) > GeohashPrecision {
+		return false
+	}
+	for _, r := range value {
+		if !strings.ContainsRune(geohashAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"image"
+	"math"
+	"math/bits"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// dHashSize is the side of the grid compared by the difference hash, giving dHashSize² bits
+	dHashSize = 8
+	// QualityAnalysisSize is the longest edge images are shrunk to before measuring sharpness and exposure,
+	// so scores do not depend on the resolution of the original
+	QualityAnalysisSize = 1024
+	// exposureShadowClip and exposureHighlightClip bound the gray levels counted as clipped
+	exposureShadowClip    = 8
+	exposureHighlightClip = 247
+)
+
+// differenceHash computes the 64-bit dHash of an image: it is shrunk to a 9x8 grayscale grid and each bit records
+// whether a cell is brighter than its right neighbour. Resizing, recompression and small edits flip only a few bits.
+func differenceHash(img image.Image) uint64 {
+	small := imaging.Grayscale(imaging.Resize(img, dHashSize+1, dHashSize, imaging.Box))
+
+	var hash uint64
+	for y := 0; y < dHashSize; y++ {
+		row := small.Pix[y*small.Stride:]
+		for x := 0; x < dHashSize; x++ {
+			hash <<= 1
+			if row[x*4] > row[(x+1)*4] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// hammingDistance counts the bits that differ between two hashes
+func hammingDistance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// groupSimilarHashes clusters hashes within maxDistance bits of each other, transitively, and returns the groups
+// with at least two members as ascending indexes, ordered by their first member.
+// Members of a group can be further apart than maxDistance; callers that need a bound split the groups.
+// Hashes are split into maxDistance+1 bands; two hashes within maxDistance bits agree on at least one band,
+// so only hashes sharing a band value are compared.
+func groupSimilarHashes(hashes []uint64, maxDistance int) [][]int {
+	parent := make([]int, len(hashes))
+	for i := range parent {
+		parent[i] = i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	bands := maxDistance + 1
+	for band := 0; band < bands; band++ {
+		start, end := band*64/bands, (band+1)*64/bands
+		mask := uint64(1)<<(end-start) - 1
+
+		buckets := make(map[uint64][]int)
+		for i, hash := range hashes {
+			key := hash >> start & mask
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, members := range buckets {
+			for a := 0; a < len(members); a++ {
+				for b := a + 1; b < len(members); b++ {
+					rootA, rootB := find(members[a]), find(members[b])
+					if rootA == rootB || hammingDistance(hashes[members[a]], hashes[members[b]]) > maxDistance {
+						continue
+					}
+					if rootA < rootB {
+						parent[rootB] = rootA
+					} else {
+						parent[rootA] = rootB
+					}
+				}
+			}
+		}
+	}
+
+	// Roots are the smallest index of their group, so groups come out ordered by their first member
+	members := make(map[int][]int)
+	var roots []int
+	for i := range hashes {
+		root := find(i)
+		if root == i {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], i)
+	}
+
+	var groups [][]int
+	for _, root := range roots {
+		if len(members[root]) >= 2 {
+			groups = append(groups, members[root])
+		}
+	}
+	return groups
+}
+
+// measureImageQuality returns the sharpness and exposure scores of an image, measured in grayscale at QualityAnalysisSize
+func measureImageQuality(img image.Image) (sharpness, exposure float64) {
+	gray := imaging.Grayscale(imaging.Fit(img, QualityAnalysisSize, QualityAnalysisSize, imaging.Linear))
+	return laplacianVariance(gray), exposureScore(gray)
+}
+
+// laplacianVariance convolves a grayscale image with the 4-neighbour Laplacian kernel and returns the variance of
+// the response. Edges respond strongly, so sharp images score high and blurry or featureless ones low.
+func laplacianVariance(gray *image.NRGBA) float64 {
+	width, height := gray.Bounds().Dx(), gray.Bounds().Dy()
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	at := func(x, y int) float64 {
+		return float64(gray.Pix[y*gray.Stride+x*4])
+	}
+	var sum, sumSquares float64
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			response := at(x-1, y) + at(x+1, y) + at(x, y-1) + at(x, y+1) - 4*at(x, y)
+			sum += response
+			sumSquares += response * response
+		}
+	}
+
+	n := float64((width - 2) * (height - 2))
+	mean := sum / n
+	return sumSquares/n - mean*mean
+}
+
+// exposureScore rates the brightness histogram of a grayscale image from 0 to 1. A mid-tone mean without clipping
+// scores 1; the score falls as the mean moves toward black or white and as pixels are clipped in shadows or highlights.
+func exposureScore(gray *image.NRGBA) float64 {
+	var histogram [256]int
+	width, height := gray.Bounds().Dx(), gray.Bounds().Dy()
+	for y := 0; y < height; y++ {
+		row := gray.Pix[y*gray.Stride:]
+		for x := 0; x < width; x++ {
+			histogram[row[x*4]]++
+		}
+	}
+
+	total := width * height
+	if total == 0 {
+		return 0
+	}
+	var sum, clipped int
+	for level, count := range histogram {
+		sum += level * count
+		if level <= exposureShadowClip || level >= exposureHighlightClip {
+			clipped += count
+		}
+	}
+
+	mean := float64(sum) / float64(total) / 255
+	return (1 - math.Abs(2*mean-1)) * (1 - float64(clipped)/float64(total))
+}
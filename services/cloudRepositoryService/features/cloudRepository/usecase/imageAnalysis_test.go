@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/disintegration/imaging"
+)
+
+// gradientImage draws a diagonal gradient with a dark square, so resized copies keep the same structure
+func gradientImage(width, height int, invert bool) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8((x*255/width + y*255/height) / 2)
+			if x > width/3 && x < width/2 && y > height/4 && y < height*3/4 {
+				v /= 4
+			}
+			if invert {
+				v = 255 - v
+			}
+			img.Set(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestDifferenceHash(t *testing.T) {
+	original := gradientImage(800, 600, false)
+	hash := differenceHash(original)
+
+	if distance := hammingDistance(hash, differenceHash(imaging.Resize(original, 200, 150, imaging.Lanczos))); distance > 4 {
+		t.Errorf("resized copy: distance %d, want at most 4", distance)
+	}
+	if distance := hammingDistance(hash, differenceHash(imaging.AdjustBrightness(original, 10))); distance > 4 {
+		t.Errorf("brightened copy: distance %d, want at most 4", distance)
+	}
+	if distance := hammingDistance(hash, differenceHash(gradientImage(800, 600, true))); distance < 32 {
+		t.Errorf("inverted image: distance %d, want at least 32", distance)
+	}
+}
+
+func TestHammingDistance(t *testing.T) {
+	tests := []struct {
+		a, b uint64
+		want int
+	}{
+		{a: 0, b: 0, want: 0},
+		{a: 0b1011, b: 0b0001, want: 2},
+		{a: 0, b: ^uint64(0), want: 64},
+	}
+
+	for _, tt := range tests {
+		if got := hammingDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("hammingDistance(%b, %b) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestGroupSimilarHashes(t *testing.T) {
+	tests := []struct {
+		name        string
+		hashes      []uint64
+		maxDistance int
+		want        [][]int
+	}{
+		{
+			name:        "no similar hashes",
+			hashes:      []uint64{0, ^uint64(0), 0xFFFF_0000_FFFF_0000},
+			maxDistance: 6,
+			want:        nil,
+		},
+		{
+			name:        "pairs within distance",
+			hashes:      []uint64{0, ^uint64(0), 0b111, ^uint64(0) ^ 1<<63},
+			maxDistance: 3,
+			want:        [][]int{{0, 2}, {1, 3}},
+		},
+		{
+			name: "groups are transitive",
+			// 0 and 2 differ by 6 bits, but both are within 3 bits of 1
+			hashes:      []uint64{0, 0b111, 0b111111},
+			maxDistance: 3,
+			want:        [][]int{{0, 1, 2}},
+		},
+		{
+			name:        "differences spread over every band",
+			hashes:      []uint64{0, 1 | 1<<20 | 1<<40 | 1<<60},
+			maxDistance: 4,
+			want:        [][]int{{0, 1}},
+		},
+		{
+			name:        "beyond distance",
+			hashes:      []uint64{0, 0b11111},
+			maxDistance: 4,
+			want:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := groupSimilarHashes(tt.hashes, tt.maxDistance); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupSimilarHashes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// checkerboardImage draws black and white squares with the given gray levels
+func checkerboardImage(size, square int, dark, light uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			v := dark
+			if (x/square+y/square)%2 == 0 {
+				v = light
+			}
+			img.Set(x, y, color.NRGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+	return img
+}
+
+func TestMeasureImageQuality(t *testing.T) {
+	sharpImage := checkerboardImage(600, 20, 60, 200)
+	sharp, _ := measureImageQuality(sharpImage)
+	blurred, _ := measureImageQuality(imaging.Blur(sharpImage, 6))
+	if sharp < 100 {
+		t.Errorf("sharp image: sharpness %.2f, want at least 100", sharp)
+	}
+	if blurred >= 100 {
+		t.Errorf("blurred image: sharpness %.2f, want below 100", blurred)
+	}
+
+	tests := []struct {
+		name    string
+		img     *image.NRGBA
+		wantMin float64
+		wantMax float64
+	}{
+		{name: "mid-tones", img: checkerboardImage(200, 20, 90, 170), wantMin: 0.95, wantMax: 1},
+		{name: "underexposed", img: checkerboardImage(200, 20, 5, 40), wantMin: 0, wantMax: 0.1},
+		{name: "overexposed", img: checkerboardImage(200, 20, 230, 255), wantMin: 0, wantMax: 0.1},
+		{name: "black", img: checkerboardImage(200, 20, 0, 0), wantMin: 0, wantMax: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, exposure := measureImageQuality(tt.img)
+			if exposure < tt.wantMin || exposure > tt.wantMax {
+				t.Errorf("exposure = %.3f, want between %.2f and %.2f", exposure, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestLaplacianVarianceOfFlatImage(t *testing.T) {
+	if got := laplacianVariance(checkerboardImage(100, 100, 128, 128)); got != 0 {
+		t.Errorf("laplacianVariance() = %f, want 0", got)
+	}
+	if got := laplacianVariance(checkerboardImage(2, 1, 0, 255)); got != 0 {
+		t.Errorf("laplacianVariance() of 2x2 image = %f, want 0", got)
+	}
+}
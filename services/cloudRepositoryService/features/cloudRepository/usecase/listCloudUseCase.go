@@ -9,6 +9,7 @@ import (
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 )
 
 type ListCloudRepositoryUseCase struct {
@@ -39,15 +40,24 @@ func (u *ListCloudRepositoryUseCase) ListFiles(c context.Context, userID uint, r
 	if req.FileType != "" {
 		ft := entity.FileType(req.FileType)
 		if ft != entity.FileTypeImage && ft != entity.FileTypeVideo {
-			return nil, fmt.Errorf("invalid file type: %s", req.FileType)
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid file type: %s", req.FileType))
+		}
+	}
+	if req.MaxDuration > 0 && req.MinDuration > req.MaxDuration {
+		return nil, sharedErrors.BadRequest("invalid duration range: min_duration is greater than max_duration")
+	}
+	if req.Color != "" {
+		if _, err := request.ParseHexColor(req.Color); err != nil {
+			return nil, err
 		}
 	}
 
-	files, total, err := u.Repo.GetFilesByUserID(ctx, userID, req)
+	files, total, nextCursor, err := u.Repo.GetFilesByUserID(ctx, userID, req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list files: %w", err)
 	}
 
+	searchTerms := request.SearchTerms(req.Keyword)
 	fileInfos := make([]response.FileInfoDTO, len(files))
 	for i, file := range files {
 		// Map tags
@@ -76,25 +86,97 @@ func (u *ListCloudRepositoryUseCase) ListFiles(c context.Context, userID uint, r
 			}
 		}
 
+		takenAt, exifDTO := toExifDTO(file.Exif)
+
 		fileInfos[i] = response.FileInfoDTO{
 			ID:           file.ID,
 			FileName:     file.FileName,
+			Description:  file.Description,
+			CustomFields: file.CustomFields,
 			FileType:     string(file.FileType),
 			ContentType:  file.ContentType,
 			FileSize:     file.FileSize,
 			Duration:     file.Duration,
+			Width:        file.Width,
+			Height:       file.Height,
+			VideoCodec:   file.VideoCodec,
+			Rotation:     file.Rotation,
+			TakenAt:      takenAt,
+			Exif:         exifDTO,
+			Quality:      toImageQualityDTO(&file),
+			Palette:      toPaletteDTO(file.Palette),
 			Tags:         tagDTOs,
 			DownloadURL:  downloadURL,
 			ThumbnailURL: thumbnailURL,
 			CreatedAt:    file.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:    file.UpdatedAt.Format(time.RFC3339),
+			Highlights:   searchHighlights(file, searchTerms),
 		}
 	}
 
-	return &response.ListFilesResponseDTO{
+	resp := &response.ListFilesResponseDTO{
 		Files:      fileInfos,
-		TotalCount: total,
 		Page:       req.Page,
 		PageSize:   req.PageSize,
-	}, nil
+		NextCursor: nextCursor,
+	}
+	if !req.SkipTotal {
+		resp.TotalCount = &total
+	}
+	return resp, nil
+}
+
+// toExifDTO maps stored EXIF metadata to its response, returning the capture time separately for the timeline
+func toExifDTO(exif *entity.FileExif) (string, *response.ExifDTO) {
+	if exif == nil {
+		return "", nil
+	}
+
+	takenAt := ""
+	if exif.TakenAt != nil {
+		takenAt = exif.TakenAt.Format(time.RFC3339)
+	}
+	return takenAt, &response.ExifDTO{
+		CameraMake:   exif.CameraMake,
+		CameraModel:  exif.CameraModel,
+		LensModel:    exif.LensModel,
+		ExposureTime: exif.ExposureTime,
+		FNumber:      exif.FNumber,
+		ISO:          exif.ISO,
+		FocalLength:  exif.FocalLength,
+		Orientation:  exif.Orientation,
+		Latitude:     exif.Latitude,
+		Longitude:    exif.Longitude,
+		Altitude:     exif.Altitude,
+	}
+}
+
+// toImageQualityDTO maps the quality scores of an analyzed image to its response, nil until both are measured
+func toImageQualityDTO(file *entity.CloudFile) *response.ImageQualityDTO {
+	if file.SharpnessScore == nil || file.ExposureScore == nil {
+		return nil
+	}
+
+	blurry := *file.SharpnessScore < entity.LowSharpnessScore
+	badExposure := *file.ExposureScore < entity.LowExposureScore
+	return &response.ImageQualityDTO{
+		Sharpness:   *file.SharpnessScore,
+		Exposure:    *file.ExposureScore,
+		Blurry:      blurry,
+		BadExposure: badExposure,
+		Low:         blurry || badExposure,
+	}
+}
+
+// toPaletteDTO maps the dominant colors of an image to their response
+func toPaletteDTO(palette []entity.FileColor) []response.ColorDTO {
+	if len(palette) == 0 {
+		return nil
+	}
+
+	colors := make([]response.ColorDTO, len(palette))
+	for i, c := range palette {
+		colors[i] = response.ColorDTO{Hex: c.Hex, Weight: c.Weight}
+	}
+	return colors
 }
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+// lookupError reports a missing record as not found and keeps any other lookup failure as an internal error
+func lookupError(resource string, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return sharedErrors.NotFound(resource + " not found")
+	}
+	return fmt.Errorf("failed to get %s: %w", resource, err)
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// MapModeClusters returns files aggregated by geohash cell
+	MapModeClusters = "clusters"
+	// MapModeFiles returns individual files
+	MapModeFiles = "files"
+	// MapFilesMinZoom is the zoom level from which individual files are returned instead of clusters
+	MapFilesMinZoom = 18
+	// MapMaxClusters bounds the clusters returned for one view
+	MapMaxClusters = 1000
+	// DefaultMapFilesLimit is how many files are returned by default when zoomed in
+	DefaultMapFilesLimit = 200
+)
+
+type MapUseCase struct {
+	Repo           _interface.IMapRepository
+	ContextTimeout time.Duration
+}
+
+func NewMapUseCase(repo _interface.IMapRepository, timeout time.Duration) _interface.IMapUseCase {
+	return &MapUseCase{
+		Repo:           repo,
+		ContextTimeout: timeout,
+	}
+}
+
+// GetMap places the caller's geotagged files in the bounding box on a map. Below the files zoom level they are
+// aggregated into geohash cells sized for the zoom, each with a count and a representative thumbnail; from that
+// level, or for a single cluster, the files themselves are returned.
+func (u *MapUseCase) GetMap(c context.Context, userID uint, req *request.MapRequestDTO) (*response.MapResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	var area entity.MapArea
+	if req.Cluster != "" {
+		cluster := strings.ToLower(req.Cluster)
+		if !validGeohash(cluster) {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid cluster: %q is not a geohash", req.Cluster))
+		}
+		area.Geohash = cluster
+	} else {
+		var err error
+		if area, err = parseBoundingBox(req.BBox); err != nil {
+			return nil, err
+		}
+	}
+
+	precision, clustered := mapClusterPrecision(req.Zoom)
+	if clustered && area.Geohash == "" {
+		clusters, err := u.Repo.GetClusters(ctx, userID, area, precision, MapMaxClusters+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get map clusters: %w", err)
+		}
+		truncated := len(clusters) > MapMaxClusters
+		if truncated {
+			clusters = clusters[:MapMaxClusters]
+		}
+
+		clusterDTOs := make([]response.MapClusterDTO, len(clusters))
+		for i, cluster := range clusters {
+			clusterDTOs[i] = response.MapClusterDTO{
+				Geohash:   cluster.Geohash,
+				Count:     cluster.Count,
+				Latitude:  cluster.Latitude,
+				Longitude: cluster.Longitude,
+				Bounds: response.MapBoundsDTO{
+					MinLatitude:  cluster.MinLatitude,
+					MinLongitude: cluster.MinLongitude,
+					MaxLatitude:  cluster.MaxLatitude,
+					MaxLongitude: cluster.MaxLongitude,
+				},
+				ThumbnailURL: u.thumbnailURL(ctx, cluster.ThumbnailKey),
+			}
+			if cluster.RepresentativeID != nil {
+				clusterDTOs[i].FileID = *cluster.RepresentativeID
+			}
+		}
+
+		return &response.MapResponseDTO{
+			Mode:      MapModeClusters,
+			Zoom:      req.Zoom,
+			Precision: precision,
+			Clusters:  clusterDTOs,
+			Truncated: truncated,
+		}, nil
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultMapFilesLimit
+	}
+	files, err := u.Repo.GetFiles(ctx, userID, area, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get map files: %w", err)
+	}
+	truncated := len(files) > limit
+	if truncated {
+		files = files[:limit]
+	}
+
+	fileDTOs := make([]response.MapFileDTO, 0, len(files))
+	for _, file := range files {
+		if file.Exif == nil || file.Exif.Latitude == nil || file.Exif.Longitude == nil {
+			continue
+		}
+		takenAt, _ := toExifDTO(file.Exif)
+		fileDTOs = append(fileDTOs, response.MapFileDTO{
+			ID:           file.ID,
+			FileName:     file.FileName,
+			FileType:     string(file.FileType),
+			Latitude:     *file.Exif.Latitude,
+			Longitude:    *file.Exif.Longitude,
+			TakenAt:      takenAt,
+			ThumbnailURL: u.thumbnailURL(ctx, file.ThumbnailKey),
+		})
+	}
+
+	return &response.MapResponseDTO{
+		Mode:      MapModeFiles,
+		Zoom:      req.Zoom,
+		Files:     fileDTOs,
+		Truncated: truncated,
+	}, nil
+}
+
+func (u *MapUseCase) thumbnailURL(ctx context.Context, thumbnailKey string) string {
+	if thumbnailKey == "" {
+		return ""
+	}
+	url, err := u.Repo.GeneratePresignedDownloadURL(ctx, thumbnailKey, 1*time.Hour)
+	if err != nil {
+		// Log error but don't fail the entire request
+		return ""
+	}
+	return url
+}
+
+// mapClusterPrecision returns the geohash length of the clusters at a zoom level,
+// or false when the map is zoomed in far enough to show individual files
+func mapClusterPrecision(zoom int) (int, bool) {
+	if zoom < 0 || zoom >= MapFilesMinZoom {
+		return 0, false
+	}
+	return geohashPrecisionByZoom[zoom], true
+}
+
+// parseBoundingBox parses "min_lng,min_lat,max_lng,max_lat" in degrees. A min_lng greater than max_lng
+// describes a box crossing the antimeridian.
+func parseBoundingBox(bbox string) (entity.MapArea, error) {
+	parts := strings.Split(bbox, ",")
+	if len(parts) != 4 {
+		return entity.MapArea{}, sharedErrors.BadRequest("invalid bbox: expected min_lng,min_lat,max_lng,max_lat")
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(value) {
+			return entity.MapArea{}, sharedErrors.BadRequest(fmt.Sprintf("invalid bbox: %q is not a number", part))
+		}
+		values[i] = value
+	}
+
+	area := entity.MapArea{MinLongitude: values[0], MinLatitude: values[1], MaxLongitude: values[2], MaxLatitude: values[3]}
+	for _, lng := range []float64{area.MinLongitude, area.MaxLongitude} {
+		if lng < -180 || lng > 180 {
+			return entity.MapArea{}, sharedErrors.BadRequest(fmt.Sprintf("invalid bbox: longitude %g is outside -180..180", lng))
+		}
+	}
+	for _, lat := range []float64{area.MinLatitude, area.MaxLatitude} {
+		if lat < -90 || lat > 90 {
+			return entity.MapArea{}, sharedErrors.BadRequest(fmt.Sprintf("invalid bbox: latitude %g is outside -90..90", lat))
+		}
+	}
+	if area.MinLatitude > area.MaxLatitude {
+		return entity.MapArea{}, sharedErrors.BadRequest("invalid bbox: min_lat is greater than max_lat")
+	}
+	return area, nil
+}
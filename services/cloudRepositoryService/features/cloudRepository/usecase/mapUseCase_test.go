@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		precision int
+		want      string
+	}{
+		{name: "Jutland", latitude: 57.64911, longitude: 10.40744, precision: 11, want: "u4pruydqqvj"},
+		{name: "Spain", latitude: 42.6, longitude: -5.6, precision: 5, want: "ezs42"},
+		{name: "prefix", latitude: 57.64911, longitude: 10.40744, precision: 3, want: "u4p"},
+		{name: "south-east corner", latitude: -90, longitude: 180, precision: 1, want: "p"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeGeohash(tt.latitude, tt.longitude, tt.precision); got != tt.want {
+				t.Errorf("encodeGeohash() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidGeohash(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "u4pruydqqvj", want: true},
+		{value: "", want: false},
+		{value: "u4pa", want: false}, // a is not in the alphabet
+		{value: "U4P", want: false},
+		{value: "u4pruydqqvjxx", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := validGeohash(tt.value); got != tt.want {
+			t.Errorf("validGeohash(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestMapClusterPrecision(t *testing.T) {
+	previous := 0
+	for zoom := 0; zoom < MapFilesMinZoom; zoom++ {
+		precision, clustered := mapClusterPrecision(zoom)
+		if !clustered || precision < previous || precision > GeohashPrecision {
+			t.Fatalf("zoom %d: precision %d (clustered %v) after %d", zoom, precision, clustered, previous)
+		}
+		previous = precision
+	}
+	if _, clustered := mapClusterPrecision(MapFilesMinZoom); clustered {
+		t.Errorf("zoom %d: want files, got clusters", MapFilesMinZoom)
+	}
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		bbox    string
+		want    entity.MapArea
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			bbox: "126.8, 37.4,127.2,37.7",
+			want: entity.MapArea{MinLongitude: 126.8, MinLatitude: 37.4, MaxLongitude: 127.2, MaxLatitude: 37.7},
+		},
+		{
+			name: "crosses the antimeridian",
+			bbox: "170,-20,-170,10",
+			want: entity.MapArea{MinLongitude: 170, MinLatitude: -20, MaxLongitude: -170, MaxLatitude: 10},
+		},
+		{name: "too few values", bbox: "1,2,3", wantErr: true},
+		{name: "not a number", bbox: "a,2,3,4", wantErr: true},
+		{name: "NaN", bbox: "NaN,2,3,4", wantErr: true},
+		{name: "longitude out of range", bbox: "-181,0,10,10", wantErr: true},
+		{name: "latitude out of range", bbox: "0,0,10,91", wantErr: true},
+		{name: "latitudes reversed", bbox: "0,10,10,0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBoundingBox(tt.bbox)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBoundingBox() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("parseBoundingBox() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	DefaultMultipartPartSize = 16 * 1024 * 1024 // 16MB
+	MinMultipartPartSize     = 5 * 1024 * 1024  // S3 minimum for every part except the last
+	MaxMultipartPartSize     = 5 * 1024 * 1024 * 1024
+	MaxMultipartParts        = 10000
+	// MultipartUploadExpiration is how long a multipart upload may stay in progress before it is aborted
+	MultipartUploadExpiration = 7 * 24 * time.Hour
+	// DefaultPartURLExpiration is the lifetime of a presigned part URL; clients request new URLs when resuming
+	DefaultPartURLExpiration = 1 * time.Hour
+	// MultipartSweepBatchSize limits how many expired multipart uploads are aborted per sweep
+	MultipartSweepBatchSize = 100
+)
+
+type MultipartUploadCloudRepositoryUseCase struct {
+	Repo           _interface.IMultipartUploadCloudRepositoryRepository
+	UploadRepo     _interface.IUploadCloudRepositoryRepository
+	UploadUseCase  _interface.IUploadCloudRepositoryUseCase // Reuses upload verification and commit
+	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	ContextTimeout time.Duration
+}
+
+func NewMultipartUploadCloudRepositoryUseCase(
+	repo _interface.IMultipartUploadCloudRepositoryRepository,
+	uploadRepo _interface.IUploadCloudRepositoryRepository,
+	uploadUseCase _interface.IUploadCloudRepositoryUseCase,
+	statsRepo _interface.IUserStatsCloudRepositoryRepository,
+	timeout time.Duration,
+) _interface.IMultipartUploadCloudRepositoryUseCase {
+	return &MultipartUploadCloudRepositoryUseCase{
+		Repo:           repo,
+		UploadRepo:     uploadRepo,
+		UploadUseCase:  uploadUseCase,
+		StatsRepo:      statsRepo,
+		ContextTimeout: timeout,
+	}
+}
+
+// InitiateMultipartUpload creates a pending file record and starts an S3 multipart upload for it
+func (u *MultipartUploadCloudRepositoryUseCase) InitiateMultipartUpload(c context.Context, userID uint, req *request.InitiateMultipartUploadRequestDTO) (*response.InitiateMultipartUploadResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	// S3 keeps only a checksum of the part checksums for multipart objects, so a whole-file hash cannot be verified
+	if req.SHA256 != "" {
+		return nil, sharedErrors.BadRequest("sha256 is only supported for single uploads")
+	}
+
+	partSize, totalParts, err := calculatePartLayout(req.FileSize, req.PartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := newPendingFile(userID, &req.UploadRequestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	s3UploadID, err := u.Repo.CreateMultipartUpload(ctx, file.S3Key, file.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate multipart upload: %w", err)
+	}
+
+	if err := u.UploadRepo.CreateFile(ctx, file); err != nil {
+		_ = u.Repo.AbortMultipartUpload(ctx, file.S3Key, s3UploadID)
+		return nil, fmt.Errorf("failed to create file record: %w", err)
+	}
+	logTagAddActivity(ctx, u.StatsRepo, userID, file.Tags)
+
+	upload := &entity.MultipartUpload{
+		UserID:     userID,
+		FileID:     file.ID,
+		S3UploadID: s3UploadID,
+		S3Key:      file.S3Key,
+		PartSize:   partSize,
+		TotalParts: totalParts,
+		Status:     entity.MultipartStatusInProgress,
+		ExpiresAt:  time.Now().Add(MultipartUploadExpiration),
+	}
+	if err := u.Repo.CreateUpload(ctx, upload); err != nil {
+		_ = u.Repo.AbortMultipartUpload(ctx, file.S3Key, s3UploadID)
+		_ = u.UploadRepo.UpdateUploadStatus(ctx, file.ID, entity.UploadStatusPending, entity.UploadStatusFailed)
+		return nil, fmt.Errorf("failed to create multipart upload record: %w", err)
+	}
+
+	resp := &response.InitiateMultipartUploadResponseDTO{
+		UploadID:   upload.ID,
+		FileID:     file.ID,
+		S3Key:      file.S3Key,
+		PartSize:   partSize,
+		TotalParts: totalParts,
+		ExpiresAt:  upload.ExpiresAt.Format(time.RFC3339),
+	}
+
+	// Videos are thumbnailed from a poster frame the client uploads before completing the upload
+	if file.ThumbnailKey != "" {
+		thumbnailURL, err := u.UploadRepo.GeneratePresignedUploadURL(ctx, file.ThumbnailKey, PosterContentType, MultipartUploadExpiration)
+		if err != nil {
+			// Log error but don't fail the entire request
+			fmt.Printf("Warning: failed to generate poster upload URL for file %d: %v\n", file.ID, err)
+		} else {
+			resp.ThumbnailURL = thumbnailURL
+			resp.ThumbnailKey = file.ThumbnailKey
+		}
+	}
+
+	return resp, nil
+}
+
+// GetPartUploadURLs generates presigned URLs for the requested part numbers
+func (u *MultipartUploadCloudRepositoryUseCase) GetPartUploadURLs(c context.Context, userID, uploadID uint, req *request.PartUploadURLsRequestDTO) (*response.PartUploadURLsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != entity.MultipartStatusInProgress {
+		return nil, sharedErrors.Conflict(fmt.Sprintf("upload conflict: multipart upload is %s", upload.Status))
+	}
+	if err := checkUploadNotExpired(upload, time.Now()); err != nil {
+		return nil, err
+	}
+
+	parts := make([]response.PartUploadURLDTO, 0, len(req.PartNumbers))
+	for _, partNumber := range req.PartNumbers {
+		if partNumber < 1 || partNumber > upload.TotalParts {
+			return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid part number %d (upload has %d parts)", partNumber, upload.TotalParts))
+		}
+
+		uploadURL, err := u.Repo.GeneratePresignedUploadPartURL(ctx, upload.S3Key, upload.S3UploadID, partNumber, DefaultPartURLExpiration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate upload URL for part %d: %w", partNumber, err)
+		}
+		parts = append(parts, response.PartUploadURLDTO{
+			PartNumber: partNumber,
+			UploadURL:  uploadURL,
+		})
+	}
+
+	return &response.PartUploadURLsResponseDTO{
+		Parts:     parts,
+		ExpiresIn: int(DefaultPartURLExpiration.Seconds()),
+	}, nil
+}
+
+// GetUploadStatus syncs part progress from S3 and returns which parts are done so the client can resume
+func (u *MultipartUploadCloudRepositoryUseCase) GetUploadStatus(c context.Context, userID, uploadID uint) (*response.MultipartUploadStatusResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	if upload.Status == entity.MultipartStatusInProgress {
+		if err := u.syncParts(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+
+	uploadedParts := make([]response.UploadedPartDTO, len(upload.Parts))
+	uploaded := make(map[int32]bool, len(upload.Parts))
+	var uploadedBytes int64
+	for i, part := range upload.Parts {
+		uploadedParts[i] = response.UploadedPartDTO{
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
+			Size:       part.Size,
+		}
+		uploaded[part.PartNumber] = true
+		uploadedBytes += part.Size
+	}
+
+	return &response.MultipartUploadStatusResponseDTO{
+		UploadID:      upload.ID,
+		FileID:        upload.FileID,
+		Status:        string(upload.Status),
+		PartSize:      upload.PartSize,
+		TotalParts:    upload.TotalParts,
+		UploadedParts: uploadedParts,
+		UploadedBytes: uploadedBytes,
+		MissingParts:  missingParts(upload.TotalParts, uploaded),
+		ExpiresAt:     upload.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// CompleteMultipartUpload assembles all parts in S3, then verifies and commits the file
+func (u *MultipartUploadCloudRepositoryUseCase) CompleteMultipartUpload(c context.Context, userID, uploadID uint) (*response.CompleteUploadResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch upload.Status {
+	case entity.MultipartStatusCompleted:
+		// Idempotent - the object is already assembled, only the commit may be outstanding
+		return u.UploadUseCase.CompleteUpload(ctx, userID, upload.FileID)
+	case entity.MultipartStatusAborted:
+		return nil, sharedErrors.Conflict("upload conflict: multipart upload has been aborted")
+	}
+	if err := checkUploadNotExpired(upload, time.Now()); err != nil {
+		return nil, err
+	}
+
+	file, err := u.UploadRepo.GetFileByID(ctx, upload.FileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+
+	// An earlier request may have assembled the object and failed to record it; S3 no longer knows the upload ID
+	if _, err := u.UploadRepo.HeadObject(ctx, upload.S3Key); err == nil {
+		return u.commitAssembledUpload(ctx, userID, upload)
+	} else if !errors.Is(err, sharedAws.ErrObjectNotFound) {
+		return nil, fmt.Errorf("failed to check uploaded object: %w", err)
+	}
+
+	if err := u.syncParts(ctx, upload); err != nil {
+		return nil, err
+	}
+
+	uploaded := make(map[int32]bool, len(upload.Parts))
+	parts := make([]sharedAws.UploadedPart, 0, len(upload.Parts))
+	var uploadedBytes int64
+	for _, part := range upload.Parts {
+		if part.PartNumber > upload.TotalParts {
+			continue
+		}
+		uploaded[part.PartNumber] = true
+		uploadedBytes += part.Size
+		parts = append(parts, sharedAws.UploadedPart{
+			PartNumber: part.PartNumber,
+			ETag:       part.ETag,
+			Size:       part.Size,
+		})
+	}
+
+	if missing := missingParts(upload.TotalParts, uploaded); len(missing) > 0 {
+		return nil, sharedErrors.Conflict(fmt.Sprintf("upload conflict: %d parts have not been uploaded yet %v", len(missing), missing))
+	}
+	if uploadedBytes != file.FileSize {
+		return nil, sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: size mismatch (declared %d bytes, uploaded %d bytes)", file.FileSize, uploadedBytes))
+	}
+
+	if err := u.Repo.CompleteMultipartUpload(ctx, upload.S3Key, upload.S3UploadID, parts); err != nil {
+		return nil, fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return u.commitAssembledUpload(ctx, userID, upload)
+}
+
+// commitAssembledUpload marks a multipart upload completed once S3 has assembled its object, then verifies and commits the file
+func (u *MultipartUploadCloudRepositoryUseCase) commitAssembledUpload(ctx context.Context, userID uint, upload *entity.MultipartUpload) (*response.CompleteUploadResponseDTO, error) {
+	if err := u.Repo.UpdateUploadStatus(ctx, upload.ID, entity.MultipartStatusInProgress, entity.MultipartStatusCompleted); err != nil {
+		return nil, fmt.Errorf("failed to update upload status: %w", err)
+	}
+	return u.UploadUseCase.CompleteUpload(ctx, userID, upload.FileID)
+}
+
+// AbortMultipartUpload aborts the S3 multipart upload and marks the file as failed (idempotent)
+func (u *MultipartUploadCloudRepositoryUseCase) AbortMultipartUpload(c context.Context, userID, uploadID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return err
+	}
+
+	switch upload.Status {
+	case entity.MultipartStatusAborted:
+		return nil
+	case entity.MultipartStatusCompleted:
+		return sharedErrors.Conflict("upload conflict: multipart upload has already been completed")
+	}
+
+	if err := u.Repo.AbortMultipartUpload(ctx, upload.S3Key, upload.S3UploadID); err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return u.markAborted(ctx, upload)
+}
+
+// ExpireMultipartUploads aborts in-progress multipart uploads past their expiry time.
+// Returns the number of aborted uploads.
+func (u *MultipartUploadCloudRepositoryUseCase) ExpireMultipartUploads(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	uploads, err := u.Repo.GetExpiredUploads(ctx, time.Now(), MultipartSweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired multipart uploads: %w", err)
+	}
+
+	expired := 0
+	for i := range uploads {
+		upload := &uploads[i]
+		if err := u.Repo.AbortMultipartUpload(ctx, upload.S3Key, upload.S3UploadID); err != nil {
+			// Log error but still mark the upload aborted so the file is not left pending
+			fmt.Printf("Warning: failed to abort expired multipart upload %d: %v\n", upload.ID, err)
+		}
+		if err := u.markAborted(ctx, upload); err != nil {
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// getOwnedUpload retrieves a multipart upload and checks that the user owns it
+func (u *MultipartUploadCloudRepositoryUseCase) getOwnedUpload(ctx context.Context, userID, uploadID uint) (*entity.MultipartUpload, error) {
+	upload, err := u.Repo.GetUploadByID(ctx, uploadID)
+	if err != nil {
+		return nil, lookupError("multipart upload", err)
+	}
+	if upload.UserID != userID {
+		return nil, sharedErrors.Forbidden("access denied: you do not own this upload")
+	}
+	return upload, nil
+}
+
+// syncParts refreshes the recorded parts from S3, which is the source of truth for uploaded parts
+func (u *MultipartUploadCloudRepositoryUseCase) syncParts(ctx context.Context, upload *entity.MultipartUpload) error {
+	s3Parts, err := u.Repo.ListUploadedParts(ctx, upload.S3Key, upload.S3UploadID)
+	if err != nil {
+		return fmt.Errorf("failed to list uploaded parts: %w", err)
+	}
+
+	parts := make([]entity.MultipartUploadPart, len(s3Parts))
+	for i, part := range s3Parts {
+		parts[i] = entity.MultipartUploadPart{
+			MultipartUploadID: upload.ID,
+			PartNumber:        part.PartNumber,
+			ETag:              part.ETag,
+			Size:              part.Size,
+		}
+	}
+	if err := u.Repo.SaveParts(ctx, parts); err != nil {
+		return fmt.Errorf("failed to save uploaded parts: %w", err)
+	}
+
+	upload.Parts = parts
+	return nil
+}
+
+// checkUploadNotExpired rejects an in-progress upload past its expiry time; the expiry sweep aborts it
+func checkUploadNotExpired(upload *entity.MultipartUpload, now time.Time) error {
+	if now.Before(upload.ExpiresAt) {
+		return nil
+	}
+	return sharedErrors.Gone(fmt.Sprintf("multipart upload is no longer available: it expired at %s", upload.ExpiresAt.Format(time.RFC3339)))
+}
+
+// markAborted marks the upload aborted and its pending file failed
+func (u *MultipartUploadCloudRepositoryUseCase) markAborted(ctx context.Context, upload *entity.MultipartUpload) error {
+	if err := u.Repo.UpdateUploadStatus(ctx, upload.ID, entity.MultipartStatusInProgress, entity.MultipartStatusAborted); err != nil {
+		return fmt.Errorf("failed to update upload status: %w", err)
+	}
+	upload.Status = entity.MultipartStatusAborted
+
+	if err := u.UploadRepo.UpdateUploadStatus(ctx, upload.FileID, entity.UploadStatusPending, entity.UploadStatusFailed); err != nil {
+		fmt.Printf("Warning: failed to mark file %d as failed: %v\n", upload.FileID, err)
+	}
+	return nil
+}
+
+// calculatePartLayout picks a part size that keeps the upload within the S3 part count limit
+func calculatePartLayout(fileSize, requestedPartSize int64) (int64, int32, error) {
+	partSize := requestedPartSize
+	if partSize == 0 {
+		partSize = DefaultMultipartPartSize
+	}
+	if partSize < MinMultipartPartSize {
+		partSize = MinMultipartPartSize
+	}
+
+	// Grow the part size until the file fits in MaxMultipartParts parts
+	if minPartSize := (fileSize + MaxMultipartParts - 1) / MaxMultipartParts; partSize < minPartSize {
+		partSize = minPartSize
+	}
+	if partSize > MaxMultipartPartSize {
+		return 0, 0, sharedErrors.BadRequest(fmt.Sprintf("file too large for multipart upload: %d bytes", fileSize))
+	}
+
+	totalParts := (fileSize + partSize - 1) / partSize
+	return partSize, int32(totalParts), nil
+}
+
+// missingParts returns the part numbers in 1..totalParts that have not been uploaded
+func missingParts(totalParts int32, uploaded map[int32]bool) []int32 {
+	missing := make([]int32, 0)
+	for partNumber := int32(1); partNumber <= totalParts; partNumber++ {
+		if !uploaded[partNumber] {
+			missing = append(missing, partNumber)
+		}
+	}
+	return missing
+}
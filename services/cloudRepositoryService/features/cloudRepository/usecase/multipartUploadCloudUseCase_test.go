@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+)
+
+func TestCheckUploadNotExpired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		wantErr   bool
+	}{
+		{name: "open", expiresAt: now.Add(time.Hour)},
+		{name: "expires now", expiresAt: now, wantErr: true},
+		{name: "expired", expiresAt: now.Add(-time.Hour), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUploadNotExpired(&entity.MultipartUpload{ExpiresAt: tt.expiresAt}, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkUploadNotExpired() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMultipartUploadsRejectSHA256(t *testing.T) {
+	upload := request.UploadRequestDTO{
+		FileName:    "clip.mp4",
+		ContentType: "video/mp4",
+		FileType:    "video",
+		FileSize:    64 * 1024 * 1024,
+		SHA256:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}
+
+	// Both are rejected before any repository is used
+	_, multipartErr := (&MultipartUploadCloudRepositoryUseCase{ContextTimeout: time.Second}).
+		InitiateMultipartUpload(context.Background(), 1, &request.InitiateMultipartUploadRequestDTO{UploadRequestDTO: upload})
+	_, tusErr := (&TusUploadCloudRepositoryUseCase{ContextTimeout: time.Second}).
+		CreateUpload(context.Background(), 1, &request.TusCreateUploadRequestDTO{UploadRequestDTO: upload})
+
+	for name, err := range map[string]error{"multipart": multipartErr, "tus": tusErr} {
+		if status := errorStatus(err); status != http.StatusBadRequest {
+			t.Errorf("%s error = %v, want status %d", name, err, http.StatusBadRequest)
+		}
+	}
+}
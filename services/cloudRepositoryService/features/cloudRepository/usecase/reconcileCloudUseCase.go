@@ -0,0 +1,334 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+const (
+	// ReconcileOrphanGracePeriod keeps objects younger than this out of the orphan report.
+	// Their record may have been created after the run loaded the user's files.
+	ReconcileOrphanGracePeriod = 24 * time.Hour
+	// ReconcileUserTimeout bounds the reconciliation of a single user
+	ReconcileUserTimeout = 5 * time.Minute
+	// MaxReconcileReportEntries caps each issue list of a report; the counts are always complete
+	MaxReconcileReportEntries = 1000
+)
+
+// reconcileIssue is a file record that does not match its object in S3
+type reconcileIssue struct {
+	FileID       uint
+	S3Key        string
+	ExpectedSize int64
+	ActualSize   int64
+}
+
+// reconcileDiff is the result of comparing a user's records with the objects under their prefixes
+type reconcileDiff struct {
+	Orphans           []sharedAws.ListedObject
+	MissingFiles      []reconcileIssue
+	MissingThumbnails []reconcileIssue
+	SizeMismatches    []reconcileIssue
+}
+
+type ReconcileCloudRepositoryUseCase struct {
+	Repo           _interface.IReconcileCloudRepositoryRepository
+	ContextTimeout time.Duration
+}
+
+func NewReconcileCloudRepositoryUseCase(repo _interface.IReconcileCloudRepositoryRepository, timeout time.Duration) _interface.IReconcileCloudRepositoryUseCase {
+	return &ReconcileCloudRepositoryUseCase{
+		Repo:           repo,
+		ContextTimeout: timeout,
+	}
+}
+
+// Reconcile compares the files and thumbnails of every user with the objects in S3.
+// Users are collected from both the bucket and cloud_files, so users with only orphans or only missing objects are covered.
+// Per-user failures are recorded in the report and do not stop the run. With dryRun nothing is changed.
+func (u *ReconcileCloudRepositoryUseCase) Reconcile(c context.Context, dryRun bool) (*response.ReconcileReportDTO, error) {
+	userIDs, err := u.listUserIDs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	report := newReconcileReport(dryRun)
+	for _, userID := range userIDs {
+		if err := c.Err(); err != nil {
+			return report, err
+		}
+		if err := u.reconcileUser(c, userID, dryRun, report); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("user %d: %v", userID, err))
+		}
+	}
+
+	return report, nil
+}
+
+// ReconcileUser compares the files and thumbnails of a single user with the objects in S3
+func (u *ReconcileCloudRepositoryUseCase) ReconcileUser(c context.Context, userID uint, dryRun bool) (*response.ReconcileReportDTO, error) {
+	report := newReconcileReport(dryRun)
+	if err := u.reconcileUser(c, userID, dryRun, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (u *ReconcileCloudRepositoryUseCase) reconcileUser(c context.Context, userID uint, dryRun bool, report *response.ReconcileReportDTO) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	// Records are loaded before listing; objects uploaded in between are newer than the orphan grace period
+	orphanBefore := time.Now().Add(-ReconcileOrphanGracePeriod)
+	files, err := u.Repo.GetUserFiles(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get files: %w", err)
+	}
+	partKeys, err := u.Repo.GetInProgressTusPartKeys(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get tus uploads: %w", err)
+	}
+
+	prefixes := reconcilePrefixes(userID)
+	var objects []sharedAws.ListedObject
+	for _, prefix := range prefixes {
+		listed, err := u.listAllObjects(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, listed...)
+	}
+	report.UsersScanned++
+	report.ObjectsScanned += len(objects)
+
+	diff := diffUserObjects(files, partKeys, objects, prefixes, orphanBefore)
+	u.applyDiff(ctx, userID, diff, dryRun, report)
+	return nil
+}
+
+// applyDiff adds the issues to the report and, unless dryRun, repairs them:
+// orphans are deleted, files with a missing or wrong-sized object are marked failed
+// and files with missing thumbnails are queued for regeneration.
+func (u *ReconcileCloudRepositoryUseCase) applyDiff(ctx context.Context, userID uint, diff *reconcileDiff, dryRun bool, report *response.ReconcileReportDTO) {
+	repair := func(description string, fn func() error) {
+		if dryRun {
+			return
+		}
+		if err := fn(); err != nil {
+			report.Errors = appendCapped(report.Errors, fmt.Sprintf("user %d: failed to %s: %v", userID, description, err))
+			return
+		}
+		report.RepairedCount++
+	}
+
+	for _, object := range diff.Orphans {
+		report.OrphanCount++
+		report.OrphanObjects = appendCapped(report.OrphanObjects, response.ReconcileObjectDTO{
+			Key:          object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified.Format(time.RFC3339),
+		})
+		repair("delete orphan "+object.Key, func() error {
+			return u.Repo.DeleteFromS3(ctx, object.Key)
+		})
+	}
+
+	for _, issue := range diff.MissingFiles {
+		report.MissingCount++
+		report.MissingObjects = appendCapped(report.MissingObjects, response.ReconcileMissingDTO{
+			FileID: issue.FileID,
+			UserID: userID,
+			Kind:   "file",
+			S3Key:  issue.S3Key,
+		})
+		repair(fmt.Sprintf("fail file %d", issue.FileID), func() error {
+			return u.Repo.FailCommittedFile(ctx, issue.FileID)
+		})
+	}
+
+	for _, issue := range diff.SizeMismatches {
+		report.SizeMismatchCount++
+		report.SizeMismatches = appendCapped(report.SizeMismatches, response.ReconcileSizeMismatchDTO{
+			FileID:       issue.FileID,
+			UserID:       userID,
+			S3Key:        issue.S3Key,
+			ExpectedSize: issue.ExpectedSize,
+			ActualSize:   issue.ActualSize,
+		})
+		// The object was overwritten after verification - it cannot be trusted
+		repair(fmt.Sprintf("fail file %d", issue.FileID), func() error {
+			if err := u.Repo.FailCommittedFile(ctx, issue.FileID); err != nil {
+				return err
+			}
+			return u.Repo.DeleteFromS3(ctx, issue.S3Key)
+		})
+	}
+
+	reset := make(map[uint]bool)
+	for _, issue := range diff.MissingThumbnails {
+		report.MissingCount++
+		report.MissingObjects = appendCapped(report.MissingObjects, response.ReconcileMissingDTO{
+			FileID: issue.FileID,
+			UserID: userID,
+			Kind:   "thumbnail",
+			S3Key:  issue.S3Key,
+		})
+		if reset[issue.FileID] {
+			continue
+		}
+		reset[issue.FileID] = true
+		repair(fmt.Sprintf("reset thumbnails of file %d", issue.FileID), func() error {
+			return u.Repo.ResetThumbnails(ctx, issue.FileID)
+		})
+	}
+}
+
+// listUserIDs merges the user prefixes found in the bucket with the users that have file records
+func (u *ReconcileCloudRepositoryUseCase) listUserIDs(ctx context.Context) ([]uint, error) {
+	userIDs, err := u.Repo.GetUserIDsWithFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with files: %w", err)
+	}
+
+	token := ""
+	for {
+		page, err := u.Repo.ListObjects(ctx, "users/", "/", token)
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range page.CommonPrefixes {
+			id, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(prefix, "users/"), "/"), 10, 64)
+			if err != nil {
+				continue
+			}
+			userIDs = append(userIDs, uint(id))
+		}
+		if page.NextContinuationToken == "" {
+			break
+		}
+		token = page.NextContinuationToken
+	}
+
+	slices.Sort(userIDs)
+	return slices.Compact(userIDs), nil
+}
+
+// listAllObjects lists every object under prefix, following continuation tokens
+func (u *ReconcileCloudRepositoryUseCase) listAllObjects(ctx context.Context, prefix string) ([]sharedAws.ListedObject, error) {
+	var objects []sharedAws.ListedObject
+	token := ""
+	for {
+		page, err := u.Repo.ListObjects(ctx, prefix, "", token)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, page.Objects...)
+		if page.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// reconcilePrefixes returns the prefixes holding a user's originals and generated thumbnails
+func reconcilePrefixes(userID uint) []string {
+	return []string{
+		fmt.Sprintf("users/%d/files/", userID),
+		fmt.Sprintf("users/%d/thumbnails/", userID),
+	}
+}
+
+// diffUserObjects compares a user's file records with the objects listed under prefixes.
+// Pending files and in-progress tus parts (extraKeys) are known but not required to exist yet.
+// Trashed files keep their objects until purged, so they are known but never reported; so are pending and archived versions.
+// Keys outside the listed prefixes are never reported missing. Objects modified after orphanBefore are never orphans.
+func diffUserObjects(files []entity.CloudFile, extraKeys []string, objects []sharedAws.ListedObject, prefixes []string, orphanBefore time.Time) *reconcileDiff {
+	listed := func(key string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	objectsByKey := make(map[string]sharedAws.ListedObject, len(objects))
+	for _, object := range objects {
+		objectsByKey[object.Key] = object
+	}
+	known := make(map[string]bool, len(files)+len(extraKeys))
+	for _, key := range extraKeys {
+		known[key] = true
+	}
+
+	diff := &reconcileDiff{}
+	for i := range files {
+		file := &files[i]
+		for _, version := range file.Versions {
+			if version.Status != entity.VersionStatusFailed {
+				known[version.S3Key] = true
+			}
+		}
+		if file.UploadStatus == entity.UploadStatusFailed {
+			continue
+		}
+		known[file.S3Key] = true
+		if file.ThumbnailKey != "" {
+			known[file.ThumbnailKey] = true
+		}
+		for _, thumbnail := range file.Thumbnails {
+			known[thumbnail.S3Key] = true
+		}
+
+		if file.DeletedAt != nil || file.UploadStatus != entity.UploadStatusCommitted || !listed(file.S3Key) {
+			continue
+		}
+		object, ok := objectsByKey[file.S3Key]
+		if !ok {
+			diff.MissingFiles = append(diff.MissingFiles, reconcileIssue{FileID: file.ID, S3Key: file.S3Key, ExpectedSize: file.FileSize})
+			continue
+		}
+		if object.Size != file.FileSize {
+			diff.SizeMismatches = append(diff.SizeMismatches, reconcileIssue{FileID: file.ID, S3Key: file.S3Key, ExpectedSize: file.FileSize, ActualSize: object.Size})
+			continue
+		}
+		for _, thumbnail := range file.Thumbnails {
+			if _, ok := objectsByKey[thumbnail.S3Key]; !ok && listed(thumbnail.S3Key) {
+				diff.MissingThumbnails = append(diff.MissingThumbnails, reconcileIssue{FileID: file.ID, S3Key: thumbnail.S3Key})
+			}
+		}
+	}
+
+	for _, object := range objects {
+		if !known[object.Key] && object.LastModified.Before(orphanBefore) {
+			diff.Orphans = append(diff.Orphans, object)
+		}
+	}
+	return diff
+}
+
+func newReconcileReport(dryRun bool) *response.ReconcileReportDTO {
+	return &response.ReconcileReportDTO{
+		DryRun:         dryRun,
+		OrphanObjects:  make([]response.ReconcileObjectDTO, 0),
+		MissingObjects: make([]response.ReconcileMissingDTO, 0),
+		SizeMismatches: make([]response.ReconcileSizeMismatchDTO, 0),
+	}
+}
+
+// appendCapped appends item unless the list already holds MaxReconcileReportEntries entries
+func appendCapped[T any](list []T, item T) []T {
+	if len(list) >= MaxReconcileReportEntries {
+		return list
+	}
+	return append(list, item)
+}
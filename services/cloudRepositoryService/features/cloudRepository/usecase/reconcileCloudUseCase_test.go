@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+func TestDiffUserObjects(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	deletedAt := now.Add(-72 * time.Hour)
+
+	files := []entity.CloudFile{
+		{ID: 1, S3Key: "users/7/files/a.jpg", FileSize: 100, UploadStatus: entity.UploadStatusCommitted,
+			Thumbnails: []entity.FileThumbnail{{S3Key: "users/7/thumbnails/1_256.jpg"}, {S3Key: "users/7/thumbnails/1_1024.jpg"}}},
+		{ID: 2, S3Key: "users/7/files/b.mp4", FileSize: 200, UploadStatus: entity.UploadStatusCommitted,
+			Versions: []entity.FileVersion{{S3Key: "users/7/files/b-v1.mp4", Status: entity.VersionStatusArchived}, {S3Key: "users/7/files/b-v3.mp4", Status: entity.VersionStatusFailed}}},
+		{ID: 3, S3Key: "users/7/files/c.png", FileSize: 300, UploadStatus: entity.UploadStatusCommitted},
+		{ID: 4, S3Key: "users/7/files/d.jpg", FileSize: 400, UploadStatus: entity.UploadStatusPending},
+		{ID: 5, S3Key: "users/7/files/e.jpg", FileSize: 500, UploadStatus: entity.UploadStatusCommitted, DeletedAt: &deletedAt},
+		{ID: 7, S3Key: "users/7/files/h.jpg", FileSize: 700, UploadStatus: entity.UploadStatusFailed},
+		{ID: 8, S3Key: "users/7/files/i.jpg", FileSize: 800, UploadStatus: entity.UploadStatusCommitted, DeletedAt: &deletedAt},
+		{ID: 6, S3Key: "legacy/7/f.jpg", FileSize: 600, UploadStatus: entity.UploadStatusCommitted},
+	}
+	objects := []sharedAws.ListedObject{
+		{Key: "users/7/files/a.jpg", Size: 100, LastModified: old},
+		{Key: "users/7/thumbnails/1_256.jpg", Size: 10, LastModified: old},
+		{Key: "users/7/files/c.png", Size: 301, LastModified: old},
+		{Key: "users/7/files/e.jpg", Size: 500, LastModified: old},
+		{Key: "users/7/files/h.jpg", Size: 700, LastModified: old},
+		{Key: "users/7/files/b-v1.mp4", Size: 150, LastModified: old},
+		{Key: "users/7/files/g.mp4.part", Size: 5, LastModified: old},
+		{Key: "users/7/files/fresh.jpg", Size: 1, LastModified: now},
+	}
+
+	diff := diffUserObjects(files, []string{"users/7/files/g.mp4.part"}, objects, reconcilePrefixes(7), now.Add(-ReconcileOrphanGracePeriod))
+
+	if len(diff.Orphans) != 1 || diff.Orphans[0].Key != "users/7/files/h.jpg" {
+		t.Errorf("Orphans = %+v, want only the object of the failed file", diff.Orphans)
+	}
+	if len(diff.MissingFiles) != 1 || diff.MissingFiles[0].FileID != 2 {
+		t.Errorf("MissingFiles = %+v, want file 2 but not the trashed file 8", diff.MissingFiles)
+	}
+	if len(diff.SizeMismatches) != 1 || diff.SizeMismatches[0].FileID != 3 || diff.SizeMismatches[0].ActualSize != 301 {
+		t.Errorf("SizeMismatches = %+v, want file 3 with 301 bytes", diff.SizeMismatches)
+	}
+	if len(diff.MissingThumbnails) != 1 || diff.MissingThumbnails[0].S3Key != "users/7/thumbnails/1_1024.jpg" {
+		t.Errorf("MissingThumbnails = %+v, want the 1024px thumbnail of file 1", diff.MissingThumbnails)
+	}
+}
+
+func TestAppendCapped(t *testing.T) {
+	var list []int
+	for i := 0; i < MaxReconcileReportEntries+5; i++ {
+		list = appendCapped(list, i)
+	}
+	if len(list) != MaxReconcileReportEntries {
+		t.Errorf("len(list) = %d, want %d", len(list), MaxReconcileReportEntries)
+	}
+}
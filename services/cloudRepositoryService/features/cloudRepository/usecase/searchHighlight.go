@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+// SearchSnippetContext is how many characters a description snippet keeps on each side of the first match
+const SearchSnippetContext = 40
+
+// searchHighlights marks where the search terms occur in the file name, description and tags of a file.
+// Returns nil when there are no terms or nothing matched, e.g. when MySQL matched across a word boundary.
+func searchHighlights(file entity.CloudFile, terms []string) *response.SearchHighlightDTO {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	highlights := &response.SearchHighlightDTO{}
+	matched := false
+	if text, ok := highlightText(file.FileName, terms, 0); ok {
+		highlights.FileName = text
+		matched = true
+	}
+	if text, ok := highlightText(file.Description, terms, SearchSnippetContext); ok {
+		highlights.Description = text
+		matched = true
+	}
+	for _, tag := range file.Tags {
+		if text, ok := highlightText(tag.Name, terms, 0); ok {
+			highlights.Tags = append(highlights.Tags, text)
+			matched = true
+		}
+	}
+
+	if !matched {
+		return nil
+	}
+	return highlights
+}
+
+// highlightText HTML-escapes text and wraps every case-insensitive occurrence of a term in <mark>.
+// With a positive context, the text is cut to that many characters around the first match.
+func highlightText(text string, terms []string, context int) (string, bool) {
+	runes := []rune(text)
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	marked := make([]bool, len(runes))
+	first, last := -1, -1
+	for _, term := range terms {
+		termRunes := []rune(term)
+		for i := 0; len(termRunes) > 0 && i+len(termRunes) <= len(lower); i++ {
+			if string(lower[i:i+len(termRunes)]) != term {
+				continue
+			}
+			for j := i; j < i+len(termRunes); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+				last = i + len(termRunes)
+			}
+		}
+	}
+	if first == -1 {
+		return "", false
+	}
+
+	start, end := 0, len(runes)
+	if context > 0 {
+		start = max(first-context, 0)
+		end = min(last+context, len(runes))
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		segment := html.EscapeString(string(runes[i:j]))
+		if marked[i] {
+			segment = "<mark>" + segment + "</mark>"
+		}
+		b.WriteString(segment)
+		i = j
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
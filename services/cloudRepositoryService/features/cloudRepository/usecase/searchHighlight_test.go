@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+)
+
+func TestHighlightText(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		keyword string
+		context int
+		want    string
+		ok      bool
+	}{
+		{
+			name:    "case-insensitive match",
+			text:    "Beach Trip.jpg",
+			keyword: "beach",
+			want:    "<mark>Beach</mark> Trip.jpg",
+			ok:      true,
+		},
+		{
+			name:    "korean inside a word",
+			text:    "제주도여행사진.png",
+			keyword: "여행",
+			want:    "제주도<mark>여행</mark>사진.png",
+			ok:      true,
+		},
+		{
+			name:    "every term is marked and text is escaped",
+			text:    "<b>cat & dog</b>",
+			keyword: "+cat dog*",
+			want:    "&lt;b&gt;<mark>cat</mark> &amp; <mark>dog</mark>&lt;/b&gt;",
+			ok:      true,
+		},
+		{
+			name:    "snippet around the first match",
+			text:    "aaaaaaaaaa needle bbbbbbbbbb",
+			keyword: "needle",
+			context: 3,
+			want:    "…aa <mark>needle</mark> bb…",
+			ok:      true,
+		},
+		{
+			name:    "no match",
+			text:    "sunset.jpg",
+			keyword: "beach",
+			ok:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := highlightText(tt.text, request.SearchTerms(tt.keyword), tt.context)
+			if ok != tt.ok || got != tt.want {
+				t.Errorf("highlightText() = %q, %v, want %q, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestSearchHighlights(t *testing.T) {
+	file := entity.CloudFile{
+		FileName:    "IMG_0001.jpg",
+		Description: strings.Repeat("x", 100) + " 바다 " + strings.Repeat("y", 100),
+		Tags:        []entity.Tag{{Name: "바다여행"}, {Name: "family"}},
+	}
+
+	highlights := searchHighlights(file, request.SearchTerms("바다"))
+	if highlights == nil {
+		t.Fatal("searchHighlights() = nil, want matches in the description and a tag")
+	}
+	if highlights.FileName != "" {
+		t.Errorf("FileName = %q, want empty", highlights.FileName)
+	}
+	if !strings.HasPrefix(highlights.Description, "…") || !strings.Contains(highlights.Description, "<mark>바다</mark>") {
+		t.Errorf("Description = %q, want a snippet around the match", highlights.Description)
+	}
+	if len(highlights.Tags) != 1 || highlights.Tags[0] != "<mark>바다</mark>여행" {
+		t.Errorf("Tags = %v, want only the matching tag", highlights.Tags)
+	}
+
+	if got := searchHighlights(file, nil); got != nil {
+		t.Errorf("searchHighlights() without terms = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// ShareTokenBytes is the amount of randomness in a share link token (256 bits)
+	ShareTokenBytes = 32
+	// ShareDownloadURLExpiration is the lifetime of the presigned URLs issued through a share link
+	ShareDownloadURLExpiration = 5 * time.Minute
+	// MaxSharePasswordBytes is the longest password bcrypt can hash
+	MaxSharePasswordBytes = 72
+)
+
+// Password outcomes of resolving a protected share link
+var (
+	ErrShareLinkPasswordRequired = sharedErrors.Unauthorized("share link password required")
+	ErrShareLinkWrongPassword    = sharedErrors.Unauthorized("wrong share link password")
+)
+
+type ShareLinkUseCase struct {
+	Repo           _interface.IShareLinkRepository
+	ContextTimeout time.Duration
+}
+
+func NewShareLinkUseCase(repo _interface.IShareLinkRepository, timeout time.Duration) _interface.IShareLinkUseCase {
+	return &ShareLinkUseCase{
+		Repo:           repo,
+		ContextTimeout: timeout,
+	}
+}
+
+// CreateShareLink creates a share link for the user's files and returns its token
+func (u *ShareLinkUseCase) CreateShareLink(c context.Context, userID uint, req request.CreateShareLinkRequestDTO) (*response.CreateShareLinkResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, sharedErrors.BadRequest("invalid expires_at: must be in the future")
+	}
+	if err := validateSharePassword(req.Password); err != nil {
+		return nil, err
+	}
+
+	fileIDs := uniqueIDs(req.FileIDs)
+	files, err := u.Repo.GetLiveFiles(ctx, userID, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get files: %w", err)
+	}
+	if len(files) != len(fileIDs) {
+		return nil, sharedErrors.NotFound("file not found")
+	}
+
+	token, tokenHash, err := newShareToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share token: %w", err)
+	}
+
+	link := &entity.ShareLink{
+		UserID:       userID,
+		TokenHash:    tokenHash,
+		ExpiresAt:    req.ExpiresAt,
+		MaxDownloads: req.MaxDownloads,
+	}
+	if req.Password != "" {
+		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		link.PasswordHash = string(passwordHash)
+	}
+
+	if err := u.Repo.CreateShareLink(ctx, link, fileIDs); err != nil {
+		return nil, fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	return &response.CreateShareLinkResponseDTO{
+		ShareLinkDTO: toShareLinkDTO(link, now),
+		Token:        token,
+		URL:          "/s/" + token,
+	}, nil
+}
+
+// ListShareLinks lists the user's share links, newest first
+func (u *ShareLinkUseCase) ListShareLinks(c context.Context, userID uint, req request.ListShareLinksRequestDTO) (*response.ListShareLinksResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	links, total, err := u.Repo.GetUserShareLinks(ctx, userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+
+	now := time.Now()
+	linkDTOs := make([]response.ShareLinkDTO, len(links))
+	for i := range links {
+		linkDTOs[i] = toShareLinkDTO(&links[i], now)
+	}
+
+	return &response.ListShareLinksResponseDTO{
+		ShareLinks: linkDTOs,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}, nil
+}
+
+// RevokeShareLink revokes a share link of the user; it stops working immediately
+func (u *ShareLinkUseCase) RevokeShareLink(c context.Context, userID, linkID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if err := u.Repo.RevokeShareLink(ctx, userID, linkID); err != nil {
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+	return nil
+}
+
+// ListAccesses lists the access log of a share link of the user, most recent first
+func (u *ShareLinkUseCase) ListAccesses(c context.Context, userID, linkID uint, req request.ListShareAccessesRequestDTO) (*response.ListShareAccessesResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	if _, err := u.Repo.GetShareLink(ctx, userID, linkID); err != nil {
+		return nil, sharedErrors.NotFound("share link not found")
+	}
+
+	accesses, total, err := u.Repo.GetAccesses(ctx, linkID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list share link accesses: %w", err)
+	}
+
+	accessDTOs := make([]response.ShareAccessDTO, len(accesses))
+	for i, access := range accesses {
+		accessDTOs[i] = response.ShareAccessDTO{
+			Outcome:    string(access.Outcome),
+			IPAddress:  access.IPAddress,
+			UserAgent:  access.UserAgent,
+			AccessedAt: access.AccessedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &response.ListShareAccessesResponseDTO{
+		Accesses:   accessDTOs,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}, nil
+}
+
+// ResolveShareLink checks a share link and issues short-lived download URLs for its files.
+// Each successful resolution counts as one download. Every attempt on an existing link is logged.
+func (u *ShareLinkUseCase) ResolveShareLink(c context.Context, token, password, ipAddress, userAgent string) (*response.ResolveShareLinkResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	link, err := u.Repo.GetShareLinkByTokenHash(ctx, hashShareToken(token))
+	if err != nil {
+		return nil, lookupError("share link", err)
+	}
+
+	logAccess := func(outcome entity.ShareAccessOutcome) {
+		if len(userAgent) > 255 {
+			userAgent = userAgent[:255]
+		}
+		access := &entity.ShareLinkAccess{ShareLinkID: link.ID, Outcome: outcome, IPAddress: ipAddress, UserAgent: userAgent}
+		if err := u.Repo.LogAccess(ctx, access); err != nil {
+			fmt.Printf("Warning: failed to log share link access: %v\n", err)
+		}
+	}
+
+	now := time.Now()
+	if outcome := shareLinkStatus(link, now); outcome != entity.ShareAccessGranted {
+		logAccess(outcome)
+		return nil, sharedErrors.Gone(fmt.Sprintf("share link is no longer available: %s", outcome))
+	}
+
+	if err := checkSharePassword(link.PasswordHash, password); err != nil {
+		if errors.Is(err, ErrShareLinkPasswordRequired) {
+			logAccess(entity.ShareAccessPasswordRequired)
+		} else {
+			logAccess(entity.ShareAccessWrongPassword)
+		}
+		return nil, err
+	}
+
+	fileIDs := make([]uint, len(link.Files))
+	for i, file := range link.Files {
+		fileIDs[i] = file.FileID
+	}
+	files, err := u.Repo.GetLiveFiles(ctx, link.UserID, fileIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared files: %w", err)
+	}
+	if len(files) == 0 {
+		logAccess(entity.ShareAccessNoFiles)
+		return nil, sharedErrors.NotFound("shared files not found")
+	}
+
+	ok, err := u.Repo.ConsumeDownload(ctx, link.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count share link download: %w", err)
+	}
+	if !ok {
+		// Another request used the last download or the link changed since it was loaded
+		logAccess(entity.ShareAccessLimitReached)
+		return nil, sharedErrors.Gone(fmt.Sprintf("share link is no longer available: %s", entity.ShareAccessLimitReached))
+	}
+	link.DownloadCount++
+
+	sharedFiles := make([]response.SharedFileDTO, len(files))
+	for i, file := range files {
+		// Downloads are saved under the current file name, not the name in the S3 key
+		downloadURL, err := u.Repo.GeneratePresignedDownloadURLWithFilename(ctx, file.S3Key, file.FileName, ShareDownloadURLExpiration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate download URL: %w", err)
+		}
+
+		thumbnailURL := ""
+		if file.ThumbnailKey != "" {
+			thumbnailURL, err = u.Repo.GeneratePresignedDownloadURL(ctx, file.ThumbnailKey, ShareDownloadURLExpiration)
+			if err != nil {
+				// Log error but don't fail the entire request
+				thumbnailURL = ""
+			}
+		}
+
+		sharedFiles[i] = response.SharedFileDTO{
+			ID:           file.ID,
+			FileName:     file.FileName,
+			FileType:     string(file.FileType),
+			ContentType:  file.ContentType,
+			FileSize:     file.FileSize,
+			DownloadURL:  downloadURL,
+			ThumbnailURL: thumbnailURL,
+		}
+	}
+	logAccess(entity.ShareAccessGranted)
+
+	resp := &response.ResolveShareLinkResponseDTO{
+		Files:        sharedFiles,
+		URLExpiresAt: now.Add(ShareDownloadURLExpiration).Format(time.RFC3339),
+	}
+	if link.ExpiresAt != nil {
+		resp.ExpiresAt = link.ExpiresAt.Format(time.RFC3339)
+	}
+	if link.MaxDownloads != nil {
+		remaining := *link.MaxDownloads - link.DownloadCount
+		resp.RemainingDownloads = &remaining
+	}
+	return resp, nil
+}
+
+// newShareToken returns a random URL-safe token and the SHA-256 hex stored in its place
+func newShareToken() (string, string, error) {
+	buf := make([]byte, ShareTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashShareToken(token), nil
+}
+
+func hashShareToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// validateSharePassword rejects passwords bcrypt cannot hash; the request limit counts characters, not bytes
+func validateSharePassword(password string) error {
+	if len(password) > MaxSharePasswordBytes {
+		return sharedErrors.BadRequest(fmt.Sprintf("invalid password: longer than %d bytes", MaxSharePasswordBytes))
+	}
+	return nil
+}
+
+// checkSharePassword returns ErrShareLinkPasswordRequired or ErrShareLinkWrongPassword unless the password opens the link
+func checkSharePassword(passwordHash, password string) error {
+	if passwordHash == "" {
+		return nil
+	}
+	if password == "" {
+		return ErrShareLinkPasswordRequired
+	}
+	if bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)) != nil {
+		return ErrShareLinkWrongPassword
+	}
+	return nil
+}
+
+// shareLinkStatus reports whether a share link can be used at now, or why not
+func shareLinkStatus(link *entity.ShareLink, now time.Time) entity.ShareAccessOutcome {
+	switch {
+	case link.RevokedAt != nil:
+		return entity.ShareAccessRevoked
+	case link.ExpiresAt != nil && !link.ExpiresAt.After(now):
+		return entity.ShareAccessExpired
+	case link.MaxDownloads != nil && link.DownloadCount >= *link.MaxDownloads:
+		return entity.ShareAccessLimitReached
+	default:
+		return entity.ShareAccessGranted
+	}
+}
+
+func toShareLinkDTO(link *entity.ShareLink, now time.Time) response.ShareLinkDTO {
+	fileIDs := make([]uint, len(link.Files))
+	for i, file := range link.Files {
+		fileIDs[i] = file.FileID
+	}
+
+	status := string(shareLinkStatus(link, now))
+	if status == string(entity.ShareAccessGranted) {
+		status = "active"
+	}
+
+	dto := response.ShareLinkDTO{
+		ID:            link.ID,
+		FileIDs:       fileIDs,
+		HasPassword:   link.PasswordHash != "",
+		MaxDownloads:  link.MaxDownloads,
+		DownloadCount: link.DownloadCount,
+		Status:        status,
+		CreatedAt:     link.CreatedAt.Format(time.RFC3339),
+	}
+	if link.ExpiresAt != nil {
+		dto.ExpiresAt = link.ExpiresAt.Format(time.RFC3339)
+	}
+	if link.RevokedAt != nil {
+		dto.RevokedAt = link.RevokedAt.Format(time.RFC3339)
+	}
+	return dto
+}
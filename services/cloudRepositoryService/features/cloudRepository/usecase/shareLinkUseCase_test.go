@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestShareLinkStatus(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+	limit := 3
+
+	tests := []struct {
+		name string
+		link entity.ShareLink
+		want entity.ShareAccessOutcome
+	}{
+		{name: "no restrictions", link: entity.ShareLink{}, want: entity.ShareAccessGranted},
+		{name: "not expired yet", link: entity.ShareLink{ExpiresAt: &future}, want: entity.ShareAccessGranted},
+		{name: "downloads left", link: entity.ShareLink{MaxDownloads: &limit, DownloadCount: 2}, want: entity.ShareAccessGranted},
+		{name: "expired", link: entity.ShareLink{ExpiresAt: &past}, want: entity.ShareAccessExpired},
+		{name: "expires now", link: entity.ShareLink{ExpiresAt: &now}, want: entity.ShareAccessExpired},
+		{name: "limit reached", link: entity.ShareLink{MaxDownloads: &limit, DownloadCount: 3}, want: entity.ShareAccessLimitReached},
+		{name: "revoked wins", link: entity.ShareLink{RevokedAt: &past, ExpiresAt: &past}, want: entity.ShareAccessRevoked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shareLinkStatus(&tt.link, now); got != tt.want {
+				t.Errorf("shareLinkStatus() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewShareToken(t *testing.T) {
+	token, tokenHash, err := newShareToken()
+	if err != nil {
+		t.Fatalf("newShareToken() error = %v", err)
+	}
+	if len(token) != 43 {
+		t.Errorf("len(token) = %d, want 43", len(token))
+	}
+	if tokenHash != hashShareToken(token) || len(tokenHash) != 64 {
+		t.Errorf("token hash = %q, want the SHA-256 hex of the token", tokenHash)
+	}
+
+	other, _, err := newShareToken()
+	if err != nil {
+		t.Fatalf("newShareToken() error = %v", err)
+	}
+	if other == token {
+		t.Error("newShareToken() returned the same token twice")
+	}
+}
+
+func TestValidateSharePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  bool
+	}{
+		{name: "no password", password: ""},
+		{name: "72 bytes", password: strings.Repeat("a", 72)},
+		{name: "73 bytes", password: strings.Repeat("a", 73), wantErr: true},
+		{name: "24 korean characters", password: strings.Repeat("비", 24)},
+		{name: "25 korean characters are 75 bytes", password: strings.Repeat("비", 25), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSharePassword(tt.password)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateSharePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var appErr *sharedErrors.AppError
+			if err != nil && (!errors.As(err, &appErr) || appErr.HTTPStatus != http.StatusBadRequest) {
+				t.Errorf("validateSharePassword() error = %v, want a bad request", err)
+			}
+		})
+	}
+}
+
+func TestCheckSharePassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("비밀번호"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("GenerateFromPassword() error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		passwordHash string
+		password     string
+		want         error
+	}{
+		{name: "unprotected link", passwordHash: "", password: ""},
+		{name: "unprotected link ignores password", passwordHash: "", password: "anything"},
+		{name: "missing password", passwordHash: string(hash), password: "", want: ErrShareLinkPasswordRequired},
+		{name: "wrong password", passwordHash: string(hash), password: "password", want: ErrShareLinkWrongPassword},
+		{name: "right password", passwordHash: string(hash), password: "비밀번호"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkSharePassword(tt.passwordHash, tt.password); !errors.Is(err, tt.want) {
+				t.Errorf("checkSharePassword() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	// DefaultSimilarDistance is how many of the 64 perceptual hash bits may differ between similar images
+	DefaultSimilarDistance = 6
+	// DefaultSimilarFilesLimit is how many similar files are returned by default
+	DefaultSimilarFilesLimit = 20
+	// DefaultSimilarGroupsLimit is how many similar groups are returned by default
+	DefaultSimilarGroupsLimit = 50
+	// SimilarGroupsMaxFiles bounds how many of the user's most recent images are clustered for the report
+	SimilarGroupsMaxFiles = 10000
+)
+
+type SimilarImageUseCase struct {
+	Repo           _interface.ISimilarImageRepository
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewSimilarImageUseCase(repo _interface.ISimilarImageRepository, authorizer _interface.IFileAuthorizer, timeout time.Duration) _interface.ISimilarImageUseCase {
+	return &SimilarImageUseCase{
+		Repo:           repo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// ListSimilarFiles returns the caller's images that look like the given image, closest first.
+// The image itself may be shared with the caller; only the caller's own files are searched.
+func (u *SimilarImageUseCase) ListSimilarFiles(c context.Context, userID, fileID uint, req *request.SimilarFilesRequestDTO) (*response.ListSimilarFilesResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	maxDistance := req.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = DefaultSimilarDistance
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultSimilarFilesLimit
+	}
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionView); err != nil {
+		return nil, err
+	}
+	if file.FileType != entity.FileTypeImage {
+		return nil, sharedErrors.BadRequest("invalid file: only images can be compared")
+	}
+	if file.PerceptualHash == nil {
+		if file.AnalysisStatus == entity.AnalysisStatusPending {
+			return nil, sharedErrors.Conflict("conflict: the image has not been analyzed yet, try again shortly")
+		}
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid file: the image could not be analyzed (status: %s)", file.AnalysisStatus))
+	}
+
+	matches, err := u.Repo.GetSimilarImages(ctx, userID, file.ID, *file.PerceptualHash, maxDistance, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar images: %w", err)
+	}
+
+	files := make([]response.SimilarFileDTO, len(matches))
+	for i, match := range matches {
+		files[i] = u.toSimilarFileDTO(ctx, &match.File, match.Distance)
+	}
+
+	return &response.ListSimilarFilesResponseDTO{
+		FileID:      file.ID,
+		MaxDistance: maxDistance,
+		Files:       files,
+	}, nil
+}
+
+// ListSimilarGroups clusters the caller's most recent images by perceptual hash into groups of similar shots,
+// suggesting one file to keep per group. The other files can be removed with the bulk delete endpoint.
+func (u *SimilarImageUseCase) ListSimilarGroups(c context.Context, userID uint, req *request.SimilarGroupsRequestDTO) (*response.SimilarGroupsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	maxDistance := req.MaxDistance
+	if maxDistance == 0 {
+		maxDistance = DefaultSimilarDistance
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = DefaultSimilarGroupsLimit
+	}
+
+	files, err := u.Repo.GetAnalyzedImages(ctx, userID, SimilarGroupsMaxFiles+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get images: %w", err)
+	}
+	truncated := len(files) > SimilarGroupsMaxFiles
+	if truncated {
+		files = files[:SimilarGroupsMaxFiles]
+	}
+
+	groups := clusterSimilarImages(files, maxDistance)
+
+	var reclaimable int64
+	for _, group := range groups {
+		reclaimable += group.ReclaimableSize
+	}
+	totalGroups := len(groups)
+	if len(groups) > limit {
+		groups = groups[:limit]
+	}
+
+	groupDTOs := make([]response.SimilarGroupDTO, len(groups))
+	for i, group := range groups {
+		fileDTOs := make([]response.SimilarFileDTO, len(group.Files))
+		for j := range group.Files {
+			fileDTOs[j] = u.toSimilarFileDTO(ctx, &group.Files[j], group.Distances[j])
+		}
+		groupDTOs[i] = response.SimilarGroupDTO{
+			KeepFileID:      group.Files[0].ID,
+			ReclaimableSize: group.ReclaimableSize,
+			Files:           fileDTOs,
+		}
+	}
+
+	return &response.SimilarGroupsResponseDTO{
+		MaxDistance:     maxDistance,
+		Groups:          groupDTOs,
+		TotalGroups:     totalGroups,
+		ReclaimableSize: reclaimable,
+		ScannedFiles:    len(files),
+		Truncated:       truncated,
+	}, nil
+}
+
+func (u *SimilarImageUseCase) toSimilarFileDTO(ctx context.Context, file *entity.CloudFile, distance int) response.SimilarFileDTO {
+	thumbnailURL := ""
+	if file.ThumbnailKey != "" {
+		var err error
+		thumbnailURL, err = u.Repo.GeneratePresignedDownloadURL(ctx, file.ThumbnailKey, 1*time.Hour)
+		if err != nil {
+			// Log error but don't fail the entire request
+			thumbnailURL = ""
+		}
+	}
+
+	return response.SimilarFileDTO{
+		ID:           file.ID,
+		FileName:     file.FileName,
+		ContentType:  file.ContentType,
+		FileSize:     file.FileSize,
+		ThumbnailURL: thumbnailURL,
+		Distance:     distance,
+		CreatedAt:    file.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// similarGroup is a cluster of similar images, the file suggested to keep first
+type similarGroup struct {
+	Files           []entity.CloudFile
+	Distances       []int // Hamming distance of each file to the kept file
+	ReclaimableSize int64 // Size of every file but the kept one
+}
+
+// clusterSimilarImages groups files whose perceptual hashes are within maxDistance bits of the file kept in the group.
+// Transitive clusters can chain images that look nothing alike, so each one is split: its largest file (then the
+// oldest) is kept together with the files within maxDistance of it, and the rest is split again the same way.
+// The kept file comes first and the others follow by distance to it; groups are ordered by the space deleting
+// the others would free.
+func clusterSimilarImages(files []entity.CloudFile, maxDistance int) []similarGroup {
+	hashes := make([]uint64, 0, len(files))
+	analyzed := make([]entity.CloudFile, 0, len(files))
+	for _, file := range files {
+		if file.PerceptualHash != nil {
+			hashes = append(hashes, *file.PerceptualHash)
+			analyzed = append(analyzed, file)
+		}
+	}
+
+	var groups []similarGroup
+	for _, remaining := range groupSimilarHashes(hashes, maxDistance) {
+		for len(remaining) >= 2 {
+			keep := remaining[0]
+			for _, i := range remaining[1:] {
+				if keepsOver(&analyzed[i], &analyzed[keep]) {
+					keep = i
+				}
+			}
+
+			var others, rest []int
+			for _, i := range remaining {
+				switch {
+				case i == keep:
+				case hammingDistance(hashes[i], hashes[keep]) <= maxDistance:
+					others = append(others, i)
+				default:
+					rest = append(rest, i)
+				}
+			}
+			remaining = rest
+			if len(others) == 0 {
+				continue
+			}
+
+			sort.SliceStable(others, func(a, b int) bool {
+				distA, distB := hammingDistance(hashes[others[a]], hashes[keep]), hammingDistance(hashes[others[b]], hashes[keep])
+				if distA != distB {
+					return distA < distB
+				}
+				return analyzed[others[a]].ID < analyzed[others[b]].ID
+			})
+
+			group := similarGroup{
+				Files:     []entity.CloudFile{analyzed[keep]},
+				Distances: []int{0},
+			}
+			for _, i := range others {
+				group.Files = append(group.Files, analyzed[i])
+				group.Distances = append(group.Distances, hammingDistance(hashes[i], hashes[keep]))
+				group.ReclaimableSize += analyzed[i].FileSize
+			}
+			groups = append(groups, group)
+		}
+	}
+
+	sort.SliceStable(groups, func(a, b int) bool {
+		if groups[a].ReclaimableSize != groups[b].ReclaimableSize {
+			return groups[a].ReclaimableSize > groups[b].ReclaimableSize
+		}
+		return groups[a].Files[0].ID < groups[b].Files[0].ID
+	})
+	return groups
+}
+
+// keepsOver reports whether candidate is a better file to keep than current: larger, then older
+func keepsOver(candidate, current *entity.CloudFile) bool {
+	if candidate.FileSize != current.FileSize {
+		return candidate.FileSize > current.FileSize
+	}
+	if !candidate.CreatedAt.Equal(current.CreatedAt) {
+		return candidate.CreatedAt.Before(current.CreatedAt)
+	}
+	return candidate.ID < current.ID
+}
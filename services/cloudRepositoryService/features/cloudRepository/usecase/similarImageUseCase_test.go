@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+)
+
+func TestClusterSimilarImages(t *testing.T) {
+	hash := func(h uint64) *uint64 { return &h }
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	files := []entity.CloudFile{
+		{ID: 1, FileSize: 100, PerceptualHash: hash(0), CreatedAt: base},
+		{ID: 2, FileSize: 300, PerceptualHash: hash(0b11), CreatedAt: base.Add(time.Hour)},
+		{ID: 3, FileSize: 300, PerceptualHash: hash(0b1), CreatedAt: base.Add(2 * time.Hour)},
+		{ID: 4, FileSize: 500, PerceptualHash: hash(^uint64(0)), CreatedAt: base},
+		{ID: 5, FileSize: 50, PerceptualHash: hash(^uint64(0) ^ 1), CreatedAt: base},
+		{ID: 6, FileSize: 1000, PerceptualHash: hash(0xFFFF_0000_FFFF_0000), CreatedAt: base},
+		{ID: 7, FileSize: 1000},
+	}
+
+	groups := clusterSimilarImages(files, 4)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+
+	// Same size: the older file 2 is kept; files follow by distance to it
+	first := groups[0]
+	if ids := fileIDs(first.Files); !reflect.DeepEqual(ids, []uint{2, 3, 1}) {
+		t.Errorf("first group files = %v, want [2 3 1]", ids)
+	}
+	if want := []int{0, 1, 2}; !reflect.DeepEqual(first.Distances, want) {
+		t.Errorf("first group distances = %v, want %v", first.Distances, want)
+	}
+	if first.ReclaimableSize != 400 {
+		t.Errorf("first group reclaimable = %d, want 400", first.ReclaimableSize)
+	}
+
+	second := groups[1]
+	if ids := fileIDs(second.Files); !reflect.DeepEqual(ids, []uint{4, 5}) {
+		t.Errorf("second group files = %v, want [4 5]", ids)
+	}
+	if second.ReclaimableSize != 50 {
+		t.Errorf("second group reclaimable = %d, want 50", second.ReclaimableSize)
+	}
+}
+
+func TestClusterSimilarImagesSplitsChains(t *testing.T) {
+	hash := func(h uint64) *uint64 { return &h }
+	// Each hash is 2 bits from the next, so the transitive cluster spans 6 bits
+	files := []entity.CloudFile{
+		{ID: 1, FileSize: 400, PerceptualHash: hash(0)},
+		{ID: 2, FileSize: 100, PerceptualHash: hash(0b11)},
+		{ID: 3, FileSize: 300, PerceptualHash: hash(0b1111)},
+		{ID: 4, FileSize: 200, PerceptualHash: hash(0b111111)},
+	}
+
+	groups := clusterSimilarImages(files, 2)
+	var got [][]uint
+	for _, group := range groups {
+		got = append(got, fileIDs(group.Files))
+		for i, distance := range group.Distances {
+			if distance > 2 {
+				t.Errorf("file %d is %d bits from the kept file %d, want at most 2", group.Files[i].ID, distance, group.Files[0].ID)
+			}
+		}
+	}
+	if want := [][]uint{{3, 4}, {1, 2}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("groups = %v, want %v", got, want)
+	}
+}
+
+func fileIDs(files []entity.CloudFile) []uint {
+	ids := make([]uint, len(files))
+	for i, file := range files {
+		ids[i] = file.ID
+	}
+	return ids
+}
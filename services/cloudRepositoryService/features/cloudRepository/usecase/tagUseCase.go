@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+type TagUseCase struct {
+	TagRepo        _interface.ITagRepository
+	FileRepo       _interface.IDownloadCloudRepositoryRepository // For file validation
+	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	Authorizer     _interface.IFileAuthorizer
+	ContextTimeout time.Duration
+}
+
+func NewTagUseCase(
+	tagRepo _interface.ITagRepository,
+	fileRepo _interface.IDownloadCloudRepositoryRepository,
+	statsRepo _interface.IUserStatsCloudRepositoryRepository,
+	authorizer _interface.IFileAuthorizer,
+	timeout time.Duration,
+) _interface.ITagUseCase {
+	return &TagUseCase{
+		TagRepo:        tagRepo,
+		FileRepo:       fileRepo,
+		StatsRepo:      statsRepo,
+		Authorizer:     authorizer,
+		ContextTimeout: timeout,
+	}
+}
+
+// ListTags lists the user's tags by name with the number of files each one is attached to
+func (u *TagUseCase) ListTags(c context.Context, userID uint) (*response.ListTagsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tags, err := u.TagRepo.GetUserTags(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tagDTOs := make([]response.TagSummaryDTO, len(tags))
+	for i, tag := range tags {
+		tagDTOs[i] = response.TagSummaryDTO{
+			ID:        tag.ID,
+			Name:      tag.Name,
+			FileCount: tag.FileCount,
+			CreatedAt: tag.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &response.ListTagsResponseDTO{Tags: tagDTOs}, nil
+}
+
+// RenameTag renames a tag. Renaming to the name of another tag is a conflict; merge the tags instead.
+func (u *TagUseCase) RenameTag(c context.Context, userID, tagID uint, req request.RenameTagRequestDTO) (*response.TagSummaryDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, sharedErrors.BadRequest("invalid tag name")
+	}
+
+	tag, err := u.getTag(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != tag.Name {
+		existing, err := u.TagRepo.GetTagByName(ctx, userID, name)
+		if err == nil && existing.ID != tag.ID {
+			return nil, sharedErrors.Conflict(fmt.Sprintf("tag conflict: %s already exists, merge the tags instead", name))
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check tag name: %w", err)
+		}
+
+		if err := u.TagRepo.RenameTag(ctx, tag.ID, name); err != nil {
+			return nil, fmt.Errorf("failed to rename tag: %w", err)
+		}
+		tag.Name = name
+		u.logTagActivity(ctx, userID, nil, entity.ActivityTypeTagRename, name)
+	}
+
+	return u.toTagSummaryDTO(ctx, tag)
+}
+
+// MergeTag moves every file of a tag to the target tag and deletes the merged tag
+func (u *TagUseCase) MergeTag(c context.Context, userID, tagID uint, req request.MergeTagRequestDTO) (*response.TagSummaryDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if tagID == req.TargetTagID {
+		return nil, sharedErrors.BadRequest("invalid merge: a tag cannot be merged into itself")
+	}
+
+	source, err := u.getTag(ctx, userID, tagID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := u.getTag(ctx, userID, req.TargetTagID)
+	if err != nil {
+		return nil, fmt.Errorf("target %w", err)
+	}
+
+	if err := u.TagRepo.MergeTags(ctx, source.ID, target.ID); err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+	u.logTagActivity(ctx, userID, nil, entity.ActivityTypeTagMerge, source.Name)
+
+	return u.toTagSummaryDTO(ctx, target)
+}
+
+// DeleteTag removes a tag from every file and deletes it; the files are kept
+func (u *TagUseCase) DeleteTag(c context.Context, userID, tagID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	tag, err := u.getTag(ctx, userID, tagID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.TagRepo.DeleteTag(ctx, tag.ID); err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	u.logTagActivity(ctx, userID, nil, entity.ActivityTypeTagDel, tag.Name)
+
+	return nil
+}
+
+// AddFileTags adds tags to an existing file, creating missing tags.
+// Tags belong to the file owner, so users with edit permission tag shared files with the owner's tags.
+func (u *TagUseCase) AddFileTags(c context.Context, userID, fileID uint, req request.AddFileTagsRequestDTO) (*response.FileTagsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	names := normalizeTagNames(req.Tags)
+	if len(names) == 0 {
+		return nil, sharedErrors.BadRequest("invalid tags: at least one tag name is required")
+	}
+
+	file, err := u.getEditableFile(ctx, userID, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := u.TagRepo.FindOrCreateTags(ctx, file.UserID, names)
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make([]uint, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+	if err := u.TagRepo.AddFileTags(ctx, file.ID, tagIDs); err != nil {
+		return nil, fmt.Errorf("failed to add tags: %w", err)
+	}
+	for _, tag := range tags {
+		u.logTagActivity(ctx, userID, &file.ID, entity.ActivityTypeTagAdd, tag.Name)
+	}
+
+	return u.fileTagsResponse(ctx, file.ID)
+}
+
+// RemoveFileTag removes a tag from a file; the tag itself is kept
+func (u *TagUseCase) RemoveFileTag(c context.Context, userID, fileID, tagID uint) (*response.FileTagsResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.getEditableFile(ctx, userID, fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := u.getTag(ctx, file.UserID, tagID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.TagRepo.RemoveFileTag(ctx, file.ID, tag.ID); err != nil {
+		return nil, fmt.Errorf("failed to remove tag: %w", err)
+	}
+	u.logTagActivity(ctx, userID, &file.ID, entity.ActivityTypeTagDel, tag.Name)
+
+	return u.fileTagsResponse(ctx, file.ID)
+}
+
+// getTag loads a tag of the user
+func (u *TagUseCase) getTag(ctx context.Context, userID, tagID uint) (*entity.Tag, error) {
+	tag, err := u.TagRepo.GetTag(ctx, userID, tagID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, sharedErrors.NotFound("tag not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+	return tag, nil
+}
+
+// getEditableFile loads a file the user may change the tags of
+func (u *TagUseCase) getEditableFile(ctx context.Context, userID, fileID uint) (*entity.CloudFile, error) {
+	file, err := u.FileRepo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, sharedErrors.NotFound("file not found")
+	}
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionEdit); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (u *TagUseCase) fileTagsResponse(ctx context.Context, fileID uint) (*response.FileTagsResponseDTO, error) {
+	tags, err := u.TagRepo.GetFileTags(ctx, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file tags: %w", err)
+	}
+
+	tagDTOs := make([]response.TagDTO, len(tags))
+	for i, tag := range tags {
+		tagDTOs[i] = response.TagDTO{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+
+	return &response.FileTagsResponseDTO{
+		FileID: fileID,
+		Tags:   tagDTOs,
+	}, nil
+}
+
+func (u *TagUseCase) toTagSummaryDTO(ctx context.Context, tag *entity.Tag) (*response.TagSummaryDTO, error) {
+	count, err := u.TagRepo.GetTagFileCount(ctx, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag files: %w", err)
+	}
+
+	return &response.TagSummaryDTO{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		FileCount: count,
+		CreatedAt: tag.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (u *TagUseCase) logTagActivity(ctx context.Context, userID uint, fileID *uint, activityType entity.ActivityType, tagName string) {
+	if u.StatsRepo == nil {
+		return
+	}
+	activity := &entity.ActivityLog{
+		UserID:       userID,
+		FileID:       fileID,
+		ActivityType: activityType,
+		TagName:      tagName,
+	}
+	_ = u.StatsRepo.LogActivity(ctx, activity) // Don't fail on logging error
+}
+
+// normalizeTagNames trims tag names and drops empty and duplicate ones, keeping the first occurrence
+func normalizeTagNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+	return normalized
+}
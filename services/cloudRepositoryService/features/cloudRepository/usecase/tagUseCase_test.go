@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+	"gorm.io/gorm"
+)
+
+func TestNormalizeTagNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{"trims names", []string{" beach ", "sunset"}, []string{"beach", "sunset"}},
+		{"drops empty names", []string{"", "  ", "beach"}, []string{"beach"}},
+		{"drops duplicates after trimming", []string{"beach", "beach ", "sunset", "beach"}, []string{"beach", "sunset"}},
+		{"keeps case", []string{"Beach", "beach"}, []string{"Beach", "beach"}},
+		{"empty input", nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeTagNames(tt.input); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeTagNames(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTagRepository keeps tags and their files in memory
+type fakeTagRepository struct {
+	tags  map[uint]*entity.Tag
+	files map[uint]map[uint]bool // Tag ID to the IDs of its files
+}
+
+func newFakeTagRepository(tags ...entity.Tag) *fakeTagRepository {
+	r := &fakeTagRepository{tags: map[uint]*entity.Tag{}, files: map[uint]map[uint]bool{}}
+	for i := range tags {
+		r.tags[tags[i].ID] = &tags[i]
+		r.files[tags[i].ID] = map[uint]bool{}
+	}
+	return r
+}
+
+func (r *fakeTagRepository) GetUserTags(ctx context.Context, userID uint) ([]entity.TagFileCount, error) {
+	var tags []entity.TagFileCount
+	for _, tag := range r.tags {
+		if tag.UserID == userID {
+			tags = append(tags, entity.TagFileCount{ID: tag.ID, Name: tag.Name, FileCount: int64(len(r.files[tag.ID])), CreatedAt: tag.CreatedAt})
+		}
+	}
+	return tags, nil
+}
+
+func (r *fakeTagRepository) GetTagFileCount(ctx context.Context, tagID uint) (int64, error) {
+	return int64(len(r.files[tagID])), nil
+}
+
+func (r *fakeTagRepository) GetTag(ctx context.Context, userID, tagID uint) (*entity.Tag, error) {
+	tag, ok := r.tags[tagID]
+	if !ok || tag.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *tag
+	return &found, nil
+}
+
+func (r *fakeTagRepository) GetTagByName(ctx context.Context, userID uint, name string) (*entity.Tag, error) {
+	for _, tag := range r.tags {
+		if tag.UserID == userID && tag.Name == name {
+			found := *tag
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeTagRepository) RenameTag(ctx context.Context, tagID uint, name string) error {
+	r.tags[tagID].Name = name
+	return nil
+}
+
+func (r *fakeTagRepository) MergeTags(ctx context.Context, sourceID, targetID uint) error {
+	for fileID := range r.files[sourceID] {
+		r.files[targetID][fileID] = true
+	}
+	return r.DeleteTag(ctx, sourceID)
+}
+
+func (r *fakeTagRepository) DeleteTag(ctx context.Context, tagID uint) error {
+	delete(r.tags, tagID)
+	delete(r.files, tagID)
+	return nil
+}
+
+func (r *fakeTagRepository) FindOrCreateTags(ctx context.Context, userID uint, names []string) ([]entity.Tag, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *fakeTagRepository) AddFileTags(ctx context.Context, fileID uint, tagIDs []uint) error {
+	for _, tagID := range tagIDs {
+		r.files[tagID][fileID] = true
+	}
+	return nil
+}
+
+func (r *fakeTagRepository) RemoveFileTag(ctx context.Context, fileID, tagID uint) error {
+	delete(r.files[tagID], fileID)
+	return nil
+}
+
+func (r *fakeTagRepository) GetFileTags(ctx context.Context, fileID uint) ([]entity.Tag, error) {
+	return nil, errors.New("not implemented")
+}
+
+// newTestTagUseCase returns a tag use case over user 1's tags beach (files 1 and 2), sunset (files 2 and 3)
+// and user 2's tag beach
+func newTestTagUseCase() (*TagUseCase, *fakeTagRepository) {
+	repo := newFakeTagRepository(
+		entity.Tag{ID: 1, UserID: 1, Name: "beach"},
+		entity.Tag{ID: 2, UserID: 1, Name: "sunset"},
+		entity.Tag{ID: 3, UserID: 2, Name: "beach"},
+	)
+	repo.AddFileTags(context.Background(), 1, []uint{1})
+	repo.AddFileTags(context.Background(), 2, []uint{1, 2})
+	repo.AddFileTags(context.Background(), 3, []uint{2})
+	return &TagUseCase{TagRepo: repo, ContextTimeout: time.Second}, repo
+}
+
+// errorStatus returns the HTTP status of an app error, or 0 for any other error
+func errorStatus(err error) int {
+	var appErr *sharedErrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus
+	}
+	return 0
+}
+
+func TestRenameTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint
+		tagID      uint
+		newName    string
+		wantStatus int
+		wantName   string
+	}{
+		{name: "renames", userID: 1, tagID: 1, newName: " sea ", wantName: "sea"},
+		{name: "same name", userID: 1, tagID: 1, newName: "beach", wantName: "beach"},
+		{name: "name of another tag", userID: 1, tagID: 1, newName: "sunset", wantStatus: http.StatusConflict},
+		{name: "blank name", userID: 1, tagID: 1, newName: "  ", wantStatus: http.StatusBadRequest},
+		{name: "tag of another user", userID: 2, tagID: 1, newName: "sea", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo := newTestTagUseCase()
+
+			got, err := u.RenameTag(context.Background(), tt.userID, tt.tagID, request.RenameTagRequestDTO{Name: tt.newName})
+			if tt.wantStatus != 0 {
+				if status := errorStatus(err); status != tt.wantStatus {
+					t.Fatalf("RenameTag() error = %v, want status %d", err, tt.wantStatus)
+				}
+				if repo.tags[1].Name != "beach" {
+					t.Errorf("tag renamed to %q on error", repo.tags[1].Name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RenameTag() error = %v", err)
+			}
+			if got.Name != tt.wantName || got.FileCount != 2 {
+				t.Errorf("RenameTag() = %s with %d files, want %s with 2 files", got.Name, got.FileCount, tt.wantName)
+			}
+			if repo.tags[1].Name != tt.wantName {
+				t.Errorf("stored name = %q, want %q", repo.tags[1].Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestMergeTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint
+		tagID      uint
+		targetID   uint
+		wantStatus int
+	}{
+		{name: "merges", userID: 1, tagID: 1, targetID: 2},
+		{name: "into itself", userID: 1, tagID: 1, targetID: 1, wantStatus: http.StatusBadRequest},
+		{name: "unknown target", userID: 1, tagID: 1, targetID: 9, wantStatus: http.StatusNotFound},
+		{name: "target of another user", userID: 1, tagID: 1, targetID: 3, wantStatus: http.StatusNotFound},
+		{name: "source of another user", userID: 2, tagID: 1, targetID: 3, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo := newTestTagUseCase()
+
+			got, err := u.MergeTag(context.Background(), tt.userID, tt.tagID, request.MergeTagRequestDTO{TargetTagID: tt.targetID})
+			if tt.wantStatus != 0 {
+				if status := errorStatus(err); status != tt.wantStatus {
+					t.Fatalf("MergeTag() error = %v, want status %d", err, tt.wantStatus)
+				}
+				if len(repo.tags) != 3 {
+					t.Errorf("tags = %d after failed merge, want 3", len(repo.tags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeTag() error = %v", err)
+			}
+			if got.ID != tt.targetID || got.FileCount != 3 {
+				t.Errorf("MergeTag() = tag %d with %d files, want tag %d with 3 files", got.ID, got.FileCount, tt.targetID)
+			}
+			if _, ok := repo.tags[tt.tagID]; ok {
+				t.Error("merged tag was not deleted")
+			}
+		})
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     uint
+		tagID      uint
+		wantStatus int
+	}{
+		{name: "deletes", userID: 1, tagID: 1},
+		{name: "unknown tag", userID: 1, tagID: 9, wantStatus: http.StatusNotFound},
+		{name: "tag of another user", userID: 2, tagID: 1, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, repo := newTestTagUseCase()
+
+			err := u.DeleteTag(context.Background(), tt.userID, tt.tagID)
+			if tt.wantStatus != 0 {
+				if status := errorStatus(err); status != tt.wantStatus {
+					t.Fatalf("DeleteTag() error = %v, want status %d", err, tt.wantStatus)
+				}
+				if len(repo.tags) != 3 {
+					t.Errorf("tags = %d after failed delete, want 3", len(repo.tags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DeleteTag() error = %v", err)
+			}
+			if _, ok := repo.tags[tt.tagID]; ok {
+				t.Error("tag was not deleted")
+			}
+			if count, _ := repo.GetTagFileCount(context.Background(), 2); count != 2 {
+				t.Errorf("other tag has %d files, want 2", count)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp" // Register WebP decoder for image.Decode
+)
+
+const (
+	// ThumbnailJPEGQuality is the JPEG quality of generated thumbnails
+	ThumbnailJPEGQuality = 80
+	// MaxThumbnailSourcePixels guards against decompression bombs (about 100 megapixels)
+	MaxThumbnailSourcePixels = 100_000_000
+)
+
+// ThumbnailSizes are the bounding boxes (longest edge in px) generated for every image
+var ThumbnailSizes = []int{256, 1024}
+
+// generatedThumbnail is an encoded JPEG thumbnail
+type generatedThumbnail struct {
+	Size   int
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// generateThumbnails decodes an image and encodes a JPEG thumbnail for each size.
+// Images are never upscaled, so small originals produce thumbnails at their own size.
+func generateThumbnails(source []byte, sizes []int) ([]generatedThumbnail, error) {
+	flattened, err := decodeImage(source)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnails := make([]generatedThumbnail, 0, len(sizes))
+	for _, size := range sizes {
+		resized := imaging.Fit(flattened, size, size, imaging.Lanczos)
+
+		var buf bytes.Buffer
+		if err := imaging.Encode(&buf, resized, imaging.JPEG, imaging.JPEGQuality(ThumbnailJPEGQuality)); err != nil {
+			return nil, fmt.Errorf("failed to encode %dpx thumbnail: %w", size, err)
+		}
+
+		thumbnails = append(thumbnails, generatedThumbnail{
+			Size:   size,
+			Width:  resized.Bounds().Dx(),
+			Height: resized.Bounds().Dy(),
+			Data:   buf.Bytes(),
+		})
+	}
+
+	return thumbnails, nil
+}
+
+// decodeImage decodes an image upright, as it is displayed, with transparency flattened onto white.
+// The pixel count is checked before decoding to guard against decompression bombs.
+func decodeImage(source []byte) (*image.NRGBA, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported image: %w", err)
+	}
+	if config.Width*config.Height > MaxThumbnailSourcePixels {
+		return nil, fmt.Errorf("image too large to decode: %dx%d", config.Width, config.Height)
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(source), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	// Flatten transparency onto white since JPEG has no alpha channel
+	bounds := img.Bounds()
+	return imaging.Overlay(imaging.New(bounds.Dx(), bounds.Dy(), color.White), img, image.Pt(0, 0), 1.0), nil
+}
+
+// generateThumbnailKey returns the S3 key of a file's thumbnail at the given size.
+// Keys are deterministic so regenerating a thumbnail overwrites the previous one.
+func generateThumbnailKey(userID, fileID uint, size int) string {
+	// Format: users/{userID}/thumbnails/{fileID}_{size}.jpg
+	return fmt.Sprintf("users/%d/thumbnails/%d_%d.jpg", userID, fileID, size)
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerateThumbnails(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		wantWidth  []int
+		wantHeight []int
+	}{
+		{name: "landscape", width: 2000, height: 1000, wantWidth: []int{256, 1024}, wantHeight: []int{128, 512}},
+		{name: "portrait", width: 600, height: 1200, wantWidth: []int{128, 512}, wantHeight: []int{256, 1024}},
+		{name: "small image is not upscaled", width: 100, height: 50, wantWidth: []int{100, 100}, wantHeight: []int{50, 50}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnails, err := generateThumbnails(encodePNG(t, tt.width, tt.height), ThumbnailSizes)
+			if err != nil {
+				t.Fatalf("generateThumbnails() error = %v", err)
+			}
+			if len(thumbnails) != len(ThumbnailSizes) {
+				t.Fatalf("got %d thumbnails, want %d", len(thumbnails), len(ThumbnailSizes))
+			}
+			for i, thumbnail := range thumbnails {
+				if thumbnail.Width != tt.wantWidth[i] || thumbnail.Height != tt.wantHeight[i] {
+					t.Errorf("%dpx thumbnail is %dx%d, want %dx%d", thumbnail.Size, thumbnail.Width, thumbnail.Height, tt.wantWidth[i], tt.wantHeight[i])
+				}
+				decoded, err := jpeg.Decode(bytes.NewReader(thumbnail.Data))
+				if err != nil {
+					t.Fatalf("thumbnail is not a valid JPEG: %v", err)
+				}
+				if decoded.Bounds().Dx() != thumbnail.Width {
+					t.Errorf("encoded width %d, want %d", decoded.Bounds().Dx(), thumbnail.Width)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateThumbnailsRejectsNonImage(t *testing.T) {
+	if _, err := generateThumbnails([]byte("not an image"), ThumbnailSizes); err == nil {
+		t.Fatal("expected error for non-image data")
+	}
+}
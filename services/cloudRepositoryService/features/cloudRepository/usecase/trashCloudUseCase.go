@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+)
+
+const (
+	// DefaultTrashRetention is how long deleted files stay in the trash when TRASH_RETENTION_DAYS is not set
+	DefaultTrashRetention = 30 * 24 * time.Hour
+	// TrashPurgeBatchSize limits how many files are loaded per purge query
+	TrashPurgeBatchSize = 100
+)
+
+// TrashRetention returns how long deleted files stay in the trash, read from TRASH_RETENTION_DAYS
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days < 1 {
+		return DefaultTrashRetention
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+type TrashCloudRepositoryUseCase struct {
+	Repo           _interface.ITrashCloudRepositoryRepository
+	Retention      time.Duration
+	ContextTimeout time.Duration
+}
+
+func NewTrashCloudRepositoryUseCase(repo _interface.ITrashCloudRepositoryRepository, retention, timeout time.Duration) _interface.ITrashCloudRepositoryUseCase {
+	return &TrashCloudRepositoryUseCase{
+		Repo:           repo,
+		Retention:      retention,
+		ContextTimeout: timeout,
+	}
+}
+
+// ListTrash lists the user's deleted files with the time each one will be purged
+func (u *TrashCloudRepositoryUseCase) ListTrash(c context.Context, userID uint, req request.ListTrashRequestDTO) (*response.ListTrashResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 || req.PageSize > 100 {
+		req.PageSize = 20
+	}
+
+	files, total, err := u.Repo.GetTrashedFiles(ctx, userID, (req.Page-1)*req.PageSize, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+
+	trashFiles := make([]response.TrashFileDTO, len(files))
+	for i, file := range files {
+		thumbnailURL := ""
+		if file.ThumbnailKey != "" {
+			thumbnailURL, err = u.Repo.GeneratePresignedDownloadURL(ctx, file.ThumbnailKey, 1*time.Hour)
+			if err != nil {
+				// Log error but don't fail the entire request
+				thumbnailURL = ""
+			}
+		}
+
+		trashFiles[i] = response.TrashFileDTO{
+			ID:           file.ID,
+			FileName:     file.FileName,
+			FileType:     string(file.FileType),
+			ContentType:  file.ContentType,
+			FileSize:     file.FileSize,
+			ThumbnailURL: thumbnailURL,
+			CreatedAt:    file.CreatedAt.Format(time.RFC3339),
+			DeletedAt:    file.DeletedAt.Format(time.RFC3339),
+			PurgeAt:      file.DeletedAt.Add(u.Retention).Format(time.RFC3339),
+		}
+	}
+
+	return &response.ListTrashResponseDTO{
+		Files:      trashFiles,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	}, nil
+}
+
+// RestoreFile moves a file out of the trash; it keeps counting against the quota as before
+func (u *TrashCloudRepositoryUseCase) RestoreFile(c context.Context, userID, fileID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	if err := u.Repo.RestoreFile(ctx, userID, fileID); err != nil {
+		return fmt.Errorf("failed to restore file: %w", err)
+	}
+	return nil
+}
+
+// EmptyTrash permanently removes every file in the user's trash.
+// Returns the number of purged files.
+func (u *TrashCloudRepositoryUseCase) EmptyTrash(c context.Context, userID uint) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	purged := 0
+	for {
+		files, err := u.Repo.GetUserTrashedFiles(ctx, userID, TrashPurgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to get trashed files: %w", err)
+		}
+		if len(files) == 0 {
+			return purged, nil
+		}
+
+		n, err := u.purgeFiles(ctx, files)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+}
+
+// PurgeExpiredFiles permanently removes files that have been in the trash longer than the retention period.
+// Returns the number of purged files.
+func (u *TrashCloudRepositoryUseCase) PurgeExpiredFiles(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	deletedBefore := time.Now().Add(-u.Retention)
+	purged := 0
+	for {
+		files, err := u.Repo.GetExpiredTrashedFiles(ctx, deletedBefore, TrashPurgeBatchSize)
+		if err != nil {
+			return purged, fmt.Errorf("failed to get expired trashed files: %w", err)
+		}
+
+		n, err := u.purgeFiles(ctx, files)
+		purged += n
+		if err != nil || len(files) < TrashPurgeBatchSize {
+			return purged, err
+		}
+	}
+}
+
+// purgeFiles purges a batch of trashed files.
+// A batch where any file fails is reported as an error so callers do not fetch the same files again.
+func (u *TrashCloudRepositoryUseCase) purgeFiles(ctx context.Context, files []entity.CloudFile) (int, error) {
+	purged := 0
+	var lastErr error
+	for i := range files {
+		if err := u.purgeFile(ctx, &files[i]); err != nil {
+			fmt.Printf("Warning: failed to purge file %d: %v\n", files[i].ID, err)
+			lastErr = err
+			continue
+		}
+		purged++
+	}
+
+	if lastErr != nil {
+		return purged, fmt.Errorf("failed to purge %d of %d files: %w", len(files)-purged, len(files), lastErr)
+	}
+	return purged, nil
+}
+
+// purgeFile removes the file record first so a concurrent restore cannot end up without content.
+// S3 cleanup is best effort - the reconciliation job deletes objects left behind.
+func (u *TrashCloudRepositoryUseCase) purgeFile(ctx context.Context, file *entity.CloudFile) error {
+	if err := u.Repo.PurgeFile(ctx, file); err != nil {
+		return err
+	}
+
+	keys := []string{file.S3Key}
+	if file.ThumbnailKey != "" {
+		keys = append(keys, file.ThumbnailKey)
+	}
+	for _, thumbnail := range file.Thumbnails {
+		if thumbnail.S3Key != file.ThumbnailKey {
+			keys = append(keys, thumbnail.S3Key)
+		}
+	}
+	for _, version := range file.Versions {
+		keys = append(keys, version.S3Key)
+	}
+	for _, key := range keys {
+		if err := u.Repo.DeleteFromS3(ctx, key); err != nil {
+			fmt.Printf("Warning: failed to delete purged object from S3: %v\n", err)
+		}
+	}
+	return nil
+}
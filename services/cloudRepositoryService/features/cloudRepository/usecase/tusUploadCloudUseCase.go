@@ -0,0 +1,356 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
+)
+
+const (
+	TusVersion = "1.0.0"
+	// TusPartSize is the size of the S3 parts PATCH bodies are split into (also the per-request buffer size)
+	TusPartSize = DefaultMultipartPartSize
+	// TusMaxSize is the largest upload that fits in MaxMultipartParts parts of TusPartSize
+	TusMaxSize = TusPartSize * MaxMultipartParts
+	// TusUploadExpiration is how long a tus upload may stay incomplete before it is terminated
+	TusUploadExpiration = MultipartUploadExpiration
+)
+
+type TusUploadCloudRepositoryUseCase struct {
+	Repo           _interface.ITusUploadCloudRepositoryRepository
+	UploadRepo     _interface.IUploadCloudRepositoryRepository
+	UploadUseCase  _interface.IUploadCloudRepositoryUseCase // Reuses upload verification and commit
+	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
+	ContextTimeout time.Duration
+}
+
+func NewTusUploadCloudRepositoryUseCase(
+	repo _interface.ITusUploadCloudRepositoryRepository,
+	uploadRepo _interface.IUploadCloudRepositoryRepository,
+	uploadUseCase _interface.IUploadCloudRepositoryUseCase,
+	statsRepo _interface.IUserStatsCloudRepositoryRepository,
+	timeout time.Duration,
+) _interface.ITusUploadCloudRepositoryUseCase {
+	return &TusUploadCloudRepositoryUseCase{
+		Repo:           repo,
+		UploadRepo:     uploadRepo,
+		UploadUseCase:  uploadUseCase,
+		StatsRepo:      statsRepo,
+		ContextTimeout: timeout,
+	}
+}
+
+// CreateUpload creates a pending file record and the S3 multipart upload that tus chunks are streamed into
+func (u *TusUploadCloudRepositoryUseCase) CreateUpload(c context.Context, userID uint, req *request.TusCreateUploadRequestDTO) (*response.TusUploadDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	// tus chunks are assembled as an S3 multipart upload, which cannot verify a whole-file hash
+	if req.SHA256 != "" {
+		return nil, sharedErrors.BadRequest("sha256 is only supported for single uploads")
+	}
+
+	if req.FileSize > TusMaxSize {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("file too large for tus upload (max %d bytes)", int64(TusMaxSize)))
+	}
+
+	file, err := newPendingFile(userID, &req.UploadRequestDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	s3UploadID, err := u.Repo.CreateMultipartUpload(ctx, file.S3Key, file.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initiate multipart upload: %w", err)
+	}
+
+	if err := u.UploadRepo.CreateFile(ctx, file); err != nil {
+		_ = u.Repo.AbortMultipartUpload(ctx, file.S3Key, s3UploadID)
+		return nil, fmt.Errorf("failed to create file record: %w", err)
+	}
+	logTagAddActivity(ctx, u.StatsRepo, userID, file.Tags)
+
+	upload := &entity.TusUpload{
+		UserID:       userID,
+		FileID:       file.ID,
+		S3UploadID:   s3UploadID,
+		S3Key:        file.S3Key,
+		UploadLength: file.FileSize,
+		Metadata:     req.Metadata,
+		Status:       entity.MultipartStatusInProgress,
+		ExpiresAt:    time.Now().Add(TusUploadExpiration),
+	}
+	if err := u.Repo.CreateUpload(ctx, upload); err != nil {
+		_ = u.Repo.AbortMultipartUpload(ctx, file.S3Key, s3UploadID)
+		_ = u.UploadRepo.UpdateUploadStatus(ctx, file.ID, entity.UploadStatusPending, entity.UploadStatusFailed)
+		return nil, fmt.Errorf("failed to create tus upload record: %w", err)
+	}
+
+	return newTusUploadResponse(upload), nil
+}
+
+// GetUpload returns the current offset of a tus upload.
+// An upload whose bytes are all stored but whose final commit failed is finished here, so a
+// client that sees offset == length can rely on the file being committed.
+func (u *TusUploadCloudRepositoryUseCase) GetUpload(c context.Context, userID, uploadID uint) (*response.TusUploadDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status == entity.MultipartStatusAborted {
+		return nil, sharedErrors.NotFound("tus upload not found: upload has been terminated")
+	}
+
+	if upload.Status == entity.MultipartStatusInProgress && upload.UploadOffset == upload.UploadLength {
+		if err := u.finishUpload(ctx, userID, upload); err != nil {
+			return nil, err
+		}
+	}
+
+	return newTusUploadResponse(upload), nil
+}
+
+// WriteChunk streams a PATCH body into S3 parts starting at offset.
+// Full parts are uploaded as they fill; a trailing partial part is stored as the incomplete part
+// object and prepended to the next PATCH. The request context bounds the stream instead of
+// ContextTimeout because a single chunk can take minutes.
+func (u *TusUploadCloudRepositoryUseCase) WriteChunk(ctx context.Context, userID, uploadID uint, offset int64, body io.Reader) (*response.TusUploadDTO, error) {
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch upload.Status {
+	case entity.MultipartStatusCompleted:
+		return nil, sharedErrors.Conflict("upload conflict: upload is already complete")
+	case entity.MultipartStatusAborted:
+		return nil, sharedErrors.NotFound("tus upload not found: upload has been terminated")
+	}
+
+	if offset != upload.UploadOffset {
+		return nil, sharedErrors.Conflict(fmt.Sprintf("upload conflict: offset mismatch (expected %d, got %d)", upload.UploadOffset, offset))
+	}
+
+	// Never read past the declared length
+	reader := io.LimitReader(body, upload.UploadLength-upload.UploadOffset)
+
+	// Prepend the bytes left over from the previous PATCH
+	if upload.IncompletePartSize > 0 {
+		incomplete, err := u.Repo.GetIncompletePart(ctx, upload.IncompletePartKey())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load incomplete part: %w", err)
+		}
+		if int64(len(incomplete)) != upload.IncompletePartSize {
+			return nil, sharedErrors.Conflict(fmt.Sprintf("upload conflict: incomplete part has %d bytes, expected %d", len(incomplete), upload.IncompletePartSize))
+		}
+		reader = io.MultiReader(bytes.NewReader(incomplete), reader)
+	}
+
+	buf := make([]byte, TusPartSize)
+	for {
+		// buf always starts at the end of the last uploaded part
+		partStart := upload.UploadOffset - upload.IncompletePartSize
+		n, readErr := io.ReadFull(reader, buf)
+		partEnd := partStart + int64(n)
+
+		if n == len(buf) || (n > 0 && partEnd == upload.UploadLength) {
+			if err := u.writePart(ctx, upload, buf[:n]); err != nil {
+				return nil, err
+			}
+		} else if partEnd > upload.UploadOffset {
+			// The body ended (or the connection dropped) before a full part - keep the bytes for the next PATCH
+			if err := u.writeIncompletePart(ctx, upload, buf[:n]); err != nil {
+				return nil, err
+			}
+		}
+
+		if readErr != nil {
+			// io.EOF / io.ErrUnexpectedEOF end the chunk; other read errors mean the client went away
+			break
+		}
+	}
+
+	if upload.UploadOffset == upload.UploadLength {
+		finishCtx, cancel := context.WithTimeout(ctx, u.ContextTimeout)
+		defer cancel()
+		if err := u.finishUpload(finishCtx, userID, upload); err != nil {
+			return nil, err
+		}
+	}
+
+	return newTusUploadResponse(upload), nil
+}
+
+// TerminateUpload aborts the S3 multipart upload and marks the file as failed (idempotent)
+func (u *TusUploadCloudRepositoryUseCase) TerminateUpload(c context.Context, userID, uploadID uint) error {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	upload, err := u.getOwnedUpload(ctx, userID, uploadID)
+	if err != nil {
+		return err
+	}
+
+	switch upload.Status {
+	case entity.MultipartStatusAborted:
+		return nil
+	case entity.MultipartStatusCompleted:
+		return sharedErrors.Conflict("upload conflict: upload has already been completed")
+	}
+
+	if err := u.Repo.AbortMultipartUpload(ctx, upload.S3Key, upload.S3UploadID); err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return u.markAborted(ctx, upload)
+}
+
+// ExpireTusUploads terminates in-progress tus uploads past their expiry time.
+// Returns the number of terminated uploads.
+func (u *TusUploadCloudRepositoryUseCase) ExpireTusUploads(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	uploads, err := u.Repo.GetExpiredUploads(ctx, time.Now(), MultipartSweepBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired tus uploads: %w", err)
+	}
+
+	expired := 0
+	for i := range uploads {
+		upload := &uploads[i]
+		if err := u.Repo.AbortMultipartUpload(ctx, upload.S3Key, upload.S3UploadID); err != nil {
+			// Log error but still mark the upload aborted so the file is not left pending
+			fmt.Printf("Warning: failed to abort expired tus upload %d: %v\n", upload.ID, err)
+		}
+		if err := u.markAborted(ctx, upload); err != nil {
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// writePart uploads a full (or final) part and advances the offset
+func (u *TusUploadCloudRepositoryUseCase) writePart(ctx context.Context, upload *entity.TusUpload, data []byte) error {
+	partNumber := upload.PartCount + 1
+	if err := u.Repo.UploadPart(ctx, upload.S3Key, upload.S3UploadID, partNumber, data); err != nil {
+		return fmt.Errorf("failed to upload part %d: %w", partNumber, err)
+	}
+
+	hadIncompletePart := upload.IncompletePartSize > 0
+	fromOffset := upload.UploadOffset
+	progress := *upload
+	progress.PartCount = partNumber
+	progress.UploadOffset = upload.UploadOffset - upload.IncompletePartSize + int64(len(data))
+	progress.IncompletePartSize = 0
+	if err := u.Repo.UpdateProgress(ctx, upload.ID, fromOffset, &progress); err != nil {
+		return fmt.Errorf("failed to update upload progress: %w", err)
+	}
+	*upload = progress
+
+	// The incomplete part is now inside an uploaded part
+	if hadIncompletePart {
+		if err := u.Repo.DeleteFromS3(ctx, upload.IncompletePartKey()); err != nil {
+			fmt.Printf("Warning: failed to delete incomplete part of tus upload %d: %v\n", upload.ID, err)
+		}
+	}
+	return nil
+}
+
+// writeIncompletePart stores bytes that do not fill a part yet.
+// It runs even if the client has disconnected so the received bytes are not lost.
+func (u *TusUploadCloudRepositoryUseCase) writeIncompletePart(c context.Context, upload *entity.TusUpload, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c), u.ContextTimeout)
+	defer cancel()
+
+	if err := u.Repo.PutIncompletePart(ctx, upload.IncompletePartKey(), data); err != nil {
+		return fmt.Errorf("failed to store incomplete part: %w", err)
+	}
+
+	fromOffset := upload.UploadOffset
+	progress := *upload
+	progress.UploadOffset = upload.UploadOffset - upload.IncompletePartSize + int64(len(data))
+	progress.IncompletePartSize = int64(len(data))
+	if err := u.Repo.UpdateProgress(ctx, upload.ID, fromOffset, &progress); err != nil {
+		return fmt.Errorf("failed to update upload progress: %w", err)
+	}
+	*upload = progress
+	return nil
+}
+
+// finishUpload assembles the parts in S3, then verifies and commits the file
+func (u *TusUploadCloudRepositoryUseCase) finishUpload(ctx context.Context, userID uint, upload *entity.TusUpload) error {
+	parts, err := u.Repo.ListUploadedParts(ctx, upload.S3Key, upload.S3UploadID)
+	if err != nil {
+		return fmt.Errorf("failed to list uploaded parts: %w", err)
+	}
+	if len(parts) != int(upload.PartCount) {
+		return sharedErrors.Conflict(fmt.Sprintf("upload conflict: %d parts stored, expected %d", len(parts), upload.PartCount))
+	}
+
+	if err := u.Repo.CompleteMultipartUpload(ctx, upload.S3Key, upload.S3UploadID, parts); err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	if err := u.Repo.UpdateUploadStatus(ctx, upload.ID, entity.MultipartStatusInProgress, entity.MultipartStatusCompleted); err != nil {
+		return fmt.Errorf("failed to update upload status: %w", err)
+	}
+	upload.Status = entity.MultipartStatusCompleted
+
+	if _, err := u.UploadUseCase.CompleteUpload(ctx, userID, upload.FileID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getOwnedUpload retrieves a tus upload and checks that the user owns it
+func (u *TusUploadCloudRepositoryUseCase) getOwnedUpload(ctx context.Context, userID, uploadID uint) (*entity.TusUpload, error) {
+	upload, err := u.Repo.GetUploadByID(ctx, uploadID)
+	if err != nil {
+		return nil, lookupError("tus upload", err)
+	}
+	if upload.UserID != userID {
+		return nil, sharedErrors.Forbidden("access denied: you do not own this upload")
+	}
+	return upload, nil
+}
+
+// markAborted marks the upload aborted, removes its incomplete part and fails its pending file
+func (u *TusUploadCloudRepositoryUseCase) markAborted(ctx context.Context, upload *entity.TusUpload) error {
+	if err := u.Repo.UpdateUploadStatus(ctx, upload.ID, entity.MultipartStatusInProgress, entity.MultipartStatusAborted); err != nil {
+		return fmt.Errorf("failed to update upload status: %w", err)
+	}
+	upload.Status = entity.MultipartStatusAborted
+
+	if upload.IncompletePartSize > 0 {
+		if err := u.Repo.DeleteFromS3(ctx, upload.IncompletePartKey()); err != nil {
+			fmt.Printf("Warning: failed to delete incomplete part of tus upload %d: %v\n", upload.ID, err)
+		}
+	}
+	if err := u.UploadRepo.UpdateUploadStatus(ctx, upload.FileID, entity.UploadStatusPending, entity.UploadStatusFailed); err != nil {
+		fmt.Printf("Warning: failed to mark file %d as failed: %v\n", upload.FileID, err)
+	}
+	return nil
+}
+
+func newTusUploadResponse(upload *entity.TusUpload) *response.TusUploadDTO {
+	return &response.TusUploadDTO{
+		UploadID:     upload.ID,
+		FileID:       upload.FileID,
+		UploadOffset: upload.UploadOffset,
+		UploadLength: upload.UploadLength,
+		Metadata:     upload.Metadata,
+		Status:       string(upload.Status),
+	}
+}
@@ -2,7 +2,12 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"mime"
 	"path/filepath"
 	"strings"
 	"time"
@@ -11,12 +16,22 @@ import (
 	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/request"
 	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/response"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+	sharedErrors "github.com/JokerTrickster/joker_backend/shared/errors"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 const (
 	DefaultUploadExpiration = 12 * time.Hour
+	// MaxSingleUploadSize is the S3 limit for a single PUT; larger files must use multipart upload
+	MaxSingleUploadSize = 5 * 1024 * 1024 * 1024
+	// PendingUploadGracePeriod is added to the presigned URL lifetime before a pending upload is expired
+	PendingUploadGracePeriod = 1 * time.Hour
+	// PendingUploadSweepBatchSize limits how many stale uploads are expired per sweep
+	PendingUploadSweepBatchSize = 100
+	// PosterContentType is the content type of the poster frame clients upload for videos
+	PosterContentType = "image/jpeg"
 )
 
 var (
@@ -42,90 +57,147 @@ var (
 type UploadCloudRepositoryUseCase struct {
 	Repo           _interface.IUploadCloudRepositoryRepository
 	StatsRepo      _interface.IUserStatsCloudRepositoryRepository
-	DB             *gorm.DB
+	Processor      _interface.IFileProcessingCloudRepositoryUseCase // Generates thumbnails after commit
+	Authorizer     _interface.IFileAuthorizer
 	ContextTimeout time.Duration
 }
 
-func NewUploadCloudRepositoryUseCase(repo _interface.IUploadCloudRepositoryRepository, statsRepo _interface.IUserStatsCloudRepositoryRepository, db *gorm.DB, timeout time.Duration) _interface.IUploadCloudRepositoryUseCase {
+func NewUploadCloudRepositoryUseCase(repo _interface.IUploadCloudRepositoryRepository, statsRepo _interface.IUserStatsCloudRepositoryRepository, processor _interface.IFileProcessingCloudRepositoryUseCase, authorizer _interface.IFileAuthorizer, timeout time.Duration) _interface.IUploadCloudRepositoryUseCase {
 	return &UploadCloudRepositoryUseCase{
 		Repo:           repo,
 		StatsRepo:      statsRepo,
-		DB:             db,
+		Processor:      processor,
+		Authorizer:     authorizer,
 		ContextTimeout: timeout,
 	}
 }
 
-// RequestUploadURL generates a presigned upload URL and creates a file record
+// RequestUploadURL generates a presigned upload URL and creates a pending file record.
+// The file becomes visible only after CompleteUpload verifies the object in S3.
 func (u *UploadCloudRepositoryUseCase) RequestUploadURL(c context.Context, userID uint, req *request.UploadRequestDTO) (*response.UploadResponseDTO, error) {
 	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
 	defer cancel()
 
-	// Validate content type
-	fileType := entity.FileType(req.FileType)
-	if fileType == entity.FileTypeImage && !AllowedImageTypes[req.ContentType] {
-		return nil, fmt.Errorf("invalid image content type: %s", req.ContentType)
+	// Reject files that cannot be uploaded with a single presigned PUT
+	if req.FileSize > MaxSingleUploadSize {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("file too large for single upload (max %d bytes), use multipart upload", MaxSingleUploadSize))
 	}
-	if fileType == entity.FileTypeVideo && !AllowedVideoTypes[req.ContentType] {
-		return nil, fmt.Errorf("invalid video content type: %s", req.ContentType)
+
+	// Return the existing file when the user already uploaded the same content
+	checksum := strings.ToLower(req.SHA256)
+	if checksum != "" {
+		existing, err := u.Repo.GetCommittedFileBySHA256(ctx, userID, checksum)
+		if err == nil {
+			return &response.UploadResponseDTO{
+				FileID:    existing.ID,
+				S3Key:     existing.S3Key,
+				Duplicate: true,
+			}, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("failed to check for duplicate file: %w", err)
+		}
 	}
 
-	// Generate S3 keys for original and thumbnail
-	s3Key := u.generateS3Key(userID, fileType, req.FileName)
-	thumbnailKey := ""
+	file, err := newPendingFile(userID, req)
+	if err != nil {
+		return nil, err
+	}
+	file.SHA256 = checksum
+	s3Key := file.S3Key
 
-	// Generate thumbnail key for both images and videos
-	if fileType == entity.FileTypeImage || fileType == entity.FileTypeVideo {
-		thumbnailKey = u.generateThumbnailKey(userID, req.FileName)
+	if err := u.Repo.CreateFile(ctx, file); err != nil {
+		return nil, fmt.Errorf("failed to create file record: %w", err)
 	}
+	logTagAddActivity(ctx, u.StatsRepo, userID, file.Tags)
 
-	// Process tags if provided
-	tags := make([]entity.Tag, 0, len(req.Tags))
-	if len(req.Tags) > 0 {
-		for _, tagName := range req.Tags {
-			if tagName == "" {
-				continue
-			}
-			tag := entity.Tag{
-				UserID: userID,
-				Name:   tagName,
-			}
-			// Find or create tag
-			if err := u.DB.WithContext(ctx).Where("user_id = ? AND name = ?", userID, tagName).FirstOrCreate(&tag).Error; err != nil {
-				return nil, fmt.Errorf("failed to process tag %s: %w", tagName, err)
-			}
-			tags = append(tags, tag)
+	// Generate presigned upload URL for original
+	resp := &response.UploadResponseDTO{
+		FileID:    file.ID,
+		S3Key:     s3Key,
+		ExpiresIn: int(DefaultUploadExpiration.Seconds()),
+	}
+	if checksum == "" {
+		resp.UploadURL, err = u.Repo.GeneratePresignedUploadURL(ctx, s3Key, req.ContentType, DefaultUploadExpiration)
+	} else {
+		// S3 rejects the PUT unless the body matches the declared hash
+		checksumBase64 := sha256HexToBase64(checksum)
+		resp.UploadURL, err = u.Repo.GeneratePresignedUploadURLWithChecksum(ctx, s3Key, req.ContentType, checksumBase64, DefaultUploadExpiration)
+		resp.RequiredHeaders = map[string]string{
+			"Content-Type":          req.ContentType,
+			"x-amz-checksum-sha256": checksumBase64,
 		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
+	}
 
-		// Log tag activity
-		if u.StatsRepo != nil {
-			for _, tag := range tags {
-				activity := &entity.ActivityLog{
-					UserID:       userID,
-					ActivityType: entity.ActivityTypeTagAdd,
-					TagName:      tag.Name,
-				}
-				_ = u.StatsRepo.LogActivity(ctx, activity) // Don't fail on logging error
-			}
+	// Videos are thumbnailed from a poster frame the client uploads before completing the upload
+	if file.ThumbnailKey != "" {
+		resp.ThumbnailURL, err = u.Repo.GeneratePresignedUploadURL(ctx, file.ThumbnailKey, PosterContentType, DefaultUploadExpiration)
+		if err != nil {
+			// Log error but don't fail the entire request
+			fmt.Printf("Warning: failed to generate poster upload URL for file %d: %v\n", file.ID, err)
+			resp.ThumbnailURL = ""
+		} else {
+			resp.ThumbnailKey = file.ThumbnailKey
 		}
 	}
 
-	// Create file record in database
-	file := &entity.CloudFile{
-		UserID:       userID,
-		FileName:     req.FileName,
-		S3Key:        s3Key,
-		ThumbnailKey: thumbnailKey,
-		FileType:     fileType,
-		ContentType:  req.ContentType,
-		FileSize:     req.FileSize,
-		Duration:     req.Duration,
-		Tags:         tags,
+	return resp, nil
+}
+
+// CompleteUpload verifies the uploaded object against the pending record and commits it
+func (u *UploadCloudRepositoryUseCase) CompleteUpload(c context.Context, userID, fileID uint) (*response.CompleteUploadResponseDTO, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	file, err := u.Repo.GetFileByID(ctx, fileID)
+	if err != nil {
+		return nil, lookupError("file", err)
 	}
 
-	if err := u.Repo.CreateFile(ctx, file); err != nil {
-		return nil, fmt.Errorf("failed to create file record: %w", err)
+	// Check if user owns the file
+	if err := u.Authorizer.Authorize(ctx, userID, file, entity.FilePermissionOwner); err != nil {
+		return nil, err
+	}
+
+	switch file.UploadStatus {
+	case entity.UploadStatusCommitted:
+		// Idempotent - completing twice returns the committed state
+		return newCompleteUploadResponse(file), nil
+	case entity.UploadStatusFailed:
+		return nil, sharedErrors.Conflict("upload conflict: upload has already failed or expired")
+	}
+
+	object, err := u.Repo.HeadObject(ctx, file.S3Key)
+	if err != nil {
+		if errors.Is(err, sharedAws.ErrObjectNotFound) {
+			return nil, sharedErrors.Conflict("upload conflict: object has not been uploaded yet")
+		}
+		return nil, fmt.Errorf("failed to verify uploaded object: %w", err)
+	}
+
+	if verifyErr := verifyUploadedObject(file, object); verifyErr != nil {
+		u.failUpload(ctx, file)
+		return nil, verifyErr
+	}
+
+	// The declared content type is only a claim - check the leading bytes of the object as well
+	header, err := u.Repo.GetObjectRange(ctx, file.S3Key, 0, min(ContentSniffLength, file.FileSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+	if verifyErr := verifyUploadedContent(file, header); verifyErr != nil {
+		u.failUpload(ctx, file)
+		return nil, verifyErr
 	}
 
+	if err := u.Repo.UpdateUploadStatus(ctx, file.ID, entity.UploadStatusPending, entity.UploadStatusCommitted); err != nil {
+		return nil, fmt.Errorf("failed to commit file: %w", err)
+	}
+	file.UploadStatus = entity.UploadStatusCommitted
+
 	// Log upload activity
 	if u.StatsRepo != nil {
 		activity := &entity.ActivityLog{
@@ -136,34 +208,182 @@ func (u *UploadCloudRepositoryUseCase) RequestUploadURL(c context.Context, userI
 		_ = u.StatsRepo.LogActivity(ctx, activity) // Don't fail on logging error
 	}
 
-	// Generate presigned upload URL for original
-	uploadURL, err := u.Repo.GeneratePresignedUploadURL(ctx, s3Key, req.ContentType, DefaultUploadExpiration)
+	// Thumbnails are generated in the background; the backfill job retries anything missed here
+	if u.Processor != nil {
+		u.Processor.ProcessFileAsync(file.ID)
+	}
+
+	return newCompleteUploadResponse(file), nil
+}
+
+// ExpirePendingUploads marks pending uploads whose presigned URL has expired as failed
+// and removes any objects that were left behind. Returns the number of expired files.
+func (u *UploadCloudRepositoryUseCase) ExpirePendingUploads(c context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(c, u.ContextTimeout)
+	defer cancel()
+
+	createdBefore := time.Now().Add(-(DefaultUploadExpiration + PendingUploadGracePeriod))
+	files, err := u.Repo.GetStalePendingFiles(ctx, createdBefore, PendingUploadSweepBatchSize)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate upload URL: %w", err)
+		return 0, fmt.Errorf("failed to get stale pending files: %w", err)
 	}
 
-	// Generate presigned upload URL for thumbnail if it's an image
-	thumbnailURL := ""
-	if thumbnailKey != "" {
-		thumbnailURL, err = u.Repo.GeneratePresignedUploadURL(ctx, thumbnailKey, req.ContentType, DefaultUploadExpiration)
-		if err != nil {
-			// Log error but don't fail the entire request
-			thumbnailURL = ""
+	expired := 0
+	for i := range files {
+		if u.failUpload(ctx, &files[i]) {
+			expired++
 		}
 	}
 
-	return &response.UploadResponseDTO{
+	return expired, nil
+}
+
+// failUpload marks a pending file as failed and deletes its objects from S3.
+// Returns false if the file was no longer pending.
+func (u *UploadCloudRepositoryUseCase) failUpload(ctx context.Context, file *entity.CloudFile) bool {
+	if err := u.Repo.UpdateUploadStatus(ctx, file.ID, entity.UploadStatusPending, entity.UploadStatusFailed); err != nil {
+		return false
+	}
+	file.UploadStatus = entity.UploadStatusFailed
+
+	// S3 cleanup is best effort - the row is already marked failed
+	if err := u.Repo.DeleteFromS3(ctx, file.S3Key); err != nil {
+		fmt.Printf("Warning: failed to delete failed upload from S3: %v\n", err)
+	}
+	if file.ThumbnailKey != "" {
+		if err := u.Repo.DeleteFromS3(ctx, file.ThumbnailKey); err != nil {
+			fmt.Printf("Warning: failed to delete poster of failed upload from S3: %v\n", err)
+		}
+	}
+	return true
+}
+
+// verifyUploadedObject checks the S3 object against the size and content type declared at request time
+func verifyUploadedObject(file *entity.CloudFile, object *sharedAws.ObjectInfo) error {
+	if object.Size != file.FileSize {
+		return sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: size mismatch (declared %d bytes, uploaded %d bytes)", file.FileSize, object.Size))
+	}
+	if normalizeContentType(object.ContentType) != normalizeContentType(file.ContentType) {
+		return sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: content type mismatch (declared %s, uploaded %s)", file.ContentType, object.ContentType))
+	}
+	if file.SHA256 != "" && sha256Base64ToHex(object.ChecksumSHA256) != file.SHA256 {
+		return sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: checksum mismatch (declared sha256 %s)", file.SHA256))
+	}
+	return nil
+}
+
+// verifyUploadedContent checks that the sniffed format of the object matches its declared content type and file type
+func verifyUploadedContent(file *entity.CloudFile, header []byte) error {
+	sniffed := sniffContentType(header)
+	if sniffed == "" {
+		return sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: content is not a supported %s format (declared %s)", file.FileType, file.ContentType))
+	}
+	if !contentMatchesDeclared(file.ContentType, sniffed) {
+		return sharedErrors.UnprocessableEntity(fmt.Sprintf("upload verification failed: content type mismatch (declared %s, detected %s)", file.ContentType, sniffed))
+	}
+	return nil
+}
+
+// sha256HexToBase64 converts a validated hex SHA-256 to the base64 form used by S3 checksum headers
+func sha256HexToBase64(checksum string) string {
+	raw, err := hex.DecodeString(checksum)
+	if err != nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// sha256Base64ToHex converts an S3 checksum to lowercase hex, returning "" if it is missing or malformed
+func sha256Base64ToHex(checksum string) string {
+	raw, err := base64.StdEncoding.DecodeString(checksum)
+	if err != nil || len(raw) != sha256.Size {
+		return ""
+	}
+	return hex.EncodeToString(raw)
+}
+
+// normalizeContentType strips parameters and lowercases a MIME type for comparison
+func normalizeContentType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+func newCompleteUploadResponse(file *entity.CloudFile) *response.CompleteUploadResponseDTO {
+	return &response.CompleteUploadResponseDTO{
 		FileID:       file.ID,
-		UploadURL:    uploadURL,
+		UploadStatus: string(file.UploadStatus),
+		FileSize:     file.FileSize,
+		ContentType:  file.ContentType,
+	}
+}
+
+// newPendingFile validates an upload request and builds a pending file record with its tags.
+// The record is not saved; callers persist it with CreateFile, which also finds or creates the tags.
+func newPendingFile(userID uint, req *request.UploadRequestDTO) (*entity.CloudFile, error) {
+	// Validate content type
+	fileType := entity.FileType(req.FileType)
+	if fileType == entity.FileTypeImage && !AllowedImageTypes[req.ContentType] {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid image content type: %s", req.ContentType))
+	}
+	if fileType == entity.FileTypeVideo && !AllowedVideoTypes[req.ContentType] {
+		return nil, sharedErrors.BadRequest(fmt.Sprintf("invalid video content type: %s", req.ContentType))
+	}
+
+	// Generate S3 key for original (thumbnails are generated by the server after commit)
+	s3Key := generateS3Key(userID, fileType, req.FileName)
+
+	// Videos get a key for the client's poster frame, the source of their thumbnails
+	thumbnailKey := ""
+	if fileType == entity.FileTypeVideo {
+		thumbnailKey = generatePosterKey(userID)
+	}
+
+	tags := make([]entity.Tag, 0, len(req.Tags))
+	for _, tagName := range req.Tags {
+		if tagName == "" {
+			continue
+		}
+		tags = append(tags, entity.Tag{
+			UserID: userID,
+			Name:   tagName,
+		})
+	}
+
+	return &entity.CloudFile{
+		UserID:       userID,
+		FileName:     req.FileName,
+		Description:  strings.TrimSpace(req.Description),
 		S3Key:        s3Key,
-		ThumbnailURL: thumbnailURL,
 		ThumbnailKey: thumbnailKey,
-		ExpiresIn:    int(DefaultUploadExpiration.Seconds()),
+		FileType:     fileType,
+		ContentType:  req.ContentType,
+		FileSize:     req.FileSize,
+		Duration:     req.Duration,
+		UploadStatus: entity.UploadStatusPending,
+		Tags:         tags,
 	}, nil
 }
 
+// logTagAddActivity logs the tags of a file that was saved
+func logTagAddActivity(ctx context.Context, statsRepo _interface.IUserStatsCloudRepositoryRepository, userID uint, tags []entity.Tag) {
+	if statsRepo == nil {
+		return
+	}
+	for _, tag := range tags {
+		activity := &entity.ActivityLog{
+			UserID:       userID,
+			ActivityType: entity.ActivityTypeTagAdd,
+			TagName:      tag.Name,
+		}
+		_ = statsRepo.LogActivity(ctx, activity) // Don't fail on logging error
+	}
+}
+
 // generateS3Key generates a unique S3 key for a file
-func (u *UploadCloudRepositoryUseCase) generateS3Key(userID uint, fileType entity.FileType, fileName string) string {
+func generateS3Key(userID uint, fileType entity.FileType, fileName string) string {
 	// Generate UUID for uniqueness
 	fileID := uuid.New().String()
 
@@ -186,26 +406,8 @@ func (u *UploadCloudRepositoryUseCase) generateS3Key(userID uint, fileType entit
 	return fmt.Sprintf("users/%d/files/%s-%s%s", userID, fileID, baseName, ext)
 }
 
-// generateThumbnailKey generates a unique S3 key for a thumbnail
-func (u *UploadCloudRepositoryUseCase) generateThumbnailKey(userID uint, fileName string) string {
-	// Generate UUID for uniqueness
-	fileID := uuid.New().String()
-
-	// Get file extension
-	ext := filepath.Ext(fileName)
-	if ext == "" {
-		ext = ".jpg" // Default to jpg for thumbnails
-	}
-
-	// Clean extension
-	ext = strings.ToLower(ext)
-
-	// Get filename without extension
-	baseName := strings.TrimSuffix(fileName, ext)
-	if baseName == "" {
-		baseName = "file"
-	}
-
-	// Format: users/{userID}/thumbnails/{uuid}-{baseName}_thumb{ext}
-	return fmt.Sprintf("users/%d/thumbnails/%s-%s_thumb%s", userID, fileID, baseName, ext)
+// generatePosterKey generates a unique S3 key for the poster frame of a video
+func generatePosterKey(userID uint) string {
+	// Format: users/{userID}/posters/{uuid}.jpg
+	return fmt.Sprintf("users/%d/posters/%s.jpg", userID, uuid.New().String())
 }
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/entity"
+	sharedAws "github.com/JokerTrickster/joker_backend/shared/aws"
+)
+
+func TestVerifyUploadedObject(t *testing.T) {
+	// SHA-256 of "hello"
+	const helloHex = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	helloBase64 := sha256HexToBase64(helloHex)
+
+	tests := []struct {
+		name    string
+		file    entity.CloudFile
+		object  sharedAws.ObjectInfo
+		wantErr string
+	}{
+		{
+			name:   "matching object without checksum",
+			file:   entity.CloudFile{FileSize: 5, ContentType: "image/jpeg"},
+			object: sharedAws.ObjectInfo{Size: 5, ContentType: "image/jpeg"},
+		},
+		{
+			name:   "matching checksum",
+			file:   entity.CloudFile{FileSize: 5, ContentType: "image/jpeg", SHA256: helloHex},
+			object: sharedAws.ObjectInfo{Size: 5, ContentType: "IMAGE/JPEG; charset=binary", ChecksumSHA256: helloBase64},
+		},
+		{
+			name:    "size mismatch",
+			file:    entity.CloudFile{FileSize: 5, ContentType: "image/jpeg"},
+			object:  sharedAws.ObjectInfo{Size: 6, ContentType: "image/jpeg"},
+			wantErr: "size mismatch",
+		},
+		{
+			name:    "content type mismatch",
+			file:    entity.CloudFile{FileSize: 5, ContentType: "image/jpeg"},
+			object:  sharedAws.ObjectInfo{Size: 5, ContentType: "image/png"},
+			wantErr: "content type mismatch",
+		},
+		{
+			name:    "checksum missing",
+			file:    entity.CloudFile{FileSize: 5, ContentType: "image/jpeg", SHA256: helloHex},
+			object:  sharedAws.ObjectInfo{Size: 5, ContentType: "image/jpeg"},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "checksum mismatch",
+			file:    entity.CloudFile{FileSize: 5, ContentType: "image/jpeg", SHA256: strings.Repeat("0", 64)},
+			object:  sharedAws.ObjectInfo{Size: 5, ContentType: "image/jpeg", ChecksumSHA256: helloBase64},
+			wantErr: "checksum mismatch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyUploadedObject(&tt.file, &tt.object)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("verifyUploadedObject() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("verifyUploadedObject() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSHA256Conversion(t *testing.T) {
+	const hexChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	const base64Checksum = "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="
+
+	if got := sha256HexToBase64(hexChecksum); got != base64Checksum {
+		t.Errorf("sha256HexToBase64() = %q, want %q", got, base64Checksum)
+	}
+	if got := sha256Base64ToHex(base64Checksum); got != hexChecksum {
+		t.Errorf("sha256Base64ToHex() = %q, want %q", got, hexChecksum)
+	}
+	// Composite multipart checksums are not a plain SHA-256
+	if got := sha256Base64ToHex(base64Checksum + "-3"); got != "" {
+		t.Errorf("sha256Base64ToHex() = %q, want empty", got)
+	}
+}
@@ -25,17 +25,17 @@ func (u *UserStatsCloudRepositoryUseCase) GetUserStats(ctx context.Context, user
 	c, cancel := context.WithTimeout(ctx, u.ContextTimeout)
 	defer cancel()
 
-	// Get total storage used
-	used, err := u.Repo.GetTotalStorageUsed(c, userID)
+	// Get storage used, trash usage and the user's storage limit
+	used, trash, totalStorage, err := u.Repo.GetStorageUsage(c, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set total storage limit (15GB for now, can be made configurable)
-	const totalStorage int64 = 15 * 1024 * 1024 * 1024 // 15GB in bytes
-
 	// Calculate percentage
-	percentage := float64(used) / float64(totalStorage) * 100
+	var percentage float64
+	if totalStorage > 0 {
+		percentage = float64(used) / float64(totalStorage) * 100
+	}
 
 	// Get current month stats
 	now := time.Now()
@@ -63,6 +63,7 @@ func (u *UserStatsCloudRepositoryUseCase) GetUserStats(ctx context.Context, user
 	return &response.UserStatsResponseDTO{
 		Storage: response.StorageInfoDTO{
 			Used:       used,
+			Trash:      trash,
 			Total:      totalStorage,
 			Percentage: percentage,
 		},
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	_interface "github.com/JokerTrickster/joker_backend/services/cloudRepositoryService/features/cloudRepository/model/interface"
+)
+
+const (
+	// videoMetadataBlockSize is the minimum ranged read issued against S3
+	videoMetadataBlockSize = 64 * 1024
+	// MaxVideoMetadataRead caps how many bytes of a video are downloaded to find its headers
+	MaxVideoMetadataRead = 64 * 1024 * 1024
+)
+
+var errUnsupportedContainer = errors.New("unsupported video container")
+
+// videoMetadata is the information extracted from a video container
+type videoMetadata struct {
+	Duration float64 // seconds
+	Width    int
+	Height   int
+	Codec    string
+	Rotation int // clockwise degrees: 0, 90, 180 or 270
+}
+
+// extractVideoMetadata sniffs the container format and parses its headers.
+// Supports ISO BMFF (MP4/MOV/3GP) and Matroska (MKV/WebM).
+func extractVideoMetadata(r io.ReaderAt, size int64) (*videoMetadata, error) {
+	header := make([]byte, 8)
+	if n, err := r.ReadAt(header, 0); n < len(header) {
+		return nil, fmt.Errorf("failed to read container header: %w", err)
+	}
+
+	switch {
+	case bytes.Equal(header[:4], []byte{0x1A, 0x45, 0xDF, 0xA3}):
+		return parseMatroska(r, size)
+	case isBMFFTopLevelBox(string(header[4:8])):
+		return parseBMFF(r, size)
+	}
+	return nil, errUnsupportedContainer
+}
+
+// normalizeRotation rounds an angle in degrees to the nearest quarter turn in [0, 360)
+func normalizeRotation(degrees float64) int {
+	rotation := int(math.Round(degrees/90)) * 90 % 360
+	if rotation < 0 {
+		rotation += 360
+	}
+	return rotation
+}
+
+// s3RangeReader is an io.ReaderAt over an S3 object that fetches ranges on demand.
+// Fetched blocks are cached so container parsers can issue many small reads.
+type s3RangeReader struct {
+	ctx     context.Context
+	repo    _interface.IFileProcessingCloudRepositoryRepository
+	key     string
+	size    int64
+	blocks  map[int64][]byte
+	fetched int64
+	err     error // Last S3 error, to tell transient failures from malformed files
+}
+
+func newS3RangeReader(ctx context.Context, repo _interface.IFileProcessingCloudRepositoryRepository, key string, size int64) *s3RangeReader {
+	return &s3RangeReader{
+		ctx:    ctx,
+		repo:   repo,
+		key:    key,
+		size:   size,
+		blocks: make(map[int64][]byte),
+	}
+}
+
+// ReadAt implements io.ReaderAt
+func (r *s3RangeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset %d", off)
+	}
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	end := min(off+int64(len(p)), r.size)
+	if err := r.fetch(off/videoMetadataBlockSize, (end-1)/videoMetadataBlockSize); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for pos := off; pos < end; pos = off + int64(n) {
+		index := pos / videoMetadataBlockSize
+		n += copy(p[n:], r.blocks[index][pos-index*videoMetadataBlockSize:])
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// fetch loads the missing blocks in [first, last] with a single ranged read
+func (r *s3RangeReader) fetch(first, last int64) error {
+	for first <= last {
+		if _, ok := r.blocks[first]; !ok {
+			break
+		}
+		first++
+	}
+	for last >= first {
+		if _, ok := r.blocks[last]; !ok {
+			break
+		}
+		last--
+	}
+	if first > last {
+		return nil
+	}
+
+	start := first * videoMetadataBlockSize
+	length := min((last+1)*videoMetadataBlockSize, r.size) - start
+	if r.fetched+length > MaxVideoMetadataRead {
+		return fmt.Errorf("video headers exceed %d bytes", MaxVideoMetadataRead)
+	}
+
+	data, err := r.repo.GetObjectRange(r.ctx, r.key, start, length)
+	if err != nil {
+		r.err = err
+		return err
+	}
+	if int64(len(data)) != length {
+		return fmt.Errorf("short ranged read: got %d bytes, expected %d", len(data), length)
+	}
+	r.fetched += length
+
+	for index := first; index <= last; index++ {
+		offset := (index - first) * videoMetadataBlockSize
+		r.blocks[index] = data[offset:min(offset+videoMetadataBlockSize, int64(len(data)))]
+	}
+	return nil
+}
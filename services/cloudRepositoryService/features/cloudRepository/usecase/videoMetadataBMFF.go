@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// MaxMoovBoxSize caps the size of the moov box loaded into memory
+const MaxMoovBoxSize = 32 * 1024 * 1024
+
+// bmffCodecs maps sample entry types to codec names
+var bmffCodecs = map[string]string{
+	"avc1": "h264",
+	"avc3": "h264",
+	"hvc1": "hevc",
+	"hev1": "hevc",
+	"vp08": "vp8",
+	"vp09": "vp9",
+	"av01": "av1",
+	"mp4v": "mpeg4",
+	"s263": "h263",
+	"apcn": "prores",
+	"apch": "prores",
+	"apcs": "prores",
+	"apco": "prores",
+	"ap4h": "prores",
+}
+
+// isBMFFTopLevelBox reports whether a box type commonly starts an ISO BMFF file
+func isBMFFTopLevelBox(boxType string) bool {
+	switch boxType {
+	case "ftyp", "moov", "mdat", "free", "skip", "wide", "pnot":
+		return true
+	}
+	return false
+}
+
+// bmffBox is a box located in a byte slice
+type bmffBox struct {
+	Type    string
+	Payload []byte
+}
+
+// parseBMFF finds the moov box and reads duration (mvhd) and the first video track (tkhd, hdlr, stsd).
+// Only the box headers and the moov box are read, so files with moov at the end cost two or three ranged reads.
+func parseBMFF(r io.ReaderAt, size int64) (*videoMetadata, error) {
+	moov, err := readTopLevelBox(r, size, "moov")
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &videoMetadata{}
+	foundVideo := false
+	for _, box := range bmffChildren(moov) {
+		switch box.Type {
+		case "mvhd":
+			duration, err := parseMvhd(box.Payload)
+			if err != nil {
+				return nil, err
+			}
+			meta.Duration = duration
+		case "trak":
+			if foundVideo {
+				continue
+			}
+			if track, ok := parseVideoTrak(box.Payload); ok {
+				meta.Width, meta.Height, meta.Codec, meta.Rotation = track.Width, track.Height, track.Codec, track.Rotation
+				foundVideo = true
+			}
+		}
+	}
+
+	if !foundVideo {
+		return nil, fmt.Errorf("no video track found")
+	}
+	return meta, nil
+}
+
+// readTopLevelBox walks the top-level box headers and loads the payload of the first box of the given type
+func readTopLevelBox(r io.ReaderAt, size int64, boxType string) ([]byte, error) {
+	header := make([]byte, 16)
+	for offset := int64(0); offset+8 <= size; {
+		n, err := r.ReadAt(header, offset)
+		if n < 8 {
+			return nil, fmt.Errorf("failed to read box header at %d: %w", offset, err)
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		headerSize := int64(8)
+		switch boxSize {
+		case 0: // Box extends to the end of the file
+			boxSize = size - offset
+		case 1: // 64-bit size follows the type
+			if n < 16 {
+				return nil, fmt.Errorf("truncated box header at %d", offset)
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if boxSize < headerSize || offset+boxSize > size {
+			return nil, fmt.Errorf("invalid box size %d at %d", boxSize, offset)
+		}
+
+		if string(header[4:8]) == boxType {
+			payloadSize := boxSize - headerSize
+			if payloadSize > MaxMoovBoxSize {
+				return nil, fmt.Errorf("%s box too large: %d bytes", boxType, payloadSize)
+			}
+			payload := make([]byte, payloadSize)
+			if n, err := r.ReadAt(payload, offset+headerSize); int64(n) < payloadSize {
+				return nil, fmt.Errorf("failed to read %s box: %w", boxType, err)
+			}
+			return payload, nil
+		}
+
+		offset += boxSize
+	}
+	return nil, fmt.Errorf("%s box not found", boxType)
+}
+
+// bmffChildren splits a box payload into its child boxes, ignoring a truncated tail
+func bmffChildren(data []byte) []bmffBox {
+	boxes := make([]bmffBox, 0)
+	for len(data) >= 8 {
+		boxSize := uint64(binary.BigEndian.Uint32(data[0:4]))
+		headerSize := uint64(8)
+		switch boxSize {
+		case 0:
+			boxSize = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return boxes
+			}
+			boxSize = binary.BigEndian.Uint64(data[8:16])
+			headerSize = 16
+		}
+		if boxSize < headerSize || boxSize > uint64(len(data)) {
+			return boxes
+		}
+
+		boxes = append(boxes, bmffBox{Type: string(data[4:8]), Payload: data[headerSize:boxSize]})
+		data = data[boxSize:]
+	}
+	return boxes
+}
+
+// findBMFFBox follows a path of box types below data
+func findBMFFBox(data []byte, path ...string) ([]byte, bool) {
+	for _, boxType := range path {
+		found := false
+		for _, box := range bmffChildren(data) {
+			if box.Type == boxType {
+				data, found = box.Payload, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// parseMvhd returns the movie duration in seconds
+func parseMvhd(p []byte) (float64, error) {
+	if len(p) < 20 {
+		return 0, fmt.Errorf("truncated mvhd box")
+	}
+
+	var timescale uint32
+	var duration uint64
+	if p[0] == 1 {
+		if len(p) < 32 {
+			return 0, fmt.Errorf("truncated mvhd box")
+		}
+		timescale = binary.BigEndian.Uint32(p[20:24])
+		duration = binary.BigEndian.Uint64(p[24:32])
+		if duration == math.MaxUint64 {
+			duration = 0
+		}
+	} else {
+		timescale = binary.BigEndian.Uint32(p[12:16])
+		duration = uint64(binary.BigEndian.Uint32(p[16:20]))
+		if duration == math.MaxUint32 {
+			duration = 0
+		}
+	}
+
+	if timescale == 0 {
+		return 0, fmt.Errorf("invalid mvhd timescale")
+	}
+	return float64(duration) / float64(timescale), nil
+}
+
+// parseVideoTrak returns the video properties of a trak box, or false if it is not a video track
+func parseVideoTrak(trak []byte) (*videoMetadata, bool) {
+	hdlr, ok := findBMFFBox(trak, "mdia", "hdlr")
+	if !ok || len(hdlr) < 12 || string(hdlr[8:12]) != "vide" {
+		return nil, false
+	}
+
+	meta := &videoMetadata{}
+	if tkhd, ok := findBMFFBox(trak, "tkhd"); ok {
+		meta.Width, meta.Height, meta.Rotation = parseTkhd(tkhd)
+	}
+
+	if stsd, ok := findBMFFBox(trak, "mdia", "minf", "stbl", "stsd"); ok && len(stsd) >= 8 {
+		entries := bmffChildren(stsd[8:])
+		if len(entries) > 0 {
+			entry := entries[0]
+			meta.Codec = bmffCodecName(entry.Type)
+
+			// Visual sample entry: width and height follow 24 bytes of reserved/predefined fields
+			if (meta.Width == 0 || meta.Height == 0) && len(entry.Payload) >= 28 {
+				meta.Width = int(binary.BigEndian.Uint16(entry.Payload[24:26]))
+				meta.Height = int(binary.BigEndian.Uint16(entry.Payload[26:28]))
+			}
+		}
+	}
+
+	return meta, true
+}
+
+// parseTkhd returns the display width, height and clockwise rotation from a track header
+func parseTkhd(p []byte) (int, int, int) {
+	// Matrix starts after version/flags, times, track ID, duration and 16 bytes of layer/volume fields
+	matrixOffset := 40
+	if len(p) > 0 && p[0] == 1 {
+		matrixOffset = 52
+	}
+	if len(p) < matrixOffset+44 {
+		return 0, 0, 0
+	}
+
+	a := float64(int32(binary.BigEndian.Uint32(p[matrixOffset:])))
+	b := float64(int32(binary.BigEndian.Uint32(p[matrixOffset+4:])))
+	rotation := normalizeRotation(math.Atan2(b, a) * 180 / math.Pi)
+
+	// Width and height are 16.16 fixed point
+	width := int(binary.BigEndian.Uint32(p[matrixOffset+36:]) >> 16)
+	height := int(binary.BigEndian.Uint32(p[matrixOffset+40:]) >> 16)
+	return width, height, rotation
+}
+
+func bmffCodecName(sampleEntryType string) string {
+	if codec, ok := bmffCodecs[sampleEntryType]; ok {
+		return codec
+	}
+	return strings.TrimSpace(sampleEntryType)
+}
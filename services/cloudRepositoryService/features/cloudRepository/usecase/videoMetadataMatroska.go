@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Matroska element IDs used for metadata extraction
+const (
+	ebmlIDHeader        = 0x1A45DFA3
+	ebmlIDDocType       = 0x4282
+	ebmlIDSegment       = 0x18538067
+	ebmlIDSeekHead      = 0x114D9B74
+	ebmlIDSeek          = 0x4DBB
+	ebmlIDSeekID        = 0x53AB
+	ebmlIDSeekPosition  = 0x53AC
+	ebmlIDInfo          = 0x1549A966
+	ebmlIDTimecodeScale = 0x2AD7B1
+	ebmlIDDuration      = 0x4489
+	ebmlIDTracks        = 0x1654AE6B
+	ebmlIDTrackEntry    = 0xAE
+	ebmlIDTrackType     = 0x83
+	ebmlIDCodecID       = 0x86
+	ebmlIDVideo         = 0xE0
+	ebmlIDPixelWidth    = 0xB0
+	ebmlIDPixelHeight   = 0xBA
+	ebmlIDProjection    = 0x7670
+	ebmlIDPoseRoll      = 0x7675
+	ebmlIDCluster       = 0x1F43B675
+)
+
+const (
+	// MaxMatroskaElementSize caps the size of an Info or Tracks element loaded into memory
+	MaxMatroskaElementSize = 4 * 1024 * 1024
+	matroskaTrackTypeVideo = 1
+	defaultTimecodeScale   = 1000000 // nanoseconds per tick
+	ebmlUnknownSize        = -1
+)
+
+// matroskaCodecs maps Matroska codec IDs to codec names
+var matroskaCodecs = map[string]string{
+	"V_VP8":            "vp8",
+	"V_VP9":            "vp9",
+	"V_AV1":            "av1",
+	"V_MPEG4/ISO/AVC":  "h264",
+	"V_MPEGH/ISO/HEVC": "hevc",
+	"V_MPEG4/ISO/ASP":  "mpeg4",
+	"V_THEORA":         "theora",
+}
+
+// ebmlElement is an element located in a byte slice
+type ebmlElement struct {
+	ID      uint32
+	Payload []byte
+}
+
+// parseMatroska reads the Info and Tracks elements of the first Segment.
+// The top-level scan stops at the first Cluster; Info or Tracks written after the clusters are found via the SeekHead.
+func parseMatroska(r io.ReaderAt, size int64) (*videoMetadata, error) {
+	id, dataSize, headerLen, err := readEBMLHeader(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if id != ebmlIDHeader || dataSize == ebmlUnknownSize {
+		return nil, fmt.Errorf("invalid EBML header")
+	}
+
+	offset := int64(headerLen) + dataSize
+	id, dataSize, headerLen, err = readEBMLHeader(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if id != ebmlIDSegment {
+		return nil, fmt.Errorf("segment not found")
+	}
+	segmentStart := offset + int64(headerLen)
+	segmentEnd := size
+	if dataSize != ebmlUnknownSize {
+		segmentEnd = min(segmentStart+dataSize, size)
+	}
+
+	var info, tracks []byte
+	seeks := make(map[uint32]int64)
+	for offset = segmentStart; offset < segmentEnd && (info == nil || tracks == nil); {
+		id, dataSize, headerLen, err = readEBMLHeader(r, offset)
+		if err != nil || dataSize == ebmlUnknownSize || id == ebmlIDCluster {
+			break
+		}
+		dataStart := offset + int64(headerLen)
+
+		switch id {
+		case ebmlIDInfo, ebmlIDTracks, ebmlIDSeekHead:
+			data, err := readEBMLPayload(r, dataStart, dataSize)
+			if err != nil {
+				return nil, err
+			}
+			switch id {
+			case ebmlIDInfo:
+				info = data
+			case ebmlIDTracks:
+				tracks = data
+			case ebmlIDSeekHead:
+				for target, position := range parseSeekHead(data) {
+					seeks[target] = position
+				}
+			}
+		}
+
+		offset = dataStart + dataSize
+	}
+
+	if info == nil {
+		if info, err = readSeekTarget(r, segmentStart, seeks, ebmlIDInfo); err != nil {
+			return nil, err
+		}
+	}
+	if tracks == nil {
+		if tracks, err = readSeekTarget(r, segmentStart, seeks, ebmlIDTracks); err != nil {
+			return nil, err
+		}
+	}
+
+	meta, ok := parseMatroskaTracks(tracks)
+	if !ok {
+		return nil, fmt.Errorf("no video track found")
+	}
+	meta.Duration = parseMatroskaInfo(info)
+	return meta, nil
+}
+
+// readEBMLHeader reads an element ID and data size at offset
+func readEBMLHeader(r io.ReaderAt, offset int64) (uint32, int64, int, error) {
+	buf := make([]byte, 12)
+	n, err := r.ReadAt(buf, offset)
+	if n == 0 {
+		return 0, 0, 0, fmt.Errorf("failed to read element at %d: %w", offset, err)
+	}
+	buf = buf[:n]
+
+	id, idLen, ok := readEBMLID(buf)
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid element ID at %d", offset)
+	}
+	dataSize, sizeLen, ok := readEBMLSize(buf[idLen:])
+	if !ok {
+		return 0, 0, 0, fmt.Errorf("invalid element size at %d", offset)
+	}
+	return id, dataSize, idLen + sizeLen, nil
+}
+
+func readEBMLPayload(r io.ReaderAt, offset, size int64) ([]byte, error) {
+	if size > MaxMatroskaElementSize {
+		return nil, fmt.Errorf("element too large: %d bytes", size)
+	}
+	data := make([]byte, size)
+	if n, err := r.ReadAt(data, offset); int64(n) < size {
+		return nil, fmt.Errorf("failed to read element at %d: %w", offset, err)
+	}
+	return data, nil
+}
+
+// readSeekTarget loads the element a SeekHead entry points to
+func readSeekTarget(r io.ReaderAt, segmentStart int64, seeks map[uint32]int64, target uint32) ([]byte, error) {
+	position, ok := seeks[target]
+	if !ok {
+		return nil, fmt.Errorf("element %X not found", target)
+	}
+
+	offset := segmentStart + position
+	id, dataSize, headerLen, err := readEBMLHeader(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if id != target || dataSize == ebmlUnknownSize {
+		return nil, fmt.Errorf("invalid seek position for element %X", target)
+	}
+	return readEBMLPayload(r, offset+int64(headerLen), dataSize)
+}
+
+// readEBMLID reads an element ID, keeping its length marker bits as Matroska IDs are written
+func readEBMLID(buf []byte) (uint32, int, bool) {
+	if len(buf) == 0 || buf[0] == 0 {
+		return 0, 0, false
+	}
+	length := 1
+	for mask := byte(0x80); buf[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if length > 4 || len(buf) < length {
+		return 0, 0, false
+	}
+
+	var id uint32
+	for _, b := range buf[:length] {
+		id = id<<8 | uint32(b)
+	}
+	return id, length, true
+}
+
+// readEBMLSize reads a variable-length data size; all value bits set means unknown size
+func readEBMLSize(buf []byte) (int64, int, bool) {
+	if len(buf) == 0 || buf[0] == 0 {
+		return 0, 0, false
+	}
+	length := 1
+	mask := byte(0x80)
+	for ; buf[0]&mask == 0; mask >>= 1 {
+		length++
+	}
+	if len(buf) < length {
+		return 0, 0, false
+	}
+
+	value := uint64(buf[0] & (mask - 1))
+	allOnes := value == uint64(mask-1)
+	for _, b := range buf[1:length] {
+		value = value<<8 | uint64(b)
+		allOnes = allOnes && b == 0xFF
+	}
+	if allOnes {
+		return ebmlUnknownSize, length, true
+	}
+	if value > math.MaxInt64 {
+		return 0, 0, false
+	}
+	return int64(value), length, true
+}
+
+// ebmlChildren splits an element payload into its children, ignoring a truncated tail
+func ebmlChildren(data []byte) []ebmlElement {
+	elements := make([]ebmlElement, 0)
+	for len(data) > 0 {
+		id, idLen, ok := readEBMLID(data)
+		if !ok {
+			return elements
+		}
+		size, sizeLen, ok := readEBMLSize(data[idLen:])
+		if !ok {
+			return elements
+		}
+
+		start := idLen + sizeLen
+		end := int64(len(data))
+		if size != ebmlUnknownSize {
+			end = int64(start) + size
+		}
+		if end > int64(len(data)) {
+			return elements
+		}
+
+		elements = append(elements, ebmlElement{ID: id, Payload: data[start:end]})
+		data = data[end:]
+	}
+	return elements
+}
+
+func ebmlUint(data []byte) uint64 {
+	var value uint64
+	for _, b := range data {
+		value = value<<8 | uint64(b)
+	}
+	return value
+}
+
+func ebmlFloat(data []byte) float64 {
+	switch len(data) {
+	case 4:
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data)))
+	case 8:
+		return math.Float64frombits(binary.BigEndian.Uint64(data))
+	}
+	return 0
+}
+
+// parseSeekHead returns the segment-relative positions of the elements listed in a SeekHead
+func parseSeekHead(data []byte) map[uint32]int64 {
+	seeks := make(map[uint32]int64)
+	for _, seek := range ebmlChildren(data) {
+		if seek.ID != ebmlIDSeek {
+			continue
+		}
+
+		var target uint32
+		position := int64(-1)
+		for _, element := range ebmlChildren(seek.Payload) {
+			switch element.ID {
+			case ebmlIDSeekID:
+				target = uint32(ebmlUint(element.Payload))
+			case ebmlIDSeekPosition:
+				position = int64(ebmlUint(element.Payload))
+			}
+		}
+		if target != 0 && position >= 0 {
+			seeks[target] = position
+		}
+	}
+	return seeks
+}
+
+// parseMatroskaInfo returns the segment duration in seconds
+func parseMatroskaInfo(data []byte) float64 {
+	timecodeScale := uint64(defaultTimecodeScale)
+	var duration float64
+	for _, element := range ebmlChildren(data) {
+		switch element.ID {
+		case ebmlIDTimecodeScale:
+			if scale := ebmlUint(element.Payload); scale > 0 {
+				timecodeScale = scale
+			}
+		case ebmlIDDuration:
+			duration = ebmlFloat(element.Payload)
+		}
+	}
+	return duration * float64(timecodeScale) / 1e9
+}
+
+// parseMatroskaTracks returns the properties of the first video track
+func parseMatroskaTracks(data []byte) (*videoMetadata, bool) {
+	for _, entry := range ebmlChildren(data) {
+		if entry.ID != ebmlIDTrackEntry {
+			continue
+		}
+
+		meta := &videoMetadata{}
+		isVideo := false
+		for _, element := range ebmlChildren(entry.Payload) {
+			switch element.ID {
+			case ebmlIDTrackType:
+				isVideo = ebmlUint(element.Payload) == matroskaTrackTypeVideo
+			case ebmlIDCodecID:
+				meta.Codec = matroskaCodecName(string(element.Payload))
+			case ebmlIDVideo:
+				parseMatroskaVideo(element.Payload, meta)
+			}
+		}
+		if isVideo {
+			return meta, true
+		}
+	}
+	return nil, false
+}
+
+func parseMatroskaVideo(data []byte, meta *videoMetadata) {
+	for _, element := range ebmlChildren(data) {
+		switch element.ID {
+		case ebmlIDPixelWidth:
+			meta.Width = int(ebmlUint(element.Payload))
+		case ebmlIDPixelHeight:
+			meta.Height = int(ebmlUint(element.Payload))
+		case ebmlIDProjection:
+			for _, projection := range ebmlChildren(element.Payload) {
+				if projection.ID == ebmlIDPoseRoll {
+					meta.Rotation = normalizeRotation(ebmlFloat(projection.Payload))
+				}
+			}
+		}
+	}
+}
+
+func matroskaCodecName(codecID string) string {
+	codecID = strings.TrimRight(codecID, "\x00")
+	if codec, ok := matroskaCodecs[codecID]; ok {
+		return codec
+	}
+	return strings.ToLower(strings.TrimPrefix(codecID, "V_"))
+}
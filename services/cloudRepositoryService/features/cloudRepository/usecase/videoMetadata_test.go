@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+func box(boxType string, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	out := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(out, boxType...), body...)
+}
+
+func mvhdBox(timescale, duration uint32) []byte {
+	payload := make([]byte, 100)
+	binary.BigEndian.PutUint32(payload[12:], timescale)
+	binary.BigEndian.PutUint32(payload[16:], duration)
+	return box("mvhd", payload)
+}
+
+func tkhdBox(width, height int, a, b, c, d int32) []byte {
+	payload := make([]byte, 84)
+	for i, v := range []int32{a, b, 0, c, d, 0, 0, 0, 1 << 30} {
+		binary.BigEndian.PutUint32(payload[40+i*4:], uint32(v))
+	}
+	binary.BigEndian.PutUint32(payload[76:], uint32(width)<<16)
+	binary.BigEndian.PutUint32(payload[80:], uint32(height)<<16)
+	return box("tkhd", payload)
+}
+
+func trakBox(handler, sampleEntry string, tkhd []byte) []byte {
+	hdlr := make([]byte, 24)
+	copy(hdlr[8:], handler)
+	entry := make([]byte, 70)
+	binary.BigEndian.PutUint16(entry[24:], 320)
+	binary.BigEndian.PutUint16(entry[26:], 240)
+	stsd := append(make([]byte, 8), box(sampleEntry, entry)...)
+	return box("trak", tkhd, box("mdia", box("hdlr", hdlr), box("minf", box("stbl", box("stsd", stsd)))))
+}
+
+func ebml(id uint32, payload ...[]byte) []byte {
+	body := bytes.Join(payload, nil)
+	var out []byte
+	for shift := 24; shift >= 0; shift -= 8 {
+		if b := byte(id >> shift); b != 0 || len(out) > 0 {
+			out = append(out, b)
+		}
+	}
+	// 8-byte data size: length marker followed by 7 big-endian bytes
+	size := binary.BigEndian.AppendUint64(nil, uint64(len(body)))
+	size[0] = 0x01
+	return append(append(out, size...), body...)
+}
+
+func ebmlUintBytes(v uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, v)
+}
+
+func ebmlFloatBytes(v float64) []byte {
+	return binary.BigEndian.AppendUint64(nil, math.Float64bits(v))
+}
+
+func TestExtractVideoMetadataBMFF(t *testing.T) {
+	const one = 1 << 16
+	ftyp := box("ftyp", []byte("isom\x00\x00\x02\x00isomavc1"))
+	audio := trakBox("soun", "mp4a", tkhdBox(0, 0, one, 0, 0, one))
+
+	tests := []struct {
+		name string
+		file []byte
+		want videoMetadata
+	}{
+		{
+			name: "moov before mdat",
+			file: bytes.Join([][]byte{ftyp, box("moov", mvhdBox(1000, 12500), trakBox("vide", "avc1", tkhdBox(1920, 1080, one, 0, 0, one))), box("mdat", make([]byte, 64))}, nil),
+			want: videoMetadata{Duration: 12.5, Width: 1920, Height: 1080, Codec: "h264"},
+		},
+		{
+			name: "moov at end with rotation and audio first",
+			file: bytes.Join([][]byte{ftyp, box("mdat", make([]byte, 200000)), box("moov", mvhdBox(600, 1800), audio, trakBox("vide", "hvc1", tkhdBox(3840, 2160, 0, one, -one, 0)))}, nil),
+			want: videoMetadata{Duration: 3, Width: 3840, Height: 2160, Codec: "hevc", Rotation: 90},
+		},
+		{
+			name: "dimensions from sample entry",
+			file: bytes.Join([][]byte{ftyp, box("moov", mvhdBox(1, 7), trakBox("vide", "vp09", tkhdBox(0, 0, -one, 0, 0, -one)))}, nil),
+			want: videoMetadata{Duration: 7, Width: 320, Height: 240, Codec: "vp9", Rotation: 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractVideoMetadata(bytes.NewReader(tt.file), int64(len(tt.file)))
+			if err != nil {
+				t.Fatalf("extractVideoMetadata() error = %v", err)
+			}
+			if *got != tt.want {
+				t.Errorf("extractVideoMetadata() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractVideoMetadataMatroska(t *testing.T) {
+	header := ebml(ebmlIDHeader, ebml(ebmlIDDocType, []byte("webm")))
+	info := ebml(ebmlIDInfo, ebml(ebmlIDTimecodeScale, ebmlUintBytes(1000000)), ebml(ebmlIDDuration, ebmlFloatBytes(61500)))
+	tracks := ebml(ebmlIDTracks,
+		ebml(ebmlIDTrackEntry, ebml(ebmlIDTrackType, []byte{2}), ebml(ebmlIDCodecID, []byte("A_OPUS"))),
+		ebml(ebmlIDTrackEntry, ebml(ebmlIDTrackType, []byte{1}), ebml(ebmlIDCodecID, []byte("V_VP9")),
+			ebml(ebmlIDVideo, ebml(ebmlIDPixelWidth, []byte{0x05, 0x00}), ebml(ebmlIDPixelHeight, []byte{0x02, 0xD0}),
+				ebml(ebmlIDProjection, ebml(ebmlIDPoseRoll, ebmlFloatBytes(-90))))))
+	cluster := ebml(ebmlIDCluster, make([]byte, 32))
+	want := videoMetadata{Duration: 61.5, Width: 1280, Height: 720, Codec: "vp9", Rotation: 270}
+
+	t.Run("info and tracks before clusters", func(t *testing.T) {
+		file := append(header, ebml(ebmlIDSegment, info, tracks, cluster)...)
+		got, err := extractVideoMetadata(bytes.NewReader(file), int64(len(file)))
+		if err != nil {
+			t.Fatalf("extractVideoMetadata() error = %v", err)
+		}
+		if *got != want {
+			t.Errorf("extractVideoMetadata() = %+v, want %+v", *got, want)
+		}
+	})
+
+	t.Run("tracks after clusters via seek head", func(t *testing.T) {
+		seekHeadSize := len(ebml(ebmlIDSeekHead, ebml(ebmlIDSeek, ebml(ebmlIDSeekID, ebmlUintBytes(ebmlIDTracks)), ebml(ebmlIDSeekPosition, ebmlUintBytes(0)))))
+		position := uint64(seekHeadSize + len(info) + len(cluster))
+		seekHead := ebml(ebmlIDSeekHead, ebml(ebmlIDSeek, ebml(ebmlIDSeekID, ebmlUintBytes(ebmlIDTracks)), ebml(ebmlIDSeekPosition, ebmlUintBytes(position))))
+
+		file := append(header, ebml(ebmlIDSegment, seekHead, info, cluster, tracks)...)
+		got, err := extractVideoMetadata(bytes.NewReader(file), int64(len(file)))
+		if err != nil {
+			t.Fatalf("extractVideoMetadata() error = %v", err)
+		}
+		if *got != want {
+			t.Errorf("extractVideoMetadata() = %+v, want %+v", *got, want)
+		}
+	})
+}
+
+func TestExtractVideoMetadataUnsupported(t *testing.T) {
+	avi := []byte("RIFF\x00\x00\x00\x00AVI LIST")
+	if _, err := extractVideoMetadata(bytes.NewReader(avi), int64(len(avi))); !errors.Is(err, errUnsupportedContainer) {
+		t.Errorf("extractVideoMetadata() error = %v, want %v", err, errUnsupportedContainer)
+	}
+}
+
+func TestNormalizeRotation(t *testing.T) {
+	tests := []struct {
+		degrees float64
+		want    int
+	}{
+		{0, 0}, {89.9, 90}, {-90, 270}, {180, 180}, {-180, 180}, {270, 270}, {360, 0},
+	}
+
+	for _, tt := range tests {
+		if got := normalizeRotation(tt.degrees); got != tt.want {
+			t.Errorf("normalizeRotation(%v) = %d, want %d", tt.degrees, got, tt.want)
+		}
+	}
+}
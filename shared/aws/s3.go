@@ -3,18 +3,34 @@ package aws
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"html"
 	"image/png"
+	"io"
 	"math/rand"
+	"mime"
 	"mime/multipart"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/disintegration/imaging"
 )
 
+// ErrObjectNotFound is returned when the requested S3 object does not exist
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo holds the metadata of an S3 object returned by HeadObject
+type ObjectInfo struct {
+	Size           int64
+	ContentType    string
+	ETag           string
+	ChecksumSHA256 string // Base64 encoded, set when the object was uploaded with a SHA-256 checksum
+	LastModified   time.Time
+}
+
 var imgMeta = map[ImgType]imgMetaStruct{
 	ImgCloudRepository: {
 		bucket:     func() string { return "joker-cloud-repository-dev" },
@@ -140,6 +156,29 @@ func GeneratePresignedUploadURL(ctx context.Context, bucket, key, contentType st
 	return html.UnescapeString(presignResult.URL), nil
 }
 
+// GeneratePresignedUploadURLWithChecksum generates a presigned upload URL that only accepts a body with the given SHA-256.
+// checksumSHA256 is base64 encoded and must be sent by the client in the x-amz-checksum-sha256 header.
+func GeneratePresignedUploadURLWithChecksum(ctx context.Context, bucket, key, contentType, checksumSHA256 string, expiration time.Duration) (string, error) {
+	if awsClientS3 == nil {
+		return "", fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+	presignClient := s3.NewPresignClient(awsClientS3)
+
+	presignParams := &s3.PutObjectInput{
+		Bucket:         aws.String(bucket),
+		Key:            aws.String(key),
+		ContentType:    aws.String(contentType),
+		ChecksumSHA256: aws.String(checksumSHA256),
+	}
+
+	presignResult, err := presignClient.PresignPutObject(ctx, presignParams, s3.WithPresignExpires(expiration))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned upload URL: %w", err)
+	}
+
+	return html.UnescapeString(presignResult.URL), nil
+}
+
 // GeneratePresignedDownloadURL generates a presigned URL for downloading from S3
 func GeneratePresignedDownloadURL(ctx context.Context, bucket, key string, expiration time.Duration) (string, error) {
 	if awsClientS3 == nil {
@@ -170,7 +209,7 @@ func GeneratePresignedDownloadURLWithFilename(ctx context.Context, bucket, key,
 	presignParams := &s3.GetObjectInput{
 		Bucket:                     aws.String(bucket),
 		Key:                        aws.String(key),
-		ResponseContentDisposition: aws.String(fmt.Sprintf(`attachment; filename="%s"`, filename)),
+		ResponseContentDisposition: aws.String(attachmentDisposition(filename)),
 	}
 
 	presignResult, err := presignClient.PresignGetObject(ctx, presignParams, s3.WithPresignExpires(expiration))
@@ -181,6 +220,15 @@ func GeneratePresignedDownloadURLWithFilename(ctx context.Context, bucket, key,
 	return html.UnescapeString(presignResult.URL), nil
 }
 
+// attachmentDisposition builds a Content-Disposition value that downloads as filename.
+// Quotes and separators are escaped and non-ASCII names are encoded per RFC 2231.
+func attachmentDisposition(filename string) string {
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); disposition != "" {
+		return disposition
+	}
+	return "attachment"
+}
+
 // DeleteObject deletes an object from S3
 func DeleteObject(ctx context.Context, bucket, key string) error {
 	_, err := awsClientS3.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -193,3 +241,332 @@ func DeleteObject(ctx context.Context, bucket, key string) error {
 
 	return nil
 }
+
+// HeadObject retrieves object metadata from S3 without downloading the body
+func HeadObject(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(bucket),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to head object from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return &ObjectInfo{
+		Size:           aws.ToInt64(out.ContentLength),
+		ContentType:    aws.ToString(out.ContentType),
+		ETag:           aws.ToString(out.ETag),
+		ChecksumSHA256: aws.ToString(out.ChecksumSHA256),
+		LastModified:   aws.ToTime(out.LastModified),
+	}, nil
+}
+
+// UploadedPart describes a part of a multipart upload already stored in S3
+type UploadedPart struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+// CreateMultipartUpload starts a multipart upload and returns its upload ID
+func CreateMultipartUpload(ctx context.Context, bucket, key, contentType string) (string, error) {
+	if awsClientS3 == nil {
+		return "", fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return aws.ToString(out.UploadId), nil
+}
+
+// GeneratePresignedUploadPartURL generates a presigned URL for uploading a single part of a multipart upload
+func GeneratePresignedUploadPartURL(ctx context.Context, bucket, key, uploadID string, partNumber int32, expiration time.Duration) (string, error) {
+	if awsClientS3 == nil {
+		return "", fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+	presignClient := s3.NewPresignClient(awsClientS3)
+
+	presignParams := &s3.UploadPartInput{
+		Bucket:     aws.String(bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+	}
+
+	presignResult, err := presignClient.PresignUploadPart(ctx, presignParams, s3.WithPresignExpires(expiration))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned upload part URL: %w", err)
+	}
+
+	return html.UnescapeString(presignResult.URL), nil
+}
+
+// ListUploadedParts lists all parts already uploaded for a multipart upload
+func ListUploadedParts(ctx context.Context, bucket, key, uploadID string) ([]UploadedPart, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	parts := make([]UploadedPart, 0)
+	paginator := s3.NewListPartsPaginator(awsClientS3, &s3.ListPartsInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list multipart upload parts - bucket: %s, key: %s: %w", bucket, key, err)
+		}
+		for _, part := range page.Parts {
+			parts = append(parts, UploadedPart{
+				PartNumber: aws.ToInt32(part.PartNumber),
+				ETag:       aws.ToString(part.ETag),
+				Size:       aws.ToInt64(part.Size),
+			})
+		}
+	}
+
+	return parts, nil
+}
+
+// CompleteMultipartUpload assembles the uploaded parts into the final object
+func CompleteMultipartUpload(ctx context.Context, bucket, key, uploadID string, parts []UploadedPart) error {
+	if awsClientS3 == nil {
+		return fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	completedParts := make([]types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = types.CompletedPart{
+			ETag:       aws.String(part.ETag),
+			PartNumber: aws.Int32(part.PartNumber),
+		}
+	}
+
+	_, err := awsClientS3.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{
+			Parts: completedParts,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// AbortMultipartUpload aborts a multipart upload and frees the storage of its parts
+func AbortMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
+	if awsClientS3 == nil {
+		return fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	_, err := awsClientS3.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to abort multipart upload - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// UploadPart uploads a single part of a multipart upload and returns its ETag
+func UploadPart(ctx context.Context, bucket, key, uploadID string, partNumber int32, body []byte) (string, error) {
+	if awsClientS3 == nil {
+		return "", fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		UploadId:      aws.String(uploadID),
+		PartNumber:    aws.Int32(partNumber),
+		Body:          bytes.NewReader(body),
+		ContentLength: aws.Int64(int64(len(body))),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload part %d - bucket: %s, key: %s: %w", partNumber, bucket, key, err)
+	}
+
+	return aws.ToString(out.ETag), nil
+}
+
+// PutObject uploads a small object held in memory
+func PutObject(ctx context.Context, bucket, key, contentType string, body []byte) error {
+	if awsClientS3 == nil {
+		return fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	_, err := awsClientS3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(body),
+		ContentLength: aws.Int64(int64(len(body))),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object to S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return nil
+}
+
+// GetObject downloads a small object into memory
+func GetObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+	defer out.Body.Close()
+
+	body, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return body, nil
+}
+
+// OpenObject opens an object for streaming without loading it into memory.
+// The caller must close the returned body.
+func OpenObject(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return out.Body, nil
+}
+
+// GetObjectRange downloads length bytes of an object starting at offset (HTTP Range request)
+func GetObjectRange(ctx context.Context, bucket, key string, offset, length int64) ([]byte, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	out, err := awsClientS3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object range from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+	defer out.Body.Close()
+
+	body, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object range from S3 - bucket: %s, key: %s: %w", bucket, key, err)
+	}
+
+	return body, nil
+}
+
+// ListedObject is an object returned by ListObjects
+type ListedObject struct {
+	Key          string
+	Size         int64
+	ETag         string
+	LastModified time.Time
+}
+
+// ObjectPage is one page of a ListObjectsV2 listing
+type ObjectPage struct {
+	Objects               []ListedObject
+	CommonPrefixes        []string
+	NextContinuationToken string // Empty on the last page
+}
+
+// ListObjects lists one page (up to 1000 keys) of the objects under prefix.
+// With a delimiter, keys sharing the part of the key up to the delimiter are grouped into CommonPrefixes.
+func ListObjects(ctx context.Context, bucket, prefix, delimiter, continuationToken string) (*ObjectPage, error) {
+	if awsClientS3 == nil {
+		return nil, fmt.Errorf("AWS S3 client not initialized - check AWS configuration or IS_LOCAL setting")
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}
+	if delimiter != "" {
+		input.Delimiter = aws.String(delimiter)
+	}
+	if continuationToken != "" {
+		input.ContinuationToken = aws.String(continuationToken)
+	}
+
+	out, err := awsClientS3.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects from S3 - bucket: %s, prefix: %s: %w", bucket, prefix, err)
+	}
+
+	page := &ObjectPage{
+		Objects:        make([]ListedObject, 0, len(out.Contents)),
+		CommonPrefixes: make([]string, 0, len(out.CommonPrefixes)),
+	}
+	for _, object := range out.Contents {
+		page.Objects = append(page.Objects, ListedObject{
+			Key:          aws.ToString(object.Key),
+			Size:         aws.ToInt64(object.Size),
+			ETag:         aws.ToString(object.ETag),
+			LastModified: aws.ToTime(object.LastModified),
+		})
+	}
+	for _, commonPrefix := range out.CommonPrefixes {
+		page.CommonPrefixes = append(page.CommonPrefixes, aws.ToString(commonPrefix.Prefix))
+	}
+	if aws.ToBool(out.IsTruncated) {
+		page.NextContinuationToken = aws.ToString(out.NextContinuationToken)
+	}
+
+	return page, nil
+}
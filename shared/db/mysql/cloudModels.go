@@ -9,7 +9,8 @@ import (
 // User extension (partial struct for GORM)
 type User struct {
 	ID           uint   `gorm:"primaryKey"`
-	StorageUsed  int64  `gorm:"default:0"`
+	StorageUsed  int64  `gorm:"default:0"` // Includes files in the trash
+	TrashUsed    int64  `gorm:"default:0"`
 	StorageLimit int64  `gorm:"default:16106127360"` // 15GB
 }
 
@@ -41,6 +41,9 @@ const (
 	ErrCodeResourceExists      = "RESOURCE_EXISTS"
 	ErrCodeResourceNotFound    = "RESOURCE_NOT_FOUND"
 	ErrCodeUnprocessableEntity = "UNPROCESSABLE_ENTITY"
+	ErrCodeGone                = "GONE"
+	ErrCodePreconditionFailed  = "PRECONDITION_FAILED"
+	ErrCodeQuotaExceeded       = "QUOTA_EXCEEDED"
 )
 
 // New creates a new AppError
@@ -122,3 +125,15 @@ func ResourceNotFound(resourceType string) *AppError {
 func UnprocessableEntity(message string) *AppError {
 	return New(ErrCodeUnprocessableEntity, message, http.StatusUnprocessableEntity)
 }
+
+func Gone(message string) *AppError {
+	return New(ErrCodeGone, message, http.StatusGone)
+}
+
+func PreconditionFailed(message string) *AppError {
+	return New(ErrCodePreconditionFailed, message, http.StatusPreconditionFailed)
+}
+
+func QuotaExceeded(message string) *AppError {
+	return New(ErrCodeQuotaExceeded, message, http.StatusRequestEntityTooLarge)
+}
@@ -131,6 +131,34 @@ func TestPredefinedErrors(t *testing.T) {
 			wantCode:   ErrCodeInternalServer,
 			wantStatus: http.StatusInternalServerError,
 		},
+		{
+			name:       "UnprocessableEntity",
+			errFunc:    UnprocessableEntity,
+			message:    "unprocessable",
+			wantCode:   ErrCodeUnprocessableEntity,
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:       "Gone",
+			errFunc:    Gone,
+			message:    "gone",
+			wantCode:   ErrCodeGone,
+			wantStatus: http.StatusGone,
+		},
+		{
+			name:       "PreconditionFailed",
+			errFunc:    PreconditionFailed,
+			message:    "precondition failed",
+			wantCode:   ErrCodePreconditionFailed,
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:       "QuotaExceeded",
+			errFunc:    QuotaExceeded,
+			message:    "quota exceeded",
+			wantCode:   ErrCodeQuotaExceeded,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
 	}
 
 	for _, tt := range tests {
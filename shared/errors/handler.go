@@ -89,6 +89,12 @@ func mapHTTPStatusToCode(status int) string {
 		return ErrCodeNotFound
 	case http.StatusConflict:
 		return ErrCodeConflict
+	case http.StatusGone:
+		return ErrCodeGone
+	case http.StatusPreconditionFailed:
+		return ErrCodePreconditionFailed
+	case http.StatusRequestEntityTooLarge:
+		return ErrCodeQuotaExceeded
 	case http.StatusUnprocessableEntity:
 		return ErrCodeUnprocessableEntity
 	case http.StatusTooManyRequests:
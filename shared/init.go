@@ -31,6 +31,8 @@ type InitConfig struct {
 	LogLevel string
 	// Environment (development, production)
 	Environment string
+	// TimeoutSkipper skips the request timeout for long-running requests (e.g. streaming uploads)
+	TimeoutSkipper echoMiddleware.Skipper
 }
 
 // Init initializes all shared components and returns configured Echo server
@@ -88,7 +90,7 @@ func Init(cfg *InitConfig) (*echo.Echo, error) {
 	logger.Info("Echo server initialized successfully")
 
 	// 6. Middleware initialization
-	if err := initMiddleware(e, appConfig); err != nil {
+	if err := initMiddleware(e, appConfig, cfg.TimeoutSkipper); err != nil {
 		return nil, fmt.Errorf("failed to initialize middleware: %w", err)
 	}
 	logger.Info("Middleware initialized successfully")
@@ -143,7 +145,7 @@ func initEchoServer(cfg *config.Config) *echo.Echo {
 }
 
 // initMiddleware initializes all middleware
-func initMiddleware(e *echo.Echo, cfg *config.Config) error {
+func initMiddleware(e *echo.Echo, cfg *config.Config, timeoutSkipper echoMiddleware.Skipper) error {
 	// Core middleware (order matters!)
 	e.Use(middleware.RequestID())
 	e.Use(middleware.Recovery())
@@ -157,6 +159,7 @@ func initMiddleware(e *echo.Echo, cfg *config.Config) error {
 
 	// Request timeout (30 seconds) - use Echo's built-in timeout middleware
 	e.Use(echoMiddleware.TimeoutWithConfig(echoMiddleware.TimeoutConfig{
+		Skipper: timeoutSkipper,
 		Timeout: 30 * time.Second,
 	}))
 
@@ -59,6 +59,7 @@ func CORS(allowedOrigins string, env string) echo.MiddlewareFunc {
 			echo.PUT,
 			echo.PATCH,
 			echo.DELETE,
+			echo.HEAD,
 			echo.OPTIONS,
 		},
 		AllowHeaders: []string{
@@ -67,9 +68,30 @@ func CORS(allowedOrigins string, env string) echo.MiddlewareFunc {
 			echo.HeaderAccept,
 			echo.HeaderAuthorization,
 			echo.HeaderXRequestID,
+			// tus resumable upload protocol
+			"Tus-Resumable",
+			"Upload-Length",
+			"Upload-Metadata",
+			"Upload-Offset",
+			// share link passwords
+			"X-Share-Password",
+			// optimistic concurrency on file updates
+			"If-Match",
+			"If-None-Match",
 		},
 		ExposeHeaders: []string{
 			echo.HeaderXRequestID,
+			// tus resumable upload protocol
+			echo.HeaderLocation,
+			"Tus-Resumable",
+			"Tus-Version",
+			"Tus-Extension",
+			"Tus-Max-Size",
+			"Upload-Length",
+			"Upload-Metadata",
+			"Upload-Offset",
+			// optimistic concurrency on file updates
+			"ETag",
 		},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours